@@ -2,11 +2,24 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/dana-team/platform-backend/src/customerrors"
 	"github.com/dana-team/platform-backend/src/utils"
 	"github.com/dana-team/platform-backend/src/utils/pagination"
 	"k8s.io/apimachinery/pkg/labels"
+	"slices"
+	"sort"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	knativeapis "knative.dev/pkg/apis"
+	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 
@@ -26,6 +39,27 @@ const (
 	disabledState = "disabled"
 	noRevision    = "No revision available"
 	dnsLimit      = 10
+
+	// knativeRevisionNameFormat is the format of the default names of the revisions of a Knative service.
+	knativeRevisionNameFormat = "%s-%05d"
+
+	metadataNameField = "metadata.name"
+
+	// changeCauseAnnotation records why a Capp was last deployed, the same one used by kubectl.
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+	defaultChangeCause    = "Deployed image %q to container %q"
+)
+
+var (
+	// restartedAtAnnotation is the annotation bumped to restart a Capp. The capp operator only copies the annotations
+	// of its API group to the template of the Knative service, so it is used instead of the one of kubectl rollout restart
+	// for changing it to create a new revision.
+	restartedAtAnnotation = cappv1alpha1.GroupVersion.Group + "/restartedAt"
+
+	// deployedByAnnotation and deployedAtAnnotation record who last deployed a Capp and when. The capp operator copies
+	// the annotations of its API group to the template of the Knative service, so redeploying the same image creates a new revision.
+	deployedByAnnotation = cappv1alpha1.GroupVersion.Group + "/deployed-by"
+	deployedAtAnnotation = cappv1alpha1.GroupVersion.Group + "/deployed-at"
 )
 
 const (
@@ -36,20 +70,39 @@ const (
 	ErrCouldNotUpdateCapp   = "Could not get capp %q in namespace %q"
 	ErrCouldNotDeleteCapp   = "Could not delete capp %q in namespace %q"
 	ErrParsingLabelSelector = "Could not parse labelSelector"
+	ErrCouldNotRollbackCapp = "Could not rollback capp %q in namespace %q"
+	ErrNoRevisionSpecified  = "Either revisionName or revisionNumber must be specified"
+	ErrRevisionNotFound     = "Capp revision number %d not found for capp %q in namespace %q"
+	ErrCouldNotPatchCapp    = "Could not patch capp %q in namespace %q"
+	ErrCappVersionConflict  = "Capp %q in namespace %q has been modified, resourceVersion is %q"
+	ErrUnsupportedPatchType = "Unsupported patch type %q, expected %q or %q"
+	ErrCouldNotApplyPatch   = "Could not apply patch"
+	ErrCouldNotWatchCapps   = "Could not watch capps in namespace %q"
+	ErrWatchNotSupported    = "Watching is not supported by the client"
+	ErrCouldNotRestartCapp  = "Could not restart capp %q in namespace %q"
+	ErrCappDisabled         = "Capp %q in namespace %q is disabled"
+	ErrCouldNotDeployCapp   = "Could not deploy capp %q in namespace %q"
+	ErrContainerNotInCapp   = "Container %q not found in capp %q in namespace %q"
+
+	ErrCouldNotUpdateCappTraffic = "Could not update traffic of capp %q in namespace %q"
+	ErrTrafficPercentSum         = "Traffic percentages must sum to 100, got %d"
+	ErrTrafficSplitNotSupported  = "Capp %q in namespace %q can route its traffic to a single revision only, got %d targets"
 )
 
 type CappController interface {
 	// CreateCapp creates a new Capp in the specified namespace.
-	CreateCapp(namespace string, capp types.CreateCapp) (types.Capp, error)
+	// If dryRun is set, the Capp is validated and defaulted by the API server without being persisted.
+	CreateCapp(namespace string, capp types.CreateCapp, dryRun bool) (types.Capp, error)
 
 	// GetCapps gets all Capps from a specific namespace.
-	GetCapps(namespace string, limit, page int, cappQuery types.CappQuery) (types.CappList, error)
+	GetCapps(namespace string, limit, page int, cappQuery types.CappQuery, listQuery types.ListQuery) (types.CappList, error)
 
 	// GetCapp gets a specific Capp from the specified namespace.
 	GetCapp(namespace, name string) (types.Capp, error)
 
 	// UpdateCapp updates a specific Capp in the specified namespace.
-	UpdateCapp(namespace, name string, capp types.UpdateCapp) (types.Capp, error)
+	// If dryRun is set, the Capp is validated and defaulted by the API server without being persisted.
+	UpdateCapp(namespace, name string, capp types.UpdateCapp, dryRun bool) (types.Capp, error)
 
 	// DeleteCapp deletes a specific Capp in the specified namespace.
 	DeleteCapp(namespace, name string) (types.CappError, error)
@@ -62,6 +115,51 @@ type CappController interface {
 
 	// GetCappDNS gets the dns records which are related to the Capp
 	GetCappDNS(namespace, name string) (types.GetDNSResponse, error)
+
+	// GetCappTraffic gets the traffic targets of a specific Capp as applied by Knative, along with their URLs.
+	GetCappTraffic(namespace, name string) (types.CappTraffic, error)
+
+	// UpdateCappTraffic routes the traffic of a specific Capp in the specified namespace to one of its CappRevisions.
+	// The route of a Capp holds a single traffic target, so splitting the traffic between several revisions is rejected.
+	UpdateCappTraffic(namespace, name string, request types.UpdateCappTrafficRequest) (types.CappTraffic, error)
+
+	// PatchCapp applies a JSON merge patch or a JSON patch to a specific Capp in the specified namespace.
+	PatchCapp(namespace, name string, request types.PatchCappRequest) (types.Capp, error)
+
+	// RollbackCapp restores a specific Capp in the specified namespace to a previous CappRevision.
+	RollbackCapp(namespace, name string, request types.RollbackCappRequest) (types.RollbackCappResponse, error)
+
+	// RestartCapp does a rolling restart of a specific Capp in the specified namespace by creating a new revision.
+	RestartCapp(namespace, name string) (types.RestartCappResponse, error)
+
+	// DeployCapp sets the image and environment variables of a container of a specific Capp in the specified namespace,
+	// and records the change cause, the user who deployed it and when in its annotations.
+	DeployCapp(namespace, name, username string, request types.DeployCappRequest) (types.DeployCappResponse, error)
+
+	// GetCappHistory gets the deployment history of a specific Capp in the specified namespace from its CappRevisions,
+	// with the newest first.
+	GetCappHistory(namespace, name string) (types.CappHistory, error)
+
+	// StartCappRollout starts a rollout of a specific Capp in the specified namespace to one of its CappRevisions. All the
+	// traffic is routed to the revision once it is ready, and the previous traffic target is restored if the revision fails.
+	StartCappRollout(namespace, name string, request types.StartCappRolloutRequest) (types.CappRollout, error)
+
+	// GetCappRollout gets the rollout of a specific Capp in the specified namespace, advancing it first if it is progressing.
+	GetCappRollout(namespace, name string) (types.CappRollout, error)
+
+	// AbortCappRollout aborts the progressing rollout of a specific Capp in the specified namespace and restores its previous traffic target.
+	AbortCappRollout(namespace, name string) (types.CappRollout, error)
+
+	// ResumeCappRollout resumes the failed or aborted rollout of a specific Capp in the specified namespace.
+	ResumeCappRollout(namespace, name string) (types.CappRollout, error)
+
+	// WatchCapps watches all Capps in the specified namespace and returns a channel of events
+	// carrying CappSummary objects. The channel is closed when the watch ends or the context is done.
+	WatchCapps(namespace string, cappQuery types.CappQuery) (<-chan types.CappWatchEvent, error)
+
+	// WatchCapp watches a specific Capp in the specified namespace and returns a channel of events
+	// carrying Capp objects. The channel is closed when the watch ends or the context is done.
+	WatchCapp(namespace, name string) (<-chan types.CappWatchEvent, error)
 }
 
 type cappController struct {
@@ -86,11 +184,16 @@ type CappPaginator struct {
 	cappQuery types.CappQuery
 }
 
-func (c *cappController) CreateCapp(namespace string, capp types.CreateCapp) (types.Capp, error) {
+func (c *cappController) CreateCapp(namespace string, capp types.CreateCapp, dryRun bool) (types.Capp, error) {
 	c.logger.Debug(fmt.Sprintf("Trying to create capp in namespace: %q", namespace))
 
+	var createOptions []client.CreateOption
+	if dryRun {
+		createOptions = append(createOptions, client.DryRunAll)
+	}
+
 	newCapp := createCappFromType(namespace, capp)
-	if err := c.client.Create(c.ctx, &newCapp); err != nil {
+	if err := c.client.Create(c.ctx, &newCapp, createOptions...); err != nil {
 		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotCreateCapp, capp.Metadata.Name, namespace), err.Error()))
 		return types.Capp{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotCreateCapp, capp.Metadata.Name, namespace), err)
 	}
@@ -101,8 +204,9 @@ func (c *cappController) CreateCapp(namespace string, capp types.CreateCapp) (ty
 func createCappFromV1Capp(capp cappv1alpha1.Capp) types.Capp {
 	return types.Capp{
 		Metadata: types.Metadata{
-			Name:      capp.Name,
-			Namespace: capp.Namespace,
+			Name:            capp.Name,
+			Namespace:       capp.Namespace,
+			ResourceVersion: capp.ResourceVersion,
 		},
 		Annotations: utils.ConvertMapToKeyValue(capp.Annotations),
 		Labels:      utils.ConvertMapToKeyValue(capp.Labels),
@@ -128,7 +232,7 @@ func createCappFromV1Capp(capp cappv1alpha1.Capp) types.Capp {
 	}
 }
 
-func (c *cappController) GetCapps(namespace string, limit, page int, cappQuery types.CappQuery) (types.CappList, error) {
+func (c *cappController) GetCapps(namespace string, limit, page int, cappQuery types.CappQuery, listQuery types.ListQuery) (types.CappList, error) {
 	c.logger.Debug(fmt.Sprintf("Trying to fetch all capps in namespace: %q", namespace))
 
 	cappPaginator := &CappPaginator{
@@ -138,22 +242,25 @@ func (c *cappController) GetCapps(namespace string, limit, page int, cappQuery t
 		cappQuery:        cappQuery,
 	}
 
-	cappList, err := pagination.FetchPage[cappv1alpha1.Capp](limit, page, cappPaginator)
+	var cappList []cappv1alpha1.Capp
+	var listMetadata types.ListMetadata
+	var err error
+	if cappQuery.State != "" || cappQuery.Site != "" {
+		cappList, listMetadata, err = pagination.FetchFilteredPage[cappv1alpha1.Capp](limit, page, cappPaginator, listQuery, func(capp *cappv1alpha1.Capp) bool {
+			return matchesCappQuery(*capp, cappQuery)
+		})
+	} else {
+		cappList, listMetadata, err = pagination.FetchPage[cappv1alpha1.Capp](limit, page, cappPaginator, listQuery)
+	}
 	if err != nil {
 		c.logger.Error(fmt.Sprintf("%v with error: %v", ErrCouldNotListCapps, err))
 		return types.CappList{}, customerrors.NewAPIError(ErrCouldNotListCapps, err)
 	}
 
-	result := types.CappList{}
+	result := types.CappList{ListMetadata: listMetadata}
 	for _, item := range cappList {
-		summary := types.CappSummary{
-			Name:   item.Name,
-			URL:    getCappURL(item),
-			Images: getCappImages(item),
-		}
-		result.Capps = append(result.Capps, summary)
+		result.Capps = append(result.Capps, convertCappToSummary(item))
 	}
-	result.Count = len(cappList)
 
 	return result, nil
 }
@@ -261,7 +368,114 @@ func prepareDNSListOptions(namespace, name string) *client.ListOptions {
 	return listOptions
 }
 
-func (c *cappController) UpdateCapp(namespace, name string, newCapp types.UpdateCapp) (types.Capp, error) {
+func (c *cappController) GetCappTraffic(namespace, name string) (types.CappTraffic, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to fetch traffic of capp %q in namespace %q", name, namespace))
+
+	capp := &cappv1alpha1.Capp{}
+	if err := c.client.Get(c.ctx, client.ObjectKey{Namespace: namespace, Name: name}, capp); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err.Error()))
+		return types.CappTraffic{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err)
+	}
+
+	traffic := types.CappTraffic{CappName: name, Targets: []types.TrafficTarget{}}
+	if capp.Status.KnativeObjectStatus.URL != nil {
+		traffic.URL = capp.Status.KnativeObjectStatus.URL.String()
+	}
+
+	for _, target := range capp.Status.KnativeObjectStatus.Traffic {
+		traffic.Targets = append(traffic.Targets, convertTrafficTargetToType(target))
+	}
+
+	return traffic, nil
+}
+
+func (c *cappController) UpdateCappTraffic(namespace, name string, request types.UpdateCappTrafficRequest) (types.CappTraffic, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to update traffic of capp %q in namespace %q", name, namespace))
+
+	var percentSum int64
+	for _, target := range request.Targets {
+		percentSum += target.Percent
+	}
+	if percentSum != 100 {
+		return types.CappTraffic{}, customerrors.NewValidationError(fmt.Sprintf(ErrTrafficPercentSum, percentSum))
+	}
+
+	capp := &cappv1alpha1.Capp{}
+	if err := c.client.Get(c.ctx, client.ObjectKey{Namespace: namespace, Name: name}, capp); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err.Error()))
+		return types.CappTraffic{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err)
+	}
+
+	cappRevisions := make([]cappv1alpha1.CappRevision, 0, len(request.Targets))
+	for _, target := range request.Targets {
+		cappRevision, err := c.getCappRevisionOfCapp(namespace, name, target.RevisionName)
+		if err != nil {
+			return types.CappTraffic{}, err
+		}
+		cappRevisions = append(cappRevisions, cappRevision)
+	}
+
+	if len(request.Targets) > 1 {
+		return types.CappTraffic{}, customerrors.NewValidationError(fmt.Sprintf(ErrTrafficSplitNotSupported, name, namespace, len(request.Targets)))
+	}
+
+	percent := request.Targets[0].Percent
+	capp.Spec.RouteSpec.TrafficTarget = knativev1.TrafficTarget{
+		RevisionName: knativeRevisionName(name, cappRevisions[0]),
+		Tag:          request.Targets[0].Tag,
+		Percent:      &percent,
+	}
+
+	if err := c.client.Update(c.ctx, capp); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotUpdateCappTraffic, name, namespace), err.Error()))
+		return types.CappTraffic{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotUpdateCappTraffic, name, namespace), err)
+	}
+
+	c.logger.Debug(fmt.Sprintf("Routed the traffic of capp %q in namespace %q to revision %q", name, namespace, cappRevisions[0].Name))
+	traffic := types.CappTraffic{CappName: name, Targets: []types.TrafficTarget{convertTrafficTargetToType(capp.Spec.RouteSpec.TrafficTarget)}}
+	if capp.Status.KnativeObjectStatus.URL != nil {
+		traffic.URL = capp.Status.KnativeObjectStatus.URL.String()
+	}
+
+	return traffic, nil
+}
+
+// getCappRevisionOfCapp gets a specific CappRevision using the CappRevision controller, and returns a not found error
+// if it does not belong to the given Capp.
+func (c *cappController) getCappRevisionOfCapp(namespace, cappName, name string) (cappv1alpha1.CappRevision, error) {
+	revisionController := &cappRevisionController{client: c.client, ctx: c.ctx, logger: c.logger}
+	cappRevision, err := revisionController.getCappRevision(namespace, name)
+	if err != nil {
+		return cappv1alpha1.CappRevision{}, err
+	}
+
+	if err := validateCappRevisionOfCapp(cappRevision, cappName); err != nil {
+		return cappv1alpha1.CappRevision{}, err
+	}
+
+	return cappRevision, nil
+}
+
+// convertTrafficTargetToType converts a Knative traffic target to its custom type representation.
+func convertTrafficTargetToType(target knativev1.TrafficTarget) types.TrafficTarget {
+	converted := types.TrafficTarget{
+		RevisionName:   target.RevisionName,
+		Tag:            target.Tag,
+		LatestRevision: target.LatestRevision != nil && *target.LatestRevision,
+	}
+
+	if target.Percent != nil {
+		converted.Percent = *target.Percent
+	}
+
+	if target.URL != nil {
+		converted.URL = target.URL.String()
+	}
+
+	return converted
+}
+
+func (c *cappController) UpdateCapp(namespace, name string, newCapp types.UpdateCapp, dryRun bool) (types.Capp, error) {
 	c.logger.Debug(fmt.Sprintf("Trying to update capp %q in namespace %q", name, namespace))
 
 	capp := &cappv1alpha1.Capp{}
@@ -275,7 +489,12 @@ func (c *cappController) UpdateCapp(namespace, name string, newCapp types.Update
 	capp.Labels = utils.ConvertKeyValueToMap(newCapp.Labels)
 	capp.Spec = newCapp.Spec
 
-	if err := c.client.Update(c.ctx, capp); err != nil {
+	var updateOptions []client.UpdateOption
+	if dryRun {
+		updateOptions = append(updateOptions, client.DryRunAll)
+	}
+
+	if err := c.client.Update(c.ctx, capp, updateOptions...); err != nil {
 		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotUpdateCapp, name, namespace), err.Error()))
 		return types.Capp{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotUpdateCapp, name, namespace), err)
 	}
@@ -283,6 +502,332 @@ func (c *cappController) UpdateCapp(namespace, name string, newCapp types.Update
 	return convertCappToType(*capp), nil
 }
 
+func (c *cappController) PatchCapp(namespace, name string, request types.PatchCappRequest) (types.Capp, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to patch capp %q in namespace %q", name, namespace))
+
+	capp := &cappv1alpha1.Capp{}
+	err := c.client.Get(c.ctx, client.ObjectKey{Namespace: namespace, Name: name}, capp)
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err.Error()))
+		return types.Capp{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err)
+	}
+
+	if len(request.ResourceVersions) > 0 && !slices.Contains(request.ResourceVersions, capp.ResourceVersion) {
+		return types.Capp{}, customerrors.NewConflictError(fmt.Sprintf(ErrCappVersionConflict, name, namespace, capp.ResourceVersion))
+	}
+
+	patchedCapp, err := applyCappPatch(*capp, request.PatchType, request.Patch)
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotPatchCapp, name, namespace), err.Error()))
+		return types.Capp{}, err
+	}
+
+	capp.Annotations = patchedCapp.Annotations
+	capp.Labels = patchedCapp.Labels
+	capp.Spec = patchedCapp.Spec
+
+	if err := c.client.Update(c.ctx, capp); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotPatchCapp, name, namespace), err.Error()))
+		if k8serrors.IsConflict(err) {
+			return types.Capp{}, customerrors.NewConflictError(fmt.Sprintf("%s, %v", fmt.Sprintf(ErrCouldNotPatchCapp, name, namespace), err))
+		}
+		return types.Capp{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotPatchCapp, name, namespace), err)
+	}
+
+	return convertCappToType(*capp), nil
+}
+
+// applyCappPatch applies a JSON merge patch (RFC 7386) or a JSON patch (RFC 6902)
+// to the JSON representation of a Capp and returns the patched Capp.
+func applyCappPatch(capp cappv1alpha1.Capp, patchType string, patch []byte) (cappv1alpha1.Capp, error) {
+	original, err := json.Marshal(capp)
+	if err != nil {
+		return cappv1alpha1.Capp{}, customerrors.NewInternalServerError(fmt.Sprintf("%s, %v", ErrCouldNotApplyPatch, err))
+	}
+
+	var patched []byte
+	switch k8stypes.PatchType(patchType) {
+	case k8stypes.MergePatchType:
+		patched, err = jsonpatch.MergePatch(original, patch)
+	case k8stypes.JSONPatchType:
+		var jsonPatch jsonpatch.Patch
+		if jsonPatch, err = jsonpatch.DecodePatch(patch); err == nil {
+			patched, err = jsonPatch.Apply(original)
+		}
+	default:
+		return cappv1alpha1.Capp{}, customerrors.NewValidationError(fmt.Sprintf(ErrUnsupportedPatchType, patchType, k8stypes.MergePatchType, k8stypes.JSONPatchType))
+	}
+	if err != nil {
+		return cappv1alpha1.Capp{}, customerrors.NewValidationError(fmt.Sprintf("%s, %v", ErrCouldNotApplyPatch, err))
+	}
+
+	patchedCapp := cappv1alpha1.Capp{}
+	if err := json.Unmarshal(patched, &patchedCapp); err != nil {
+		return cappv1alpha1.Capp{}, customerrors.NewValidationError(fmt.Sprintf("%s, %v", ErrCouldNotApplyPatch, err))
+	}
+
+	return patchedCapp, nil
+}
+
+func (c *cappController) RollbackCapp(namespace, name string, request types.RollbackCappRequest) (types.RollbackCappResponse, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to rollback capp %q in namespace %q", name, namespace))
+
+	if request.RevisionName == "" && request.RevisionNumber == 0 {
+		return types.RollbackCappResponse{}, customerrors.NewValidationError(ErrNoRevisionSpecified)
+	}
+
+	capp := &cappv1alpha1.Capp{}
+	err := c.client.Get(c.ctx, client.ObjectKey{Namespace: namespace, Name: name}, capp)
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err.Error()))
+		return types.RollbackCappResponse{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err)
+	}
+
+	cappRevision, err := c.getCappRevisionToRestore(namespace, name, request)
+	if err != nil {
+		return types.RollbackCappResponse{}, err
+	}
+
+	capp.Annotations = cappRevision.Spec.CappTemplate.Annotations
+	capp.Labels = cappRevision.Spec.CappTemplate.Labels
+	capp.Spec = cappRevision.Spec.CappTemplate.Spec
+
+	if err := c.client.Update(c.ctx, capp); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotRollbackCapp, name, namespace), err.Error()))
+		return types.RollbackCappResponse{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotRollbackCapp, name, namespace), err)
+	}
+
+	c.logger.Debug(fmt.Sprintf("Rolled back capp %q in namespace %q to revision %q", name, namespace, cappRevision.Name))
+	return types.RollbackCappResponse{
+		RevisionName:   cappRevision.Name,
+		RevisionNumber: cappRevision.Spec.RevisionNumber,
+		Capp:           convertCappToType(*capp),
+	}, nil
+}
+
+// getCappRevisionToRestore returns the CappRevision of the Capp matching the name or number in the request.
+func (c *cappController) getCappRevisionToRestore(namespace, cappName string, request types.RollbackCappRequest) (cappv1alpha1.CappRevision, error) {
+	if request.RevisionName != "" {
+		return c.getCappRevisionOfCapp(namespace, cappName, request.RevisionName)
+	}
+
+	cappRevisions, err := c.listCappRevisions(namespace, cappName)
+	if err != nil {
+		return cappv1alpha1.CappRevision{}, err
+	}
+
+	for _, cappRevision := range cappRevisions {
+		if cappRevision.Spec.RevisionNumber == request.RevisionNumber {
+			return cappRevision, nil
+		}
+	}
+
+	return cappv1alpha1.CappRevision{}, customerrors.NewNotFoundError(fmt.Sprintf(ErrRevisionNotFound, request.RevisionNumber, cappName, namespace))
+}
+
+// listCappRevisions lists all CappRevisions of a specific Capp in the specified namespace.
+func (c *cappController) listCappRevisions(namespace, cappName string) ([]cappv1alpha1.CappRevision, error) {
+	cappRevisions := &cappv1alpha1.CappRevisionList{}
+	selector, err := labels.Parse(fmt.Sprintf(utils.CappNameLabelSelector, cappName))
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("%s with error: %v", ErrParsingLabelSelector, err.Error()))
+		return nil, customerrors.NewValidationError(ErrParsingLabelSelector)
+	}
+
+	if err := c.client.List(c.ctx, cappRevisions, &client.ListOptions{Namespace: namespace, LabelSelector: selector}); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", ErrCouldNotListCappRevisions, err.Error()))
+		return nil, customerrors.NewAPIError(ErrCouldNotListCappRevisions, err)
+	}
+
+	return cappRevisions.Items, nil
+}
+
+func (c *cappController) RestartCapp(namespace, name string) (types.RestartCappResponse, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to restart capp %q in namespace %q", name, namespace))
+
+	capp := &cappv1alpha1.Capp{}
+	err := c.client.Get(c.ctx, client.ObjectKey{Namespace: namespace, Name: name}, capp)
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err.Error()))
+		return types.RestartCappResponse{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err)
+	}
+
+	if capp.Spec.State == disabledState {
+		return types.RestartCappResponse{}, customerrors.NewValidationError(fmt.Sprintf(ErrCappDisabled, name, namespace))
+	}
+
+	restartedAt := time.Now().UTC().Format(time.RFC3339Nano)
+	if capp.Annotations == nil {
+		capp.Annotations = map[string]string{}
+	}
+	capp.Annotations[restartedAtAnnotation] = restartedAt
+
+	if err := c.client.Update(c.ctx, capp); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotRestartCapp, name, namespace), err.Error()))
+		return types.RestartCappResponse{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotRestartCapp, name, namespace), err)
+	}
+
+	c.logger.Debug(fmt.Sprintf("Restarted capp %q in namespace %q", name, namespace))
+	return types.RestartCappResponse{
+		RestartedAt: restartedAt,
+		Capp:        convertCappToType(*capp),
+	}, nil
+}
+
+func (c *cappController) DeployCapp(namespace, name, username string, request types.DeployCappRequest) (types.DeployCappResponse, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to deploy image %q to capp %q in namespace %q", request.Image, name, namespace))
+
+	capp := &cappv1alpha1.Capp{}
+	if err := c.client.Get(c.ctx, client.ObjectKey{Namespace: namespace, Name: name}, capp); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err.Error()))
+		return types.DeployCappResponse{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err)
+	}
+
+	container, err := getCappContainer(capp, request.ContainerName)
+	if err != nil {
+		return types.DeployCappResponse{}, err
+	}
+
+	container.Image = request.Image
+	for _, env := range request.Env {
+		setContainerEnv(container, env.Key, env.Value)
+	}
+
+	changeCause := request.ChangeCause
+	if changeCause == "" {
+		changeCause = fmt.Sprintf(defaultChangeCause, request.Image, container.Name)
+	}
+
+	deployedAt := time.Now().UTC().Format(time.RFC3339Nano)
+	if capp.Annotations == nil {
+		capp.Annotations = map[string]string{}
+	}
+	capp.Annotations[changeCauseAnnotation] = changeCause
+	capp.Annotations[deployedByAnnotation] = username
+	capp.Annotations[deployedAtAnnotation] = deployedAt
+
+	if err := c.client.Update(c.ctx, capp); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotDeployCapp, name, namespace), err.Error()))
+		return types.DeployCappResponse{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotDeployCapp, name, namespace), err)
+	}
+
+	c.logger.Debug(fmt.Sprintf("Deployed image %q to container %q of capp %q in namespace %q", request.Image, container.Name, name, namespace))
+	return types.DeployCappResponse{
+		ChangeCause: changeCause,
+		DeployedBy:  username,
+		DeployedAt:  deployedAt,
+		Capp:        convertCappToType(*capp),
+	}, nil
+}
+
+// getCappContainer returns the container of the Capp with the given name, or its first container if the name is empty.
+func getCappContainer(capp *cappv1alpha1.Capp, containerName string) (*corev1.Container, error) {
+	containers := capp.Spec.ConfigurationSpec.Template.Spec.Containers
+	if containerName == "" && len(containers) > 0 {
+		return &containers[0], nil
+	}
+
+	for i := range containers {
+		if containers[i].Name == containerName {
+			return &containers[i], nil
+		}
+	}
+
+	return nil, customerrors.NewValidationError(fmt.Sprintf(ErrContainerNotInCapp, containerName, capp.Name, capp.Namespace))
+}
+
+// setContainerEnv sets the value of an environment variable of a container, replacing its previous value or source.
+func setContainerEnv(container *corev1.Container, name, value string) {
+	for i := range container.Env {
+		if container.Env[i].Name == name {
+			container.Env[i] = corev1.EnvVar{Name: name, Value: value}
+			return
+		}
+	}
+
+	container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: value})
+}
+
+func (c *cappController) GetCappHistory(namespace, name string) (types.CappHistory, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to fetch history of capp %q in namespace %q", name, namespace))
+
+	capp := &cappv1alpha1.Capp{}
+	if err := c.client.Get(c.ctx, client.ObjectKey{Namespace: namespace, Name: name}, capp); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err.Error()))
+		return types.CappHistory{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err)
+	}
+
+	cappRevisions, err := c.listCappRevisions(namespace, name)
+	if err != nil {
+		return types.CappHistory{}, err
+	}
+
+	sort.Slice(cappRevisions, func(i, j int) bool {
+		return cappRevisions[i].Spec.RevisionNumber > cappRevisions[j].Spec.RevisionNumber
+	})
+
+	history := types.CappHistory{CappName: name, Revisions: []types.CappHistoryEntry{}}
+	for i, cappRevision := range cappRevisions {
+		entry := convertCappRevisionToHistoryEntry(cappRevision)
+		entry.Serving = isRevisionTargeted(*capp, knativeRevisionName(name, cappRevision)) || (i == 0 && isLatestRevisionServing(*capp, cappRevision))
+		history.Revisions = append(history.Revisions, entry)
+	}
+
+	return history, nil
+}
+
+// knativeRevisionName returns the name of the Knative revision of a CappRevision. It is the name set in the revision
+// template of the CappRevision if any, and otherwise the name Knative gives by default to the revision of the same number.
+func knativeRevisionName(cappName string, cappRevision cappv1alpha1.CappRevision) string {
+	if templateName := cappRevision.Spec.CappTemplate.Spec.ConfigurationSpec.Template.Name; templateName != "" {
+		return templateName
+	}
+
+	return fmt.Sprintf(knativeRevisionNameFormat, cappName, cappRevision.Spec.RevisionNumber)
+}
+
+// isRevisionTargeted returns whether the Knative revision of the given name receives traffic of a Capp.
+func isRevisionTargeted(capp cappv1alpha1.Capp, revisionName string) bool {
+	for _, target := range capp.Status.KnativeObjectStatus.Traffic {
+		if target.RevisionName == revisionName && target.Percent != nil && *target.Percent > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isLatestRevisionServing returns whether the newest CappRevision of a Capp is serving traffic through the latest
+// revision of the Knative service, whose number may differ from the CappRevision's. It is serving when it matches
+// the Capp and the latest created revision of the Knative service is ready and receives traffic.
+func isLatestRevisionServing(capp cappv1alpha1.Capp, cappRevision cappv1alpha1.CappRevision) bool {
+	if !equality.Semantic.DeepEqual(capp.Spec, cappRevision.Spec.CappTemplate.Spec) {
+		return false
+	}
+
+	status := capp.Status.KnativeObjectStatus
+	if status.LatestCreatedRevisionName == "" || status.LatestCreatedRevisionName != status.LatestReadyRevisionName {
+		return false
+	}
+
+	return isRevisionTargeted(capp, status.LatestReadyRevisionName)
+}
+
+// convertCappRevisionToHistoryEntry converts a CappRevision to an entry of the history of its Capp,
+// taking the change cause and deployer from the annotations of the Capp it was created from.
+func convertCappRevisionToHistoryEntry(cappRevision cappv1alpha1.CappRevision) types.CappHistoryEntry {
+	annotations := cappRevision.Spec.CappTemplate.Annotations
+	return types.CappHistoryEntry{
+		RevisionName:   cappRevision.Name,
+		RevisionNumber: cappRevision.Spec.RevisionNumber,
+		CreatedAt:      cappRevision.CreationTimestamp.UTC().Format(time.RFC3339),
+		Images:         getCappImages(cappv1alpha1.Capp{Spec: cappRevision.Spec.CappTemplate.Spec}),
+		ChangeCause:    annotations[changeCauseAnnotation],
+		DeployedBy:     annotations[deployedByAnnotation],
+		DeployedAt:     annotations[deployedAtAnnotation],
+	}
+}
+
 func (c *cappController) EditCappState(namespace string, cappName string, state string) (types.CappStateReponse, error) {
 	c.logger.Debug(fmt.Sprintf("Trying to update capp %q in namespace %q", cappName, namespace))
 
@@ -321,6 +866,100 @@ func (c *cappController) DeleteCapp(namespace, name string) (types.CappError, er
 	}, nil
 }
 
+func (c *cappController) WatchCapps(namespace string, cappQuery types.CappQuery) (<-chan types.CappWatchEvent, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to watch capps in namespace %q", namespace))
+
+	selector, err := labels.Parse(cappQuery.LabelSelector)
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("%s with error: %v", ErrParsingLabelSelector, err.Error()))
+		return nil, customerrors.NewValidationError(ErrParsingLabelSelector)
+	}
+
+	listOptions := &client.ListOptions{Namespace: namespace, LabelSelector: selector}
+	return c.watchCapps(listOptions, func(capp cappv1alpha1.Capp) interface{} {
+		return convertCappToSummary(capp)
+	})
+}
+
+func (c *cappController) WatchCapp(namespace, name string) (<-chan types.CappWatchEvent, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to watch capp %q in namespace %q", name, namespace))
+
+	if _, err := c.GetCapp(namespace, name); err != nil {
+		return nil, err
+	}
+
+	listOptions := &client.ListOptions{Namespace: namespace, FieldSelector: fields.OneTermEqualSelector(metadataNameField, name)}
+	return c.watchCapps(listOptions, func(capp cappv1alpha1.Capp) interface{} {
+		return convertCappToType(capp)
+	})
+}
+
+// watchCapps opens a watch on Capps matching the given list options and converts every
+// event using convertFunc.
+func (c *cappController) watchCapps(listOptions *client.ListOptions, convertFunc func(cappv1alpha1.Capp) interface{}) (<-chan types.CappWatchEvent, error) {
+	watchClient, ok := c.client.(client.WithWatch)
+	if !ok {
+		return nil, customerrors.NewInternalServerError(ErrWatchNotSupported)
+	}
+
+	watcher, err := watchClient.Watch(c.ctx, &cappv1alpha1.CappList{}, listOptions)
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotWatchCapps, listOptions.Namespace), err.Error()))
+		return nil, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotWatchCapps, listOptions.Namespace), err)
+	}
+
+	events := make(chan types.CappWatchEvent)
+	go func() {
+		defer close(events)
+		defer watcher.Stop()
+
+		for {
+			select {
+			case <-c.ctx.Done():
+				return
+			case event, ok := <-watcher.ResultChan():
+				if !ok {
+					return
+				}
+
+				cappEvent, ok := convertCappWatchEvent(event, convertFunc)
+				if !ok {
+					continue
+				}
+
+				select {
+				case <-c.ctx.Done():
+					return
+				case events <- cappEvent:
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// convertCappWatchEvent converts a watch event of a Capp to a CappWatchEvent. It returns false
+// for events which should not be forwarded to the client, such as bookmarks.
+func convertCappWatchEvent(event watch.Event, convertFunc func(cappv1alpha1.Capp) interface{}) (types.CappWatchEvent, bool) {
+	cappEvent := types.CappWatchEvent{Type: string(event.Type)}
+
+	switch event.Type {
+	case watch.Added, watch.Modified, watch.Deleted:
+		capp, ok := event.Object.(*cappv1alpha1.Capp)
+		if !ok {
+			return types.CappWatchEvent{}, false
+		}
+		cappEvent.Object = convertFunc(*capp)
+	case watch.Error:
+		cappEvent.Error = k8serrors.FromObject(event.Object).Error()
+	default:
+		return types.CappWatchEvent{}, false
+	}
+
+	return cappEvent, true
+}
+
 // FetchList retrieves a list of capps from the specified namespace with given options.
 func (p *CappPaginator) FetchList(listOptions metav1.ListOptions) (*types.List[cappv1alpha1.Capp], error) {
 	cappList := &cappv1alpha1.CappList{}
@@ -367,8 +1006,9 @@ func createCappFromType(namespace string, capp types.CreateCapp) cappv1alpha1.Ca
 func convertCappToType(capp cappv1alpha1.Capp) types.Capp {
 	return types.Capp{
 		Metadata: types.Metadata{
-			Name:      capp.Name,
-			Namespace: capp.Namespace,
+			Name:            capp.Name,
+			Namespace:       capp.Namespace,
+			ResourceVersion: capp.ResourceVersion,
 		},
 		Annotations: utils.ConvertMapToKeyValue(capp.Annotations),
 		Labels:      utils.ConvertMapToKeyValue(capp.Labels),
@@ -394,6 +1034,39 @@ func convertCappToType(capp cappv1alpha1.Capp) types.Capp {
 	}
 }
 
+// convertCappToSummary converts a Capp to its summarized representation.
+func convertCappToSummary(capp cappv1alpha1.Capp) types.CappSummary {
+	summary := types.CappSummary{
+		Name:                capp.Name,
+		URL:                 getCappURL(capp),
+		Images:              getCappImages(capp),
+		State:               capp.Spec.State,
+		Site:                capp.Status.ApplicationLinks.Site,
+		LatestReadyRevision: capp.Status.KnativeObjectStatus.LatestReadyRevisionName,
+		ScaleMetric:         capp.Spec.ScaleMetric,
+	}
+
+	if readyCondition := capp.Status.KnativeObjectStatus.GetCondition(knativeapis.ConditionReady); readyCondition != nil {
+		summary.ReadyStatus = string(readyCondition.Status)
+		summary.ReadyReason = readyCondition.Reason
+	}
+
+	if !capp.CreationTimestamp.IsZero() {
+		summary.CreationTimestamp = capp.CreationTimestamp.UTC().Format(time.RFC3339)
+	}
+
+	return summary
+}
+
+// matchesCappQuery returns whether a Capp matches the state and site of the given query.
+func matchesCappQuery(capp cappv1alpha1.Capp, cappQuery types.CappQuery) bool {
+	if cappQuery.State != "" && capp.Spec.State != cappQuery.State {
+		return false
+	}
+
+	return cappQuery.Site == "" || capp.Status.ApplicationLinks.Site == cappQuery.Site
+}
+
 // getCappURL returns the URL of Capp; the shortened hostname is returned
 // if it exists, otherwise the default URL is returned.
 func getCappURL(capp cappv1alpha1.Capp) string {
@@ -0,0 +1,311 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/types"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/serving/pkg/apis/serving"
+	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"slices"
+	"time"
+)
+
+const (
+	// rolloutAnnotation records the progress of the rollout of a Capp. It is not in the API group of the capp operator,
+	// which copies the annotations of its group to the template of the Knative service, so that recording the progress
+	// does not create a new revision.
+	rolloutAnnotation = "platform.dana.io/rollout"
+
+	// rolloutMaxRestarts is the number of times the pods of the revision of a rollout may restart before it fails.
+	rolloutMaxRestarts = 3
+
+	rolloutWaitingMessage   = "Waiting for revision %q to become ready"
+	rolloutCompletedMessage = "Routed all traffic to revision %q"
+	rolloutFailedMessage    = "Revision %q failed: %s"
+	rolloutRestartsMessage  = "Pods of revision %q restarted %d times, more than the %d allowed"
+	rolloutAbortedMessage   = "Aborted, traffic restored to its previous target"
+)
+
+const (
+	ErrRolloutNotFound        = "No rollout found for capp %q in namespace %q"
+	ErrRolloutInProgress      = "Capp %q in namespace %q is already rolling out to revision %q"
+	ErrRolloutNotAbortable    = "Rollout of capp %q in namespace %q is %s, only a progressing rollout can be aborted"
+	ErrRolloutNotResumable    = "Rollout of capp %q in namespace %q is %s, only a failed or aborted rollout can be resumed"
+	ErrCouldNotParseRollout   = "Could not parse the rollout of capp %q in namespace %q"
+	ErrCouldNotUpdateRollout  = "Could not update the rollout of capp %q in namespace %q"
+	ErrCouldNotListRolloutPod = "Could not list the pods of revision %q in namespace %q"
+)
+
+func (c *cappController) StartCappRollout(namespace, name string, request types.StartCappRolloutRequest) (types.CappRollout, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to start a rollout of capp %q in namespace %q to revision %q", name, namespace, request.RevisionName))
+
+	capp, rollout, err := c.getCappWithRollout(namespace, name)
+	if err != nil {
+		return types.CappRollout{}, err
+	}
+
+	if rollout != nil && rollout.State == types.RolloutStateProgressing {
+		return types.CappRollout{}, customerrors.NewConflictError(fmt.Sprintf(ErrRolloutInProgress, name, namespace, rollout.RevisionName))
+	}
+
+	if capp.Spec.State == disabledState {
+		return types.CappRollout{}, customerrors.NewValidationError(fmt.Sprintf(ErrCappDisabled, name, namespace))
+	}
+
+	cappRevision, err := c.getCappRevisionOfCapp(namespace, name, request.RevisionName)
+	if err != nil {
+		return types.CappRollout{}, err
+	}
+
+	knativeName := knativeRevisionName(name, cappRevision)
+	restarts, err := c.countRevisionRestarts(namespace, knativeName)
+	if err != nil {
+		return types.CappRollout{}, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	rollout = &types.CappRollout{
+		RevisionName:        cappRevision.Name,
+		KnativeRevisionName: knativeName,
+		State:               types.RolloutStateProgressing,
+		Message:             fmt.Sprintf(rolloutWaitingMessage, knativeName),
+		PreviousTarget:      convertTrafficTargetToType(capp.Spec.RouteSpec.TrafficTarget),
+		RestartBaseline:     restarts,
+		StartedAt:           now,
+		UpdatedAt:           now,
+	}
+
+	return c.advanceAndSaveRollout(capp, rollout, true)
+}
+
+func (c *cappController) GetCappRollout(namespace, name string) (types.CappRollout, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to fetch the rollout of capp %q in namespace %q", name, namespace))
+
+	capp, rollout, err := c.getCappWithRollout(namespace, name)
+	if err != nil {
+		return types.CappRollout{}, err
+	}
+
+	if rollout == nil {
+		return types.CappRollout{}, customerrors.NewNotFoundError(fmt.Sprintf(ErrRolloutNotFound, name, namespace))
+	}
+
+	return c.advanceAndSaveRollout(capp, rollout, false)
+}
+
+func (c *cappController) AbortCappRollout(namespace, name string) (types.CappRollout, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to abort the rollout of capp %q in namespace %q", name, namespace))
+
+	capp, rollout, err := c.getCappWithRollout(namespace, name)
+	if err != nil {
+		return types.CappRollout{}, err
+	}
+
+	if rollout == nil {
+		return types.CappRollout{}, customerrors.NewNotFoundError(fmt.Sprintf(ErrRolloutNotFound, name, namespace))
+	}
+
+	if rollout.State != types.RolloutStateProgressing {
+		return types.CappRollout{}, customerrors.NewValidationError(fmt.Sprintf(ErrRolloutNotAbortable, name, namespace, rollout.State))
+	}
+
+	restorePreviousTarget(capp, rollout, types.RolloutStateAborted, rolloutAbortedMessage)
+	return c.saveRollout(capp, rollout, true)
+}
+
+func (c *cappController) ResumeCappRollout(namespace, name string) (types.CappRollout, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to resume the rollout of capp %q in namespace %q", name, namespace))
+
+	capp, rollout, err := c.getCappWithRollout(namespace, name)
+	if err != nil {
+		return types.CappRollout{}, err
+	}
+
+	if rollout == nil {
+		return types.CappRollout{}, customerrors.NewNotFoundError(fmt.Sprintf(ErrRolloutNotFound, name, namespace))
+	}
+
+	if !slices.Contains([]string{types.RolloutStateFailed, types.RolloutStateAborted}, rollout.State) {
+		return types.CappRollout{}, customerrors.NewValidationError(fmt.Sprintf(ErrRolloutNotResumable, name, namespace, rollout.State))
+	}
+
+	restarts, err := c.countRevisionRestarts(namespace, rollout.KnativeRevisionName)
+	if err != nil {
+		return types.CappRollout{}, err
+	}
+
+	rollout.State = types.RolloutStateProgressing
+	rollout.Message = fmt.Sprintf(rolloutWaitingMessage, rollout.KnativeRevisionName)
+	rollout.RestartBaseline = restarts
+	rollout.UpdatedAt = time.Now().UTC().Format(time.RFC3339Nano)
+
+	return c.advanceAndSaveRollout(capp, rollout, true)
+}
+
+// getCappWithRollout gets a specific Capp along with the rollout recorded in its annotation, which is nil if the
+// Capp was never rolled out.
+func (c *cappController) getCappWithRollout(namespace, name string) (*cappv1alpha1.Capp, *types.CappRollout, error) {
+	capp := &cappv1alpha1.Capp{}
+	if err := c.client.Get(c.ctx, client.ObjectKey{Namespace: namespace, Name: name}, capp); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err.Error()))
+		return nil, nil, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err)
+	}
+
+	rolloutJSON, ok := capp.Annotations[rolloutAnnotation]
+	if !ok {
+		return capp, nil, nil
+	}
+
+	rollout := &types.CappRollout{}
+	if err := json.Unmarshal([]byte(rolloutJSON), rollout); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotParseRollout, name, namespace), err.Error()))
+		return nil, nil, customerrors.NewInternalServerError(fmt.Sprintf(ErrCouldNotParseRollout, name, namespace))
+	}
+
+	return capp, rollout, nil
+}
+
+// advanceAndSaveRollout advances a progressing rollout and saves it if it changed or if save is set.
+func (c *cappController) advanceAndSaveRollout(capp *cappv1alpha1.Capp, rollout *types.CappRollout, save bool) (types.CappRollout, error) {
+	state := rollout.State
+	if err := c.advanceRollout(capp, rollout); err != nil {
+		return types.CappRollout{}, err
+	}
+
+	return c.saveRollout(capp, rollout, save || rollout.State != state)
+}
+
+// advanceRollout routes all the traffic of the Capp to the revision of a progressing rollout once the revision is ready,
+// and restores the previous traffic target if the revision failed or its pods restarted too many times.
+// The rollout keeps progressing while the revision is not ready yet.
+func (c *cappController) advanceRollout(capp *cappv1alpha1.Capp, rollout *types.CappRollout) error {
+	if rollout.State != types.RolloutStateProgressing {
+		return nil
+	}
+
+	restarts, err := c.countRevisionRestarts(capp.Namespace, rollout.KnativeRevisionName)
+	if err != nil {
+		return err
+	}
+
+	if restarts-rollout.RestartBaseline > rolloutMaxRestarts {
+		message := fmt.Sprintf(rolloutRestartsMessage, rollout.KnativeRevisionName, restarts-rollout.RestartBaseline, rolloutMaxRestarts)
+		restorePreviousTarget(capp, rollout, types.RolloutStateFailed, message)
+		return nil
+	}
+
+	if failure, failed := getRevisionFailure(*capp, rollout.KnativeRevisionName); failed {
+		restorePreviousTarget(capp, rollout, types.RolloutStateFailed, fmt.Sprintf(rolloutFailedMessage, rollout.KnativeRevisionName, failure))
+		return nil
+	}
+
+	if !isRevisionReady(*capp, rollout.KnativeRevisionName) {
+		return nil
+	}
+
+	percent := int64(100)
+	capp.Spec.RouteSpec.TrafficTarget = knativev1.TrafficTarget{RevisionName: rollout.KnativeRevisionName, Percent: &percent}
+	rollout.State = types.RolloutStateCompleted
+	rollout.Message = fmt.Sprintf(rolloutCompletedMessage, rollout.KnativeRevisionName)
+	rollout.UpdatedAt = time.Now().UTC().Format(time.RFC3339Nano)
+
+	return nil
+}
+
+// saveRollout records the rollout in the annotation of the Capp and updates the Capp if save is set.
+func (c *cappController) saveRollout(capp *cappv1alpha1.Capp, rollout *types.CappRollout, save bool) (types.CappRollout, error) {
+	if !save {
+		return *rollout, nil
+	}
+
+	rolloutJSON, err := json.Marshal(rollout)
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotUpdateRollout, capp.Name, capp.Namespace), err.Error()))
+		return types.CappRollout{}, customerrors.NewInternalServerError(fmt.Sprintf(ErrCouldNotUpdateRollout, capp.Name, capp.Namespace))
+	}
+
+	if capp.Annotations == nil {
+		capp.Annotations = map[string]string{}
+	}
+	capp.Annotations[rolloutAnnotation] = string(rolloutJSON)
+
+	if err := c.client.Update(c.ctx, capp); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotUpdateRollout, capp.Name, capp.Namespace), err.Error()))
+		return types.CappRollout{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotUpdateRollout, capp.Name, capp.Namespace), err)
+	}
+
+	c.logger.Debug(fmt.Sprintf("Rollout of capp %q in namespace %q to revision %q is %s", capp.Name, capp.Namespace, rollout.RevisionName, rollout.State))
+	return *rollout, nil
+}
+
+// countRevisionRestarts returns the number of times the containers of the pods of a Knative revision restarted.
+func (c *cappController) countRevisionRestarts(namespace, revisionName string) (int32, error) {
+	pods := &corev1.PodList{}
+	if err := c.client.List(c.ctx, pods, client.InNamespace(namespace), client.MatchingLabels{serving.RevisionLabelKey: revisionName}); err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotListRolloutPod, revisionName, namespace), err.Error()))
+		return 0, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotListRolloutPod, revisionName, namespace), err)
+	}
+
+	var restarts int32
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			restarts += status.RestartCount
+		}
+	}
+
+	return restarts, nil
+}
+
+// restorePreviousTarget routes the traffic of the Capp back to the target it had before the rollout and ends the rollout.
+func restorePreviousTarget(capp *cappv1alpha1.Capp, rollout *types.CappRollout, state, message string) {
+	capp.Spec.RouteSpec.TrafficTarget = convertTypeToTrafficTarget(rollout.PreviousTarget)
+	rollout.State = state
+	rollout.Message = message
+	rollout.UpdatedAt = time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+// isRevisionReady returns whether a Knative revision of a Capp is ready, which is known from the status of the Knative
+// service once it is the latest ready revision or once it receives traffic.
+func isRevisionReady(capp cappv1alpha1.Capp, revisionName string) bool {
+	return capp.Status.KnativeObjectStatus.LatestReadyRevisionName == revisionName || isRevisionTargeted(capp, revisionName)
+}
+
+// getRevisionFailure returns the reason a Knative revision of a Capp failed, if it is the latest created revision and
+// the configuration of the Knative service is not ready because of it.
+func getRevisionFailure(capp cappv1alpha1.Capp, revisionName string) (string, bool) {
+	status := capp.Status.KnativeObjectStatus
+	if status.LatestCreatedRevisionName != revisionName || status.LatestReadyRevisionName == revisionName {
+		return "", false
+	}
+
+	condition := status.GetCondition(knativev1.ServiceConditionConfigurationsReady)
+	if condition == nil || !condition.IsFalse() {
+		return "", false
+	}
+
+	return condition.Message, true
+}
+
+// convertTypeToTrafficTarget converts a traffic target from its custom type representation to a Knative traffic target.
+func convertTypeToTrafficTarget(target types.TrafficTarget) knativev1.TrafficTarget {
+	converted := knativev1.TrafficTarget{
+		RevisionName: target.RevisionName,
+		Tag:          target.Tag,
+	}
+
+	if target.Percent != 0 {
+		percent := target.Percent
+		converted.Percent = &percent
+	}
+
+	if target.LatestRevision {
+		latestRevision := true
+		converted.LatestRevision = &latestRevision
+	}
+
+	return converted
+}
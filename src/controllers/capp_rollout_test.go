@@ -0,0 +1,393 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
+	"testing"
+)
+
+func TestStartCappRollout(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-startRollout"
+	readyCappName := testutils.CappName + "-ready"
+	pendingCappName := testutils.CappName + "-pending"
+	failedCappName := testutils.CappName + "-failed"
+	progressingCappName := testutils.CappName + "-progressing"
+
+	type requestParams struct {
+		name    string
+		request types.StartCappRolloutRequest
+	}
+
+	type want struct {
+		state         string
+		message       string
+		trafficTarget knativev1.TrafficTarget
+		errorStatus   metav1.StatusReason
+	}
+
+	cases := map[string]struct {
+		requestParams requestParams
+		want          want
+	}{
+		"ShouldCompleteRolloutToReadyRevision": {
+			requestParams: requestParams{
+				name:    readyCappName,
+				request: types.StartCappRolloutRequest{RevisionName: readyCappName + "-v2"},
+			},
+			want: want{
+				state:         types.RolloutStateCompleted,
+				message:       fmt.Sprintf(rolloutCompletedMessage, readyCappName+"-00002"),
+				trafficTarget: prepareRolloutTrafficTarget(readyCappName + "-00002"),
+				errorStatus:   metav1.StatusSuccess,
+			},
+		},
+		"ShouldKeepProgressingUntilRevisionIsReady": {
+			requestParams: requestParams{
+				name:    pendingCappName,
+				request: types.StartCappRolloutRequest{RevisionName: pendingCappName + "-v2"},
+			},
+			want: want{
+				state:       types.RolloutStateProgressing,
+				message:     fmt.Sprintf(rolloutWaitingMessage, pendingCappName+"-00002"),
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldRestorePreviousTargetWhenRevisionFailed": {
+			requestParams: requestParams{
+				name:    failedCappName,
+				request: types.StartCappRolloutRequest{RevisionName: failedCappName + "-v2"},
+			},
+			want: want{
+				state:         types.RolloutStateFailed,
+				message:       fmt.Sprintf(rolloutFailedMessage, failedCappName+"-00002", testutils.RevisionFailedMessage),
+				trafficTarget: prepareRolloutTrafficTarget(failedCappName + "-00001"),
+				errorStatus:   metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailStartingRolloutWhileRolloutIsProgressing": {
+			requestParams: requestParams{
+				name:    progressingCappName,
+				request: types.StartCappRolloutRequest{RevisionName: progressingCappName + "-v2"},
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonConflict,
+			},
+		},
+		"ShouldFailStartingRolloutToRevisionOfAnotherCapp": {
+			requestParams: requestParams{
+				name:    testutils.CappName,
+				request: types.StartCappRolloutRequest{RevisionName: readyCappName + "-v2"},
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+		"ShouldFailStartingRolloutOfNonExistingCapp": {
+			requestParams: requestParams{
+				name:    testutils.CappName + testutils.NonExistentSuffix,
+				request: types.StartCappRolloutRequest{RevisionName: readyCappName + "-v2"},
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+
+	setup()
+	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
+	createTestNamespace(namespaceName, map[string]string{})
+
+	mocks.CreateTestCappWithServingRevision(dynClient, readyCappName, namespaceName, testutils.Domain, readyCappName+"-00002")
+	mocks.CreateTestCappRevisionOfCapp(dynClient, readyCappName+"-v2", namespaceName, readyCappName, testutils.Image, 2, nil)
+
+	mocks.CreateTestCappWithServingRevision(dynClient, pendingCappName, namespaceName, testutils.Domain, pendingCappName+"-00001")
+	mocks.CreateTestCappRevisionOfCapp(dynClient, pendingCappName+"-v2", namespaceName, pendingCappName, testutils.Image, 2, nil)
+
+	failedCapp := mocks.PrepareCappWithFailedRevision(failedCappName, namespaceName, testutils.Domain, failedCappName+"-00001", failedCappName+"-00002")
+	failedCapp.Spec.RouteSpec.TrafficTarget = prepareRolloutTrafficTarget(failedCappName + "-00001")
+	assert.NoError(t, dynClient.Create(context.TODO(), &failedCapp))
+	mocks.CreateTestCappRevisionOfCapp(dynClient, failedCappName+"-v2", namespaceName, failedCappName, testutils.Image, 2, nil)
+
+	mocks.CreateTestCapp(dynClient, testutils.CappName, namespaceName, testutils.Domain, nil, nil)
+	mocks.CreateTestCapp(dynClient, progressingCappName, namespaceName, testutils.Domain, nil, mocks.PrepareRolloutAnnotations(types.CappRollout{
+		RevisionName: progressingCappName + "-v1",
+		State:        types.RolloutStateProgressing,
+	}))
+	mocks.CreateTestCappRevisionOfCapp(dynClient, progressingCappName+"-v2", namespaceName, progressingCappName, testutils.Image, 2, nil)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := cappController.StartCappRollout(namespaceName, test.requestParams.name, test.requestParams.request)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+				assert.Equal(t, test.want.errorStatus, reason)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.requestParams.request.RevisionName, response.RevisionName)
+			assert.Equal(t, test.want.state, response.State)
+			assert.Equal(t, test.want.message, response.Message)
+			assertRolloutSaved(t, namespaceName, test.requestParams.name, response, test.want.trafficTarget)
+		})
+	}
+}
+
+func TestGetCappRollout(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-getRollout"
+	readyCappName := testutils.CappName + "-ready"
+	restartingCappName := testutils.CappName + "-restarting"
+	pendingCappName := testutils.CappName + "-pending"
+
+	type want struct {
+		state         string
+		message       string
+		trafficTarget knativev1.TrafficTarget
+		errorStatus   metav1.StatusReason
+	}
+
+	cases := map[string]struct {
+		cappName string
+		want     want
+	}{
+		"ShouldCompleteRolloutOnceRevisionIsReady": {
+			cappName: readyCappName,
+			want: want{
+				state:         types.RolloutStateCompleted,
+				message:       fmt.Sprintf(rolloutCompletedMessage, readyCappName+"-00002"),
+				trafficTarget: prepareRolloutTrafficTarget(readyCappName + "-00002"),
+				errorStatus:   metav1.StatusSuccess,
+			},
+		},
+		"ShouldRestorePreviousTargetWhenPodsRestart": {
+			cappName: restartingCappName,
+			want: want{
+				state:         types.RolloutStateFailed,
+				message:       fmt.Sprintf(rolloutRestartsMessage, restartingCappName+"-00002", rolloutMaxRestarts+1, rolloutMaxRestarts),
+				trafficTarget: prepareRolloutTrafficTarget(restartingCappName + "-00001"),
+				errorStatus:   metav1.StatusSuccess,
+			},
+		},
+		"ShouldKeepProgressingUntilRevisionIsReady": {
+			cappName: pendingCappName,
+			want: want{
+				state:         types.RolloutStateProgressing,
+				message:       fmt.Sprintf(rolloutWaitingMessage, pendingCappName+"-00002"),
+				trafficTarget: prepareRolloutTrafficTarget(pendingCappName + "-00001"),
+				errorStatus:   metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailGettingRolloutOfCappWithoutRollout": {
+			cappName: testutils.CappName,
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+		"ShouldFailGettingRolloutOfNonExistingCapp": {
+			cappName: testutils.CappName + testutils.NonExistentSuffix,
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+
+	setup()
+	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
+	createTestNamespace(namespaceName, map[string]string{})
+
+	createTestCappWithProgressingRollout(t, namespaceName, readyCappName, readyCappName+"-00002")
+	createTestCappWithProgressingRollout(t, namespaceName, restartingCappName, restartingCappName+"-00001")
+	mocks.CreateTestRevisionPod(dynClient, namespaceName, testutils.PodName+"-restarting", restartingCappName+"-00002", rolloutMaxRestarts+1)
+	createTestCappWithProgressingRollout(t, namespaceName, pendingCappName, pendingCappName+"-00001")
+	mocks.CreateTestCapp(dynClient, testutils.CappName, namespaceName, testutils.Domain, nil, nil)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := cappController.GetCappRollout(namespaceName, test.cappName)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+				assert.Equal(t, test.want.errorStatus, reason)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want.state, response.State)
+			assert.Equal(t, test.want.message, response.Message)
+			assertRolloutSaved(t, namespaceName, test.cappName, response, test.want.trafficTarget)
+		})
+	}
+}
+
+func TestAbortCappRollout(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-abortRollout"
+	progressingCappName := testutils.CappName + "-progressing"
+	completedCappName := testutils.CappName + "-completed"
+
+	type want struct {
+		trafficTarget knativev1.TrafficTarget
+		errorStatus   metav1.StatusReason
+	}
+
+	cases := map[string]struct {
+		cappName string
+		want     want
+	}{
+		"ShouldAbortProgressingRollout": {
+			cappName: progressingCappName,
+			want: want{
+				trafficTarget: prepareRolloutTrafficTarget(progressingCappName + "-00001"),
+				errorStatus:   metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailAbortingCompletedRollout": {
+			cappName: completedCappName,
+			want: want{
+				errorStatus: metav1.StatusReasonBadRequest,
+			},
+		},
+		"ShouldFailAbortingRolloutOfCappWithoutRollout": {
+			cappName: testutils.CappName,
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+
+	setup()
+	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
+	createTestNamespace(namespaceName, map[string]string{})
+
+	createTestCappWithProgressingRollout(t, namespaceName, progressingCappName, progressingCappName+"-00001")
+	mocks.CreateTestCapp(dynClient, completedCappName, namespaceName, testutils.Domain, nil, mocks.PrepareRolloutAnnotations(types.CappRollout{
+		RevisionName: completedCappName + "-v2",
+		State:        types.RolloutStateCompleted,
+	}))
+	mocks.CreateTestCapp(dynClient, testutils.CappName, namespaceName, testutils.Domain, nil, nil)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := cappController.AbortCappRollout(namespaceName, test.cappName)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+				assert.Equal(t, test.want.errorStatus, reason)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, types.RolloutStateAborted, response.State)
+			assert.Equal(t, rolloutAbortedMessage, response.Message)
+			assertRolloutSaved(t, namespaceName, test.cappName, response, test.want.trafficTarget)
+		})
+	}
+}
+
+func TestResumeCappRollout(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-resumeRollout"
+	failedCappName := testutils.CappName + "-failed"
+	progressingCappName := testutils.CappName + "-progressing"
+
+	type want struct {
+		state         string
+		trafficTarget knativev1.TrafficTarget
+		errorStatus   metav1.StatusReason
+	}
+
+	cases := map[string]struct {
+		cappName string
+		want     want
+	}{
+		"ShouldResumeFailedRolloutOnceRestartsStop": {
+			cappName: failedCappName,
+			want: want{
+				state:         types.RolloutStateCompleted,
+				trafficTarget: prepareRolloutTrafficTarget(failedCappName + "-00002"),
+				errorStatus:   metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailResumingProgressingRollout": {
+			cappName: progressingCappName,
+			want: want{
+				errorStatus: metav1.StatusReasonBadRequest,
+			},
+		},
+		"ShouldFailResumingRolloutOfNonExistingCapp": {
+			cappName: testutils.CappName + testutils.NonExistentSuffix,
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+
+	setup()
+	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
+	createTestNamespace(namespaceName, map[string]string{})
+
+	failedCapp := mocks.PrepareCappWithServingRevision(failedCappName, namespaceName, testutils.Domain, failedCappName+"-00002")
+	failedCapp.Annotations = mocks.PrepareRolloutAnnotations(types.CappRollout{
+		RevisionName:        failedCappName + "-v2",
+		KnativeRevisionName: failedCappName + "-00002",
+		State:               types.RolloutStateFailed,
+	})
+	assert.NoError(t, dynClient.Create(context.TODO(), &failedCapp))
+	mocks.CreateTestRevisionPod(dynClient, namespaceName, testutils.PodName+"-failed", failedCappName+"-00002", rolloutMaxRestarts+1)
+	createTestCappWithProgressingRollout(t, namespaceName, progressingCappName, progressingCappName+"-00001")
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := cappController.ResumeCappRollout(namespaceName, test.cappName)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+				assert.Equal(t, test.want.errorStatus, reason)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want.state, response.State)
+			assert.Equal(t, int32(rolloutMaxRestarts+1), response.RestartBaseline)
+			assertRolloutSaved(t, namespaceName, test.cappName, response, test.want.trafficTarget)
+		})
+	}
+}
+
+// createTestCappWithProgressingRollout creates a Capp whose route targets the given serving revision and
+// which is rolling out to the revision numbered 2.
+func createTestCappWithProgressingRollout(t *testing.T, namespace, cappName, servingRevisionName string) {
+	capp := mocks.PrepareCappWithServingRevision(cappName, namespace, testutils.Domain, servingRevisionName)
+	capp.Spec.RouteSpec.TrafficTarget = prepareRolloutTrafficTarget(cappName + "-00001")
+	capp.Annotations = mocks.PrepareRolloutAnnotations(types.CappRollout{
+		RevisionName:        cappName + "-v2",
+		KnativeRevisionName: cappName + "-00002",
+		State:               types.RolloutStateProgressing,
+		Message:             fmt.Sprintf(rolloutWaitingMessage, cappName+"-00002"),
+		PreviousTarget:      types.TrafficTarget{RevisionName: cappName + "-00001", Percent: 100},
+	})
+	assert.NoError(t, dynClient.Create(context.TODO(), &capp))
+}
+
+// prepareRolloutTrafficTarget returns a traffic target routing all traffic to the given revision.
+func prepareRolloutTrafficTarget(revisionName string) knativev1.TrafficTarget {
+	percent := int64(100)
+	return knativev1.TrafficTarget{RevisionName: revisionName, Percent: &percent}
+}
+
+// assertRolloutSaved asserts that the rollout is recorded in the annotation of the Capp and that the route of the
+// Capp has the given traffic target.
+func assertRolloutSaved(t *testing.T, namespace, cappName string, rollout types.CappRollout, trafficTarget knativev1.TrafficTarget) {
+	capp := &cappv1alpha1.Capp{}
+	assert.NoError(t, dynClient.Get(context.TODO(), k8stypes.NamespacedName{Namespace: namespace, Name: cappName}, capp))
+	assert.Equal(t, trafficTarget, capp.Spec.RouteSpec.TrafficTarget)
+
+	var savedRollout types.CappRollout
+	assert.NoError(t, json.Unmarshal([]byte(capp.Annotations[testutils.RolloutAnnotation]), &savedRollout))
+	assert.Equal(t, rollout, savedRollout)
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
 	"github.com/dana-team/platform-backend/src/customerrors"
@@ -11,10 +12,16 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
 	"strconv"
 	"testing"
+	"time"
 )
 
+const watchTimeout = 5 * time.Second
+
 func TestGetCapp(t *testing.T) {
 	namespaceName := testutils.CappNamespace + "-get"
 
@@ -39,7 +46,7 @@ func TestGetCapp(t *testing.T) {
 			},
 			want: want{
 				capp: types.Capp{
-					Metadata: mocks.PrepareCappMetadata(testutils.CappName+"-1", namespaceName),
+					Metadata: mocks.PrepareCappMetadataWithResourceVersion(testutils.CappName+"-1", namespaceName, "1"),
 					Spec:     mocks.PrepareCappSpec(),
 					Status:   mocks.PrepareCappStatus(testutils.CappName+"-1", namespaceName, testutils.Domain),
 					Labels:   []types.KeyValue{{Key: testutils.LabelKey + "-1", Value: testutils.LabelValue + "-1"}},
@@ -180,6 +187,203 @@ func TestGetCappState(t *testing.T) {
 
 }
 
+func TestGetCappTraffic(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-getTraffic"
+	splitCappName := testutils.CappName + "-split"
+
+	type want struct {
+		traffic     types.CappTraffic
+		errorStatus metav1.StatusReason
+	}
+
+	cases := map[string]struct {
+		cappName string
+		want     want
+	}{
+		"ShouldSucceedGettingSplitTraffic": {
+			cappName: splitCappName,
+			want: want{
+				traffic: types.CappTraffic{
+					CappName: splitCappName,
+					URL:      fmt.Sprintf("https://%s-%s.%s", splitCappName, namespaceName, testutils.Domain),
+					Targets: []types.TrafficTarget{
+						{
+							RevisionName:   splitCappName + "-00002",
+							Percent:        100 - testutils.PreviousRevisionPercent,
+							LatestRevision: true,
+						},
+						{
+							RevisionName: splitCappName + "-00001",
+							Tag:          testutils.TrafficTag,
+							Percent:      testutils.PreviousRevisionPercent,
+							URL:          fmt.Sprintf("https://%s-%s-%s.%s", testutils.TrafficTag, splitCappName, namespaceName, testutils.Domain),
+						},
+					},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedGettingTrafficOfCappWithoutRoute": {
+			cappName: testutils.CappName,
+			want: want{
+				traffic: types.CappTraffic{
+					CappName: testutils.CappName,
+					URL:      fmt.Sprintf("https://%s-%s.%s", testutils.CappName, namespaceName, testutils.Domain),
+					Targets:  []types.TrafficTarget{},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailGettingNonExistingCapp": {
+			cappName: testutils.CappName + testutils.NonExistentSuffix,
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+
+	setup()
+	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
+	createTestNamespace(namespaceName, map[string]string{})
+	mocks.CreateTestCappWithTraffic(dynClient, splitCappName, namespaceName, testutils.Domain, testutils.TrafficTag)
+	mocks.CreateTestCapp(dynClient, testutils.CappName, namespaceName, testutils.Domain, nil, nil)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := cappController.GetCappTraffic(namespaceName, test.cappName)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+				assert.Equal(t, test.want.errorStatus, reason)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want.traffic, response)
+		})
+	}
+}
+
+func TestUpdateCappTraffic(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-updateTraffic"
+	firstRevisionName := testutils.CappRevisionName + "-1"
+	secondRevisionName := testutils.CappRevisionName + "-2"
+	otherRevisionName := testutils.CappRevisionName + "-other"
+
+	type requestParams struct {
+		name    string
+		request types.UpdateCappTrafficRequest
+	}
+
+	type want struct {
+		traffic     types.CappTraffic
+		errorStatus metav1.StatusReason
+	}
+
+	cases := map[string]struct {
+		requestParams requestParams
+		want          want
+	}{
+		"ShouldSucceedRoutingTrafficToRevision": {
+			requestParams: requestParams{
+				name: testutils.CappName,
+				request: types.UpdateCappTrafficRequest{Targets: []types.CappTrafficTargetRequest{
+					{RevisionName: firstRevisionName, Percent: 100, Tag: testutils.TrafficTag},
+				}},
+			},
+			want: want{
+				traffic: types.CappTraffic{
+					CappName: testutils.CappName,
+					URL:      fmt.Sprintf("https://%s-%s.%s", testutils.CappName, namespaceName, testutils.Domain),
+					Targets: []types.TrafficTarget{
+						{RevisionName: testutils.CappName + "-00001", Tag: testutils.TrafficTag, Percent: 100},
+					},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailWithPercentagesNotSummingTo100": {
+			requestParams: requestParams{
+				name: testutils.CappName,
+				request: types.UpdateCappTrafficRequest{Targets: []types.CappTrafficTargetRequest{
+					{RevisionName: firstRevisionName, Percent: 90},
+				}},
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonBadRequest,
+			},
+		},
+		"ShouldFailSplittingTrafficBetweenRevisions": {
+			requestParams: requestParams{
+				name: testutils.CappName,
+				request: types.UpdateCappTrafficRequest{Targets: []types.CappTrafficTargetRequest{
+					{RevisionName: secondRevisionName, Percent: 100 - testutils.PreviousRevisionPercent},
+					{RevisionName: firstRevisionName, Percent: testutils.PreviousRevisionPercent},
+				}},
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonBadRequest,
+			},
+		},
+		"ShouldFailRoutingTrafficToNonExistingRevision": {
+			requestParams: requestParams{
+				name: testutils.CappName,
+				request: types.UpdateCappTrafficRequest{Targets: []types.CappTrafficTargetRequest{
+					{RevisionName: firstRevisionName + testutils.NonExistentSuffix, Percent: 100},
+				}},
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+		"ShouldFailRoutingTrafficToRevisionOfAnotherCapp": {
+			requestParams: requestParams{
+				name: testutils.CappName,
+				request: types.UpdateCappTrafficRequest{Targets: []types.CappTrafficTargetRequest{
+					{RevisionName: otherRevisionName, Percent: 100},
+				}},
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+		"ShouldFailUpdatingTrafficOfNonExistingCapp": {
+			requestParams: requestParams{
+				name: testutils.CappName + testutils.NonExistentSuffix,
+				request: types.UpdateCappTrafficRequest{Targets: []types.CappTrafficTargetRequest{
+					{RevisionName: firstRevisionName, Percent: 100},
+				}},
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+
+	setup()
+	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
+	createTestNamespace(namespaceName, map[string]string{})
+	mocks.CreateTestCapp(dynClient, testutils.CappName, namespaceName, testutils.Domain, nil, nil)
+	mocks.CreateTestCappRevisionOfCapp(dynClient, firstRevisionName, namespaceName, testutils.CappName, testutils.Image, 1, nil)
+	mocks.CreateTestCappRevisionOfCapp(dynClient, secondRevisionName, namespaceName, testutils.CappName, testutils.Image, 2, nil)
+	mocks.CreateTestCappRevisionOfCapp(dynClient, otherRevisionName, namespaceName, testutils.CappName+"-other", testutils.Image, 1, nil)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := cappController.UpdateCappTraffic(namespaceName, test.requestParams.name, test.requestParams.request)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+				assert.Equal(t, test.want.errorStatus, reason)
+			} else {
+				assert.NoError(t, err)
+
+				capp := cappv1alpha1.Capp{}
+				assert.NoError(t, dynClient.Get(context.TODO(), k8stypes.NamespacedName{Namespace: namespaceName, Name: test.requestParams.name}, &capp))
+				assert.Equal(t, test.want.traffic.Targets[0].RevisionName, capp.Spec.RouteSpec.TrafficTarget.RevisionName)
+			}
+			assert.Equal(t, test.want.traffic, response)
+		})
+	}
+}
+
 func TestGetCappDNS(t *testing.T) {
 	namespaceName := testutils.CappNamespace + "-getDNS"
 
@@ -342,6 +546,7 @@ func TestGetCapps(t *testing.T) {
 
 	type requestParams struct {
 		cappQuery types.CappQuery
+		listQuery types.ListQuery
 		namespace string
 		limit     int
 		page      int
@@ -366,7 +571,7 @@ func TestGetCapps(t *testing.T) {
 				cappList: types.CappList{ListMetadata: types.ListMetadata{Count: 2}, Capps: []types.CappSummary{
 					mocks.PrepareCappSummary(testutils.CappName+"-1", namespaceName),
 
-					mocks.PrepareCappSummary(testutils.CappName+"-2", namespaceName),
+					mocks.PrepareCappSummaryWithSite(testutils.CappName+"-2", namespaceName, testutils.Site),
 				},
 				},
 			},
@@ -378,12 +583,112 @@ func TestGetCapps(t *testing.T) {
 			},
 			want: want{
 				cappList: types.CappList{ListMetadata: types.ListMetadata{Count: 1}, Capps: []types.CappSummary{
-					mocks.PrepareCappSummary(testutils.CappName+"-2", namespaceName),
+					mocks.PrepareCappSummaryWithSite(testutils.CappName+"-2", namespaceName, testutils.Site),
+				},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedGettingCappBySite": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				cappQuery: types.CappQuery{Site: testutils.Site},
+			},
+			want: want{
+				cappList: types.CappList{ListMetadata: types.ListMetadata{Count: 1}, Capps: []types.CappSummary{
+					mocks.PrepareCappSummaryWithSite(testutils.CappName+"-2", namespaceName, testutils.Site),
+				},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedGettingCappsByState": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				cappQuery: types.CappQuery{State: testutils.EnabledState},
+			},
+			want: want{
+				cappList: types.CappList{ListMetadata: types.ListMetadata{Count: 2}, Capps: []types.CappSummary{
+					mocks.PrepareCappSummary(testutils.CappName+"-1", namespaceName),
+					mocks.PrepareCappSummaryWithSite(testutils.CappName+"-2", namespaceName, testutils.Site),
+				},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedGettingSecondPageOfCappsByState": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				cappQuery: types.CappQuery{State: testutils.EnabledState},
+				limit:     1,
+				page:      2,
+			},
+			want: want{
+				cappList: types.CappList{ListMetadata: types.ListMetadata{Count: 1}, Capps: []types.CappSummary{
+					mocks.PrepareCappSummaryWithSite(testutils.CappName+"-2", namespaceName, testutils.Site),
+				},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailGettingCappsByStateWithContinue": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				cappQuery: types.CappQuery{State: testutils.EnabledState},
+				listQuery: types.ListQuery{Continue: "token"},
+			},
+			want: want{
+				cappList:    types.CappList{},
+				errorStatus: metav1.StatusReasonBadRequest,
+			},
+		},
+		"ShouldSucceedGettingNoCappsByState": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				cappQuery: types.CappQuery{State: testutils.DisabledState},
+			},
+			want: want{
+				cappList:    types.CappList{},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedGettingCappsSortedByNameDescending": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				listQuery: types.ListQuery{SortBy: pagination.SortByName, Order: pagination.OrderDesc},
+			},
+			want: want{
+				cappList: types.CappList{ListMetadata: types.ListMetadata{Count: 2}, Capps: []types.CappSummary{
+					mocks.PrepareCappSummaryWithSite(testutils.CappName+"-2", namespaceName, testutils.Site),
+					mocks.PrepareCappSummary(testutils.CappName+"-1", namespaceName),
+				},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedGettingCappsBySearchAndFieldSelector": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				listQuery: types.ListQuery{Search: testutils.CappName, FieldSelector: fmt.Sprintf("%s!=%s-2", testutils.MetadataNameField, testutils.CappName)},
+			},
+			want: want{
+				cappList: types.CappList{ListMetadata: types.ListMetadata{Count: 1}, Capps: []types.CappSummary{
+					mocks.PrepareCappSummary(testutils.CappName+"-1", namespaceName),
 				},
 				},
 				errorStatus: metav1.StatusSuccess,
 			},
 		},
+		"ShouldFailGettingCappsWithInvalidFieldSelector": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				listQuery: types.ListQuery{FieldSelector: testutils.InvalidFieldSelector},
+			},
+			want: want{
+				cappList:    types.CappList{},
+				errorStatus: metav1.StatusReasonBadRequest,
+			},
+		},
 		"ShouldFailGettingCappsWithInvalidSelector": {
 			requestParams: requestParams{
 				namespace: namespaceName,
@@ -409,7 +714,7 @@ func TestGetCapps(t *testing.T) {
 
 	createTestNamespace(namespaceName, map[string]string{})
 	mocks.CreateTestCapp(dynClient, testutils.CappName+"-1", namespaceName, testutils.Domain, map[string]string{testutils.LabelKey + "-1": testutils.LabelValue + "-1"}, map[string]string{})
-	mocks.CreateTestCapp(dynClient, testutils.CappName+"-2", namespaceName, testutils.Domain, map[string]string{testutils.LabelKey + "-2": testutils.LabelValue + "-2"}, map[string]string{})
+	mocks.CreateTestCappWithSite(dynClient, testutils.CappName+"-2", namespaceName, testutils.Site, testutils.Domain, map[string]string{testutils.LabelKey + "-2": testutils.LabelValue + "-2"}, map[string]string{})
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
 			c := mocks.GinContext()
@@ -417,7 +722,7 @@ func TestGetCapps(t *testing.T) {
 			cappController := NewCappController(dynClient, c, logger)
 
 			limit, page, _ := pagination.ExtractPaginationParamsFromCtx(c)
-			response, err := cappController.GetCapps(test.requestParams.namespace, limit, page, test.requestParams.cappQuery)
+			response, err := cappController.GetCapps(test.requestParams.namespace, limit, page, test.requestParams.cappQuery, test.requestParams.listQuery)
 			if test.want.errorStatus != metav1.StatusSuccess {
 				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
 
@@ -435,6 +740,7 @@ func TestCreateCapp(t *testing.T) {
 	type requestParams struct {
 		capp      types.CreateCapp
 		namespace string
+		dryRun    bool
 	}
 
 	type want struct {
@@ -452,7 +758,7 @@ func TestCreateCapp(t *testing.T) {
 			},
 			want: want{
 				response: types.Capp{
-					Metadata: mocks.PrepareCappMetadata(testutils.CappName+"-2", namespaceName),
+					Metadata: mocks.PrepareCappMetadataWithResourceVersion(testutils.CappName+"-2", namespaceName, "1"),
 					Spec:     mocks.PrepareCappSpec(),
 					Status:   cappv1alpha1.CappStatus{},
 					Labels:   []types.KeyValue{{Key: testutils.LabelKey + "-2", Value: testutils.LabelValue + "-2"}},
@@ -460,6 +766,22 @@ func TestCreateCapp(t *testing.T) {
 				errorStatus: metav1.StatusSuccess,
 			},
 		},
+		"ShouldSucceedCreatingCappInDryRun": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				capp:      mocks.PrepareCreateCappType(testutils.CappName+"-3", []types.KeyValue{{Key: testutils.LabelKey + "-3", Value: testutils.LabelValue + "-3"}}, nil),
+				dryRun:    true,
+			},
+			want: want{
+				response: types.Capp{
+					Metadata: mocks.PrepareCappMetadata(testutils.CappName+"-3", namespaceName),
+					Spec:     mocks.PrepareCappSpec(),
+					Status:   cappv1alpha1.CappStatus{},
+					Labels:   []types.KeyValue{{Key: testutils.LabelKey + "-3", Value: testutils.LabelValue + "-3"}},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
 		"ShouldFailCreatingExistingCapp": {
 			requestParams: requestParams{
 				namespace: namespaceName,
@@ -478,7 +800,7 @@ func TestCreateCapp(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			response, err := cappController.CreateCapp(test.requestParams.namespace, test.requestParams.capp)
+			response, err := cappController.CreateCapp(test.requestParams.namespace, test.requestParams.capp, test.requestParams.dryRun)
 			if test.want.errorStatus != metav1.StatusSuccess {
 				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
 
@@ -487,6 +809,11 @@ func TestCreateCapp(t *testing.T) {
 				assert.NoError(t, err)
 			}
 			assert.Equal(t, test.want.response, response)
+
+			if test.requestParams.dryRun && err == nil {
+				_, err := cappController.GetCapp(test.requestParams.namespace, test.requestParams.capp.Metadata.Name)
+				assert.Equal(t, metav1.StatusReasonNotFound, err.(customerrors.ErrorWithStatusCode).StatusReason())
+			}
 		})
 	}
 }
@@ -497,6 +824,7 @@ func TestUpdateCapp(t *testing.T) {
 		name      string
 		capp      types.UpdateCapp
 		namespace string
+		dryRun    bool
 	}
 
 	type want struct {
@@ -515,7 +843,7 @@ func TestUpdateCapp(t *testing.T) {
 			},
 			want: want{
 				response: types.Capp{
-					Metadata: mocks.PrepareCappMetadata(testutils.CappName+"-1", namespaceName),
+					Metadata: mocks.PrepareCappMetadataWithResourceVersion(testutils.CappName+"-1", namespaceName, "2"),
 					Spec:     mocks.PrepareCappSpec(),
 					Status:   mocks.PrepareCappStatus(testutils.CappName+"-1", namespaceName, testutils.Domain),
 					Labels:   []types.KeyValue{{Key: testutils.LabelKey + "-3", Value: testutils.LabelValue + "-3"}},
@@ -523,6 +851,23 @@ func TestUpdateCapp(t *testing.T) {
 				errorStatus: metav1.StatusSuccess,
 			},
 		},
+		"ShouldSucceedUpdatingCappInDryRun": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + "-2",
+				capp:      mocks.PrepareUpdateCappType([]types.KeyValue{{Key: testutils.LabelKey + "-3", Value: testutils.LabelValue + "-3"}}, nil),
+				dryRun:    true,
+			},
+			want: want{
+				response: types.Capp{
+					Metadata: mocks.PrepareCappMetadataWithResourceVersion(testutils.CappName+"-2", namespaceName, "1"),
+					Spec:     mocks.PrepareCappSpec(),
+					Status:   mocks.PrepareCappStatus(testutils.CappName+"-2", namespaceName, testutils.Domain),
+					Labels:   []types.KeyValue{{Key: testutils.LabelKey + "-3", Value: testutils.LabelValue + "-3"}},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
 		"ShouldFaildUpdatingNonExistingCapp": {
 			requestParams: requestParams{
 				namespace: namespaceName,
@@ -539,10 +884,11 @@ func TestUpdateCapp(t *testing.T) {
 	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
 	createTestNamespace(namespaceName, map[string]string{})
 	mocks.CreateTestCapp(dynClient, testutils.CappName+"-1", namespaceName, testutils.Domain, map[string]string{testutils.LabelKey + "-1": testutils.LabelValue + "-1"}, map[string]string{})
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-2", namespaceName, testutils.Domain, map[string]string{testutils.LabelKey + "-2": testutils.LabelValue + "-2"}, map[string]string{})
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			response, err := cappController.UpdateCapp(test.requestParams.namespace, test.requestParams.name, test.requestParams.capp)
+			response, err := cappController.UpdateCapp(test.requestParams.namespace, test.requestParams.name, test.requestParams.capp, test.requestParams.dryRun)
 			if test.want.errorStatus != metav1.StatusSuccess {
 				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
 
@@ -551,64 +897,206 @@ func TestUpdateCapp(t *testing.T) {
 				assert.NoError(t, err)
 			}
 			assert.Equal(t, test.want.response, response)
+
+			if test.requestParams.dryRun && err == nil {
+				capp, err := cappController.GetCapp(test.requestParams.namespace, test.requestParams.name)
+				assert.NoError(t, err)
+				assert.Equal(t, []types.KeyValue{{Key: testutils.LabelKey + "-2", Value: testutils.LabelValue + "-2"}}, capp.Labels)
+			}
 		})
 	}
 }
 
-func TestEditCapp(t *testing.T) {
-	namespaceName := testutils.CappNamespace + "-update"
+func TestPatchCapp(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-patch"
+
 	type requestParams struct {
 		name      string
-		state     string
 		namespace string
+		request   types.PatchCappRequest
 	}
 
 	type want struct {
-		response    types.CappStateReponse
+		response    types.Capp
 		errorStatus metav1.StatusReason
 	}
+
 	cases := map[string]struct {
 		requestParams requestParams
 		want          want
 	}{
-		"ShouldSucceedEditingCappState": {
+		"ShouldSucceedMergePatchingCapp": {
 			requestParams: requestParams{
 				namespace: namespaceName,
 				name:      testutils.CappName + "-1",
-				state:     testutils.DisabledState,
+				request: types.PatchCappRequest{
+					PatchType:        string(k8stypes.MergePatchType),
+					Patch:            []byte(fmt.Sprintf(`{"metadata":{"labels":{%q:null,%q:%q}}}`, testutils.LabelKey+"-1", testutils.LabelKey+"-3", testutils.LabelValue+"-3")),
+					ResourceVersions: []string{"0", "1"},
+				},
 			},
 			want: want{
-				response: types.CappStateReponse{
-					State: testutils.DisabledState,
-					Name:  testutils.CappName + "-1",
+				response: types.Capp{
+					Metadata: mocks.PrepareCappMetadataWithResourceVersion(testutils.CappName+"-1", namespaceName, "2"),
+					Spec:     mocks.PrepareCappSpec(),
+					Status:   mocks.PrepareCappStatus(testutils.CappName+"-1", namespaceName, testutils.Domain),
+					Labels:   []types.KeyValue{{Key: testutils.LabelKey + "-3", Value: testutils.LabelValue + "-3"}},
 				},
 				errorStatus: metav1.StatusSuccess,
 			},
 		},
-		"ShouldFailedEditingNonExistingCapp": {
+		"ShouldSucceedJSONPatchingCapp": {
 			requestParams: requestParams{
 				namespace: namespaceName,
-				name:      testutils.CappName + testutils.NonExistentSuffix,
-				state:     testutils.DisabledState,
+				name:      testutils.CappName + "-2",
+				request: types.PatchCappRequest{
+					PatchType: string(k8stypes.JSONPatchType),
+					Patch:     []byte(fmt.Sprintf(`[{"op":"replace","path":"/spec/state","value":%q}]`, testutils.DisabledState)),
+				},
 			},
 			want: want{
-				response:    types.CappStateReponse{},
-				errorStatus: metav1.StatusReasonNotFound,
+				response: types.Capp{
+					Metadata: mocks.PrepareCappMetadataWithResourceVersion(testutils.CappName+"-2", namespaceName, "2"),
+					Spec:     mocks.PrepareCappSpecWithState(testutils.DisabledState),
+					Status:   mocks.PrepareCappStatus(testutils.CappName+"-2", namespaceName, testutils.Domain),
+				},
+				errorStatus: metav1.StatusSuccess,
 			},
 		},
-	}
-	setup()
-	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
-	createTestNamespace(namespaceName, map[string]string{})
-	mocks.CreateTestCapp(dynClient, testutils.CappName+"-1", namespaceName, testutils.Domain, map[string]string{testutils.LabelKey + "-1": testutils.LabelValue + "-1"}, map[string]string{})
-
-	for name, test := range cases {
-		t.Run(name, func(t *testing.T) {
-			response, err := cappController.EditCappState(test.requestParams.namespace, test.requestParams.name, test.requestParams.state)
-			if test.want.errorStatus != metav1.StatusSuccess {
-				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
-
-				assert.Equal(t, test.want.errorStatus, reason)
+		"ShouldFailPatchingCappWithStaleResourceVersion": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + "-3",
+				request: types.PatchCappRequest{
+					PatchType:        string(k8stypes.MergePatchType),
+					Patch:            []byte(`{"spec":{"state":"disabled"}}`),
+					ResourceVersions: []string{"0"},
+				},
+			},
+			want: want{
+				response:    types.Capp{},
+				errorStatus: metav1.StatusReasonConflict,
+			},
+		},
+		"ShouldFailPatchingCappWithUnsupportedPatchType": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + "-3",
+				request: types.PatchCappRequest{
+					PatchType: string(k8stypes.StrategicMergePatchType),
+					Patch:     []byte(`{"spec":{"state":"disabled"}}`),
+				},
+			},
+			want: want{
+				response:    types.Capp{},
+				errorStatus: metav1.StatusReasonBadRequest,
+			},
+		},
+		"ShouldFailPatchingCappWithInvalidPatch": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + "-3",
+				request: types.PatchCappRequest{
+					PatchType: string(k8stypes.JSONPatchType),
+					Patch:     []byte(`[{"op":"remove","path":"/spec/nonExistent"}]`),
+				},
+			},
+			want: want{
+				response:    types.Capp{},
+				errorStatus: metav1.StatusReasonBadRequest,
+			},
+		},
+		"ShouldFailPatchingNonExistingCapp": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + testutils.NonExistentSuffix,
+				request: types.PatchCappRequest{
+					PatchType: string(k8stypes.MergePatchType),
+					Patch:     []byte(`{"spec":{"state":"disabled"}}`),
+				},
+			},
+			want: want{
+				response:    types.Capp{},
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+	setup()
+	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
+	createTestNamespace(namespaceName, map[string]string{})
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-1", namespaceName, testutils.Domain, map[string]string{testutils.LabelKey + "-1": testutils.LabelValue + "-1"}, map[string]string{})
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-2", namespaceName, testutils.Domain, map[string]string{}, map[string]string{})
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-3", namespaceName, testutils.Domain, map[string]string{}, map[string]string{})
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := cappController.PatchCapp(test.requestParams.namespace, test.requestParams.name, test.requestParams.request)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+
+				assert.Equal(t, test.want.errorStatus, reason)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want.response, response)
+		})
+	}
+}
+
+func TestEditCapp(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-update"
+	type requestParams struct {
+		name      string
+		state     string
+		namespace string
+	}
+
+	type want struct {
+		response    types.CappStateReponse
+		errorStatus metav1.StatusReason
+	}
+	cases := map[string]struct {
+		requestParams requestParams
+		want          want
+	}{
+		"ShouldSucceedEditingCappState": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + "-1",
+				state:     testutils.DisabledState,
+			},
+			want: want{
+				response: types.CappStateReponse{
+					State: testutils.DisabledState,
+					Name:  testutils.CappName + "-1",
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailedEditingNonExistingCapp": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + testutils.NonExistentSuffix,
+				state:     testutils.DisabledState,
+			},
+			want: want{
+				response:    types.CappStateReponse{},
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+	setup()
+	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
+	createTestNamespace(namespaceName, map[string]string{})
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-1", namespaceName, testutils.Domain, map[string]string{testutils.LabelKey + "-1": testutils.LabelValue + "-1"}, map[string]string{})
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := cappController.EditCappState(test.requestParams.namespace, test.requestParams.name, test.requestParams.state)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+
+				assert.Equal(t, test.want.errorStatus, reason)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -673,3 +1161,617 @@ func TestDeleteCapp(t *testing.T) {
 		})
 	}
 }
+
+func TestRollbackCapp(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-rollback"
+
+	type requestParams struct {
+		name      string
+		namespace string
+		request   types.RollbackCappRequest
+	}
+
+	type want struct {
+		response    types.RollbackCappResponse
+		errorStatus metav1.StatusReason
+	}
+
+	expectedResponse := func(cappName, revisionName string) types.RollbackCappResponse {
+		return types.RollbackCappResponse{
+			RevisionName:   revisionName,
+			RevisionNumber: 1,
+			Capp: types.Capp{
+				Metadata: mocks.PrepareCappMetadataWithResourceVersion(cappName, namespaceName, "2"),
+				Spec:     mocks.PrepareCappSpec(),
+				Status:   mocks.PrepareCappStatusWithHostname(cappName, namespaceName, testutils.Hostname, testutils.Domain),
+				Labels:   []types.KeyValue{{Key: testutils.LabelCappName, Value: cappName}},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		requestParams requestParams
+		want          want
+	}{
+		"ShouldSucceedRollingBackCappByRevisionName": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + "-1",
+				request:   types.RollbackCappRequest{RevisionName: testutils.CappRevisionName + "-1"},
+			},
+			want: want{
+				response:    expectedResponse(testutils.CappName+"-1", testutils.CappRevisionName+"-1"),
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedRollingBackCappByRevisionNumber": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + "-3",
+				request:   types.RollbackCappRequest{RevisionNumber: 1},
+			},
+			want: want{
+				response:    expectedResponse(testutils.CappName+"-3", testutils.CappRevisionName+"-3"),
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailRollingBackWithoutRevision": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + "-1",
+				request:   types.RollbackCappRequest{},
+			},
+			want: want{
+				response:    types.RollbackCappResponse{},
+				errorStatus: metav1.StatusReasonBadRequest,
+			},
+		},
+		"ShouldFailRollingBackToRevisionOfAnotherCapp": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + "-1",
+				request:   types.RollbackCappRequest{RevisionName: testutils.CappRevisionName + "-2"},
+			},
+			want: want{
+				response:    types.RollbackCappResponse{},
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+		"ShouldFailRollingBackToNonExistingRevisionNumber": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + "-1",
+				request:   types.RollbackCappRequest{RevisionNumber: 5},
+			},
+			want: want{
+				response:    types.RollbackCappResponse{},
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+		"ShouldFailRollingBackNonExistingCapp": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + testutils.NonExistentSuffix,
+				request:   types.RollbackCappRequest{RevisionNumber: 1},
+			},
+			want: want{
+				response:    types.RollbackCappResponse{},
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+	setup()
+	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
+	createTestNamespace(namespaceName, map[string]string{})
+	mocks.CreateTestCappWithHostname(dynClient, testutils.CappName+"-1", namespaceName, testutils.Hostname, testutils.Domain, map[string]string{}, map[string]string{})
+	createTestCappRevision(testutils.CappRevisionName+"-1", namespaceName, map[string]string{testutils.LabelCappName: testutils.CappName + "-1"}, map[string]string{})
+	createTestCappRevision(testutils.CappRevisionName+"-2", namespaceName, map[string]string{testutils.LabelCappName: testutils.CappName + "-2"}, map[string]string{})
+	mocks.CreateTestCappWithHostname(dynClient, testutils.CappName+"-3", namespaceName, testutils.Hostname, testutils.Domain, map[string]string{}, map[string]string{})
+	createTestCappRevision(testutils.CappRevisionName+"-3", namespaceName, map[string]string{testutils.LabelCappName: testutils.CappName + "-3"}, map[string]string{})
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := cappController.RollbackCapp(test.requestParams.namespace, test.requestParams.name, test.requestParams.request)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+
+				assert.Equal(t, test.want.errorStatus, reason)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want.response, response)
+		})
+	}
+}
+
+func TestRestartCapp(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-restart"
+
+	type requestParams struct {
+		name      string
+		namespace string
+	}
+
+	type want struct {
+		errorStatus metav1.StatusReason
+	}
+
+	cases := map[string]struct {
+		requestParams requestParams
+		want          want
+	}{
+		"ShouldSucceedRestartingCapp": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + "-1",
+			},
+			want: want{
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailRestartingDisabledCapp": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + "-2",
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonBadRequest,
+			},
+		},
+		"ShouldFailRestartingNonExistingCapp": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + testutils.NonExistentSuffix,
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+	setup()
+	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
+	createTestNamespace(namespaceName, map[string]string{})
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-1", namespaceName, testutils.Domain, map[string]string{}, map[string]string{})
+	disabledCapp := mocks.PrepareCappWithState(testutils.CappName+"-2", namespaceName, testutils.DisabledState, map[string]string{}, map[string]string{})
+	assert.NoError(t, dynClient.Create(context.TODO(), &disabledCapp))
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := cappController.RestartCapp(test.requestParams.namespace, test.requestParams.name)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+
+				assert.Equal(t, test.want.errorStatus, reason)
+				assert.Equal(t, types.RestartCappResponse{}, response)
+				return
+			}
+
+			assert.NoError(t, err)
+			_, err = time.Parse(time.RFC3339Nano, response.RestartedAt)
+			assert.NoError(t, err)
+			assert.Contains(t, response.Capp.Annotations, types.KeyValue{Key: restartedAtAnnotation, Value: response.RestartedAt})
+
+			capp := &cappv1alpha1.Capp{}
+			assert.NoError(t, dynClient.Get(context.TODO(), k8stypes.NamespacedName{Namespace: test.requestParams.namespace, Name: test.requestParams.name}, capp))
+			assert.Equal(t, response.RestartedAt, capp.Annotations[restartedAtAnnotation])
+
+			secondResponse, err := cappController.RestartCapp(test.requestParams.namespace, test.requestParams.name)
+			assert.NoError(t, err)
+			assert.NotEqual(t, response.RestartedAt, secondResponse.RestartedAt)
+		})
+	}
+}
+
+func TestWatchCapps(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-watch"
+
+	type requestParams struct {
+		namespace string
+		cappQuery types.CappQuery
+	}
+
+	type want struct {
+		event       types.CappWatchEvent
+		errorStatus metav1.StatusReason
+	}
+
+	cases := map[string]struct {
+		requestParams requestParams
+		action        func(namespace string)
+		want          want
+	}{
+		"ShouldReceiveAddedEvent": {
+			requestParams: requestParams{
+				namespace: namespaceName + "-added",
+			},
+			action: func(namespace string) {
+				mocks.CreateTestCapp(dynClient, testutils.CappName+"-1", namespace, testutils.Domain, nil, nil)
+			},
+			want: want{
+				event:       types.CappWatchEvent{Type: string(watch.Added), Object: mocks.PrepareCappSummary(testutils.CappName+"-1", namespaceName+"-added")},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldReceiveDeletedEvent": {
+			requestParams: requestParams{
+				namespace: namespaceName + "-deleted",
+			},
+			action: func(namespace string) {
+				capp := mocks.PrepareCapp(testutils.CappName+"-1", namespace, testutils.Domain, nil, nil)
+				assert.NoError(t, dynClient.Delete(context.TODO(), &capp))
+			},
+			want: want{
+				event:       types.CappWatchEvent{Type: string(watch.Deleted), Object: mocks.PrepareCappSummary(testutils.CappName+"-1", namespaceName+"-deleted")},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailWatchingCappsWithInvalidSelector": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				cappQuery: types.CappQuery{LabelSelector: testutils.InvalidLabelSelector},
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonBadRequest,
+			},
+		},
+	}
+	setup()
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-1", namespaceName+"-deleted", testutils.Domain, nil, nil)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cappController := NewCappController(dynClient, ctx, logger)
+			events, err := cappController.WatchCapps(test.requestParams.namespace, test.requestParams.cappQuery)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+				assert.Equal(t, test.want.errorStatus, reason)
+				return
+			}
+			assert.NoError(t, err)
+
+			test.action(test.requestParams.namespace)
+			select {
+			case event := <-events:
+				assert.Equal(t, test.want.event, event)
+			case <-time.After(watchTimeout):
+				t.Fatal("Timed out waiting for capp event")
+			}
+
+			cancel()
+			_, open := <-events
+			assert.False(t, open)
+		})
+	}
+}
+
+func TestWatchCapp(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-watch-one"
+
+	type requestParams struct {
+		name      string
+		namespace string
+	}
+
+	type want struct {
+		event       types.CappWatchEvent
+		errorStatus metav1.StatusReason
+	}
+
+	cases := map[string]struct {
+		requestParams requestParams
+		want          want
+	}{
+		"ShouldReceiveModifiedEvent": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + "-1",
+			},
+			want: want{
+				event: types.CappWatchEvent{Type: string(watch.Modified), Object: types.Capp{
+					Metadata: mocks.PrepareCappMetadataWithResourceVersion(testutils.CappName+"-1", namespaceName, "2"),
+					Spec:     mocks.PrepareCappSpecWithState(testutils.DisabledState),
+					Status:   mocks.PrepareCappStatus(testutils.CappName+"-1", namespaceName, testutils.Domain),
+				}},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailWatchingNonExistingCapp": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappName + testutils.NonExistentSuffix,
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+	setup()
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-1", namespaceName, testutils.Domain, nil, nil)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cappController := NewCappController(dynClient, ctx, logger)
+			events, err := cappController.WatchCapp(test.requestParams.namespace, test.requestParams.name)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+				assert.Equal(t, test.want.errorStatus, reason)
+				return
+			}
+			assert.NoError(t, err)
+
+			_, err = cappController.EditCappState(test.requestParams.namespace, test.requestParams.name, testutils.DisabledState)
+			assert.NoError(t, err)
+
+			select {
+			case event := <-events:
+				assert.Equal(t, test.want.event, event)
+			case <-time.After(watchTimeout):
+				t.Fatal("Timed out waiting for capp event")
+			}
+		})
+	}
+}
+
+func TestDeployCapp(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-deploy"
+	multiContainerCappName := testutils.CappName + "-multi-container"
+
+	type requestParams struct {
+		name    string
+		request types.DeployCappRequest
+	}
+
+	type want struct {
+		containerName string
+		env           []corev1.EnvVar
+		changeCause   string
+		errorStatus   metav1.StatusReason
+	}
+
+	cases := map[string]struct {
+		requestParams requestParams
+		want          want
+	}{
+		"ShouldSucceedDeployingToDefaultContainer": {
+			requestParams: requestParams{
+				name:    testutils.CappName,
+				request: types.DeployCappRequest{Image: testutils.DeployImage},
+			},
+			want: want{
+				containerName: testutils.ContainerName,
+				changeCause:   fmt.Sprintf(defaultChangeCause, testutils.DeployImage, testutils.ContainerName),
+				errorStatus:   metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedDeployingToNamedContainerWithEnv": {
+			requestParams: requestParams{
+				name: multiContainerCappName,
+				request: types.DeployCappRequest{
+					Image:         testutils.DeployImage,
+					ContainerName: testutils.TestContainerName,
+					Env:           []types.KeyValue{{Key: testutils.EnvKey, Value: testutils.EnvValue}, {Key: "PORT", Value: "8080"}},
+					ChangeCause:   testutils.ChangeCause,
+				},
+			},
+			want: want{
+				containerName: testutils.TestContainerName,
+				env: []corev1.EnvVar{
+					{Name: testutils.EnvKey, Value: testutils.EnvValue},
+					{Name: "PORT", Value: "8080"},
+				},
+				changeCause: testutils.ChangeCause,
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailDeployingToNonExistingContainer": {
+			requestParams: requestParams{
+				name:    testutils.CappName,
+				request: types.DeployCappRequest{Image: testutils.DeployImage, ContainerName: testutils.TestContainerName},
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonBadRequest,
+			},
+		},
+		"ShouldFailDeployingNonExistingCapp": {
+			requestParams: requestParams{
+				name:    testutils.CappName + testutils.NonExistentSuffix,
+				request: types.DeployCappRequest{Image: testutils.DeployImage},
+			},
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+
+	setup()
+	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
+	createTestNamespace(namespaceName, map[string]string{})
+	mocks.CreateTestCapp(dynClient, testutils.CappName, namespaceName, testutils.Domain, nil, nil)
+	multiContainerCapp := mocks.PrepareCapp(multiContainerCappName, namespaceName, testutils.Domain, nil, nil)
+	containers := &multiContainerCapp.Spec.ConfigurationSpec.Template.Spec.Containers
+	*containers = append(*containers, corev1.Container{
+		Name:  testutils.TestContainerName,
+		Image: testutils.Image,
+		Env: []corev1.EnvVar{{
+			Name:      testutils.EnvKey,
+			ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{Key: testutils.EnvKey}},
+		}},
+	})
+	assert.NoError(t, dynClient.Create(context.TODO(), &multiContainerCapp))
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := cappController.DeployCapp(namespaceName, test.requestParams.name, testutils.Username, test.requestParams.request)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+
+				assert.Equal(t, test.want.errorStatus, reason)
+				assert.Equal(t, types.DeployCappResponse{}, response)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want.changeCause, response.ChangeCause)
+			assert.Equal(t, testutils.Username, response.DeployedBy)
+			_, err = time.Parse(time.RFC3339Nano, response.DeployedAt)
+			assert.NoError(t, err)
+
+			capp := &cappv1alpha1.Capp{}
+			assert.NoError(t, dynClient.Get(context.TODO(), k8stypes.NamespacedName{Namespace: namespaceName, Name: test.requestParams.name}, capp))
+			assert.Equal(t, test.want.changeCause, capp.Annotations[changeCauseAnnotation])
+			assert.Equal(t, testutils.Username, capp.Annotations[deployedByAnnotation])
+			assert.Equal(t, response.DeployedAt, capp.Annotations[deployedAtAnnotation])
+
+			redeployResponse, err := cappController.DeployCapp(namespaceName, test.requestParams.name, testutils.Username, test.requestParams.request)
+			assert.NoError(t, err)
+			assert.NotEqual(t, response.DeployedAt, redeployResponse.DeployedAt)
+
+			for _, container := range capp.Spec.ConfigurationSpec.Template.Spec.Containers {
+				if container.Name != test.want.containerName {
+					assert.Equal(t, testutils.CappImage, container.Image)
+					continue
+				}
+
+				assert.Equal(t, testutils.DeployImage, container.Image)
+				assert.Equal(t, test.want.env, container.Env)
+			}
+		})
+	}
+}
+
+func TestGetCappHistory(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-history"
+	rollingOutCappName := testutils.CappName + "-rolling-out"
+	splitCappName := testutils.CappName + "-split"
+	revisionName := func(cappName string, revisionNumber int) string {
+		return fmt.Sprintf("%s-v%d", cappName, revisionNumber)
+	}
+	deployAnnotations := map[string]string{
+		testutils.ChangeCauseAnnotation: testutils.ChangeCause,
+		testutils.DeployedByAnnotation:  testutils.Username,
+		testutils.DeployedAtAnnotation:  testutils.RevisionTimestamp,
+	}
+
+	expectedHistory := func(cappName string, serving ...bool) types.CappHistory {
+		return types.CappHistory{
+			CappName: cappName,
+			Revisions: []types.CappHistoryEntry{
+				{
+					RevisionName:   revisionName(cappName, 3),
+					RevisionNumber: 3,
+					CreatedAt:      testutils.RevisionTimestamp,
+					Images:         []string{testutils.CappImage},
+					ChangeCause:    testutils.ChangeCause,
+					DeployedBy:     testutils.Username,
+					DeployedAt:     testutils.RevisionTimestamp,
+					Serving:        serving[0],
+				},
+				{
+					RevisionName:   revisionName(cappName, 2),
+					RevisionNumber: 2,
+					CreatedAt:      testutils.RevisionTimestamp,
+					Images:         []string{testutils.DeployImage},
+					ChangeCause:    testutils.ChangeCause,
+					DeployedBy:     testutils.Username,
+					DeployedAt:     testutils.RevisionTimestamp,
+					Serving:        serving[1],
+				},
+				{
+					RevisionName:   revisionName(cappName, 1),
+					RevisionNumber: 1,
+					CreatedAt:      testutils.RevisionTimestamp,
+					Images:         []string{testutils.Image},
+					Serving:        serving[2],
+				},
+			},
+		}
+	}
+
+	type want struct {
+		response    types.CappHistory
+		errorStatus metav1.StatusReason
+	}
+
+	cases := map[string]struct {
+		cappName string
+		want     want
+	}{
+		"ShouldSucceedGettingHistoryWithServingRevision": {
+			cappName: testutils.CappName,
+			want: want{
+				response:    expectedHistory(testutils.CappName, true, false, false),
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedGettingHistoryWhileRollingOut": {
+			cappName: rollingOutCappName,
+			want: want{
+				response:    expectedHistory(rollingOutCappName, false, true, false),
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedGettingHistoryWithSplitTraffic": {
+			cappName: splitCappName,
+			want: want{
+				response:    expectedHistory(splitCappName, true, false, true),
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedGettingHistoryWithoutRevisions": {
+			cappName: testutils.CappName + "-without-revisions",
+			want: want{
+				response:    types.CappHistory{CappName: testutils.CappName + "-without-revisions", Revisions: []types.CappHistoryEntry{}},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailGettingHistoryOfNonExistingCapp": {
+			cappName: testutils.CappName + testutils.NonExistentSuffix,
+			want: want{
+				response:    types.CappHistory{},
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+
+	setup()
+	cappController := NewCappController(dynClient, mocks.GinContext(), logger)
+	createTestNamespace(namespaceName, map[string]string{})
+	mocks.CreateTestCappWithServingRevision(dynClient, testutils.CappName, namespaceName, testutils.Domain, testutils.CappName+"-00003")
+	rollingOutCapp := mocks.PrepareCappWithServingRevision(rollingOutCappName, namespaceName, testutils.Domain, rollingOutCappName+"-00002")
+	rollingOutCapp.Status.KnativeObjectStatus.LatestCreatedRevisionName = rollingOutCappName + "-00003"
+	assert.NoError(t, dynClient.Create(context.TODO(), &rollingOutCapp))
+	splitCapp := mocks.PrepareCappWithServingRevision(splitCappName, namespaceName, testutils.Domain, splitCappName+"-00003")
+	latestPercent, previousPercent := int64(80), int64(20)
+	splitCapp.Status.KnativeObjectStatus.Traffic = []knativev1.TrafficTarget{
+		{RevisionName: splitCappName + "-00003", Percent: &latestPercent},
+		{RevisionName: splitCappName + "-00001", Percent: &previousPercent},
+		{RevisionName: splitCappName + "-00002", Tag: testutils.TrafficTag},
+	}
+	assert.NoError(t, dynClient.Create(context.TODO(), &splitCapp))
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-without-revisions", namespaceName, testutils.Domain, nil, nil)
+	for _, cappName := range []string{testutils.CappName, rollingOutCappName, splitCappName} {
+		mocks.CreateTestCappRevisionOfCapp(dynClient, revisionName(cappName, 2), namespaceName, cappName, testutils.DeployImage, 2, deployAnnotations)
+		mocks.CreateTestCappRevisionOfCapp(dynClient, revisionName(cappName, 3), namespaceName, cappName, testutils.CappImage, 3, deployAnnotations)
+		mocks.CreateTestCappRevisionOfCapp(dynClient, revisionName(cappName, 1), namespaceName, cappName, testutils.Image, 1, nil)
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := cappController.GetCappHistory(namespaceName, test.cappName)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+				assert.Equal(t, test.want.errorStatus, reason)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want.response, response)
+		})
+	}
+}
@@ -18,14 +18,24 @@ import (
 const (
 	ErrCouldNotListCappRevisions = "Could not list capp revisions"
 	ErrCouldNotGetCappRevision   = "Could not get capp revision %q in namespace %q"
+	ErrCouldNotDiffCappRevision  = "Could not compute diff of capp revision %q in namespace %q"
+	ErrCappRevisionNotOfCapp     = "Capp revision %q not found for capp %q in namespace %q"
+)
+
+const (
+	liveCapp = "live capp %q"
 )
 
 type CappRevisionController interface {
 	// GetCappRevisions gets all CappRevision names and count from a specific namespace.
-	GetCappRevisions(namespace string, limit, page int, cappName string) (types.CappRevisionList, error)
+	GetCappRevisions(namespace string, limit, page int, cappName string, listQuery types.ListQuery) (types.CappRevisionList, error)
 
 	// GetCappRevision gets a specific CappRevision from the specified namespace.
 	GetCappRevision(namespace, name string) (types.CappRevision, error)
+
+	// GetCappRevisionDiff compares a specific CappRevision of a Capp with another CappRevision of the Capp,
+	// or with the current Capp if no other CappRevision is given. If no Capp is given, the Capp of the CappRevision is used.
+	GetCappRevisionDiff(namespace, cappName, name, compareTo string) (types.CappRevisionDiff, error)
 }
 
 type cappRevisionController struct {
@@ -53,16 +63,86 @@ func NewCappRevisionController(client client.Client, context context.Context, lo
 func (c *cappRevisionController) GetCappRevision(namespace string, name string) (types.CappRevision, error) {
 	c.logger.Debug(fmt.Sprintf("Trying to fetch capp revision %q in namespace %q", name, namespace))
 
+	cappRevision, err := c.getCappRevision(namespace, name)
+	if err != nil {
+		return types.CappRevision{}, err
+	}
+
+	return convertCappRevisionToType(cappRevision), nil
+}
+
+func (c *cappRevisionController) GetCappRevisionDiff(namespace, cappName, name, compareTo string) (types.CappRevisionDiff, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to compute diff of capp revision %q in namespace %q", name, namespace))
+
+	cappRevision, err := c.getCappRevision(namespace, name)
+	if err != nil {
+		return types.CappRevisionDiff{}, err
+	}
+
+	if cappName == "" {
+		cappName = cappRevision.Labels[utils.CappNameLabel]
+	}
+	if err := validateCappRevisionOfCapp(cappRevision, cappName); err != nil {
+		return types.CappRevisionDiff{}, err
+	}
+
+	var compareToName string
+	var compareToTemplate cappv1alpha1.CappTemplate
+	if compareTo != "" {
+		compareToRevision, err := c.getCappRevision(namespace, compareTo)
+		if err != nil {
+			return types.CappRevisionDiff{}, err
+		}
+		if err := validateCappRevisionOfCapp(compareToRevision, cappName); err != nil {
+			return types.CappRevisionDiff{}, err
+		}
+		compareToName = compareToRevision.Name
+		compareToTemplate = compareToRevision.Spec.CappTemplate
+	} else {
+		capp := cappv1alpha1.Capp{}
+		if err := c.client.Get(c.ctx, client.ObjectKey{Namespace: namespace, Name: cappName}, &capp); err != nil {
+			c.logger.Error(fmt.Sprintf("%s with error: %s", fmt.Sprintf(ErrCouldNotGetCapp, cappName, namespace), err.Error()))
+			return types.CappRevisionDiff{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetCapp, cappName, namespace), err)
+		}
+		compareToName = fmt.Sprintf(liveCapp, capp.Name)
+		compareToTemplate = cappv1alpha1.CappTemplate{Spec: capp.Spec, Labels: capp.Labels, Annotations: capp.Annotations}
+	}
+
+	changes, err := utils.DiffJSON(cappRevision.Spec.CappTemplate, compareToTemplate)
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("%s with error: %s", fmt.Sprintf(ErrCouldNotDiffCappRevision, name, namespace), err.Error()))
+		return types.CappRevisionDiff{}, customerrors.NewInternalServerError(fmt.Sprintf(ErrCouldNotDiffCappRevision, name, namespace))
+	}
+
+	return types.CappRevisionDiff{
+		From:    cappRevision.Name,
+		To:      compareToName,
+		Changes: changes,
+		Count:   len(changes),
+	}, nil
+}
+
+// validateCappRevisionOfCapp returns a not found error if a CappRevision does not belong to the given Capp.
+func validateCappRevisionOfCapp(cappRevision cappv1alpha1.CappRevision, cappName string) error {
+	if cappRevision.Labels[utils.CappNameLabel] != cappName {
+		return customerrors.NewNotFoundError(fmt.Sprintf(ErrCappRevisionNotOfCapp, cappRevision.Name, cappName, cappRevision.Namespace))
+	}
+
+	return nil
+}
+
+// getCappRevision gets a specific CappRevision from the specified namespace in its API form.
+func (c *cappRevisionController) getCappRevision(namespace, name string) (cappv1alpha1.CappRevision, error) {
 	cappRevision := cappv1alpha1.CappRevision{}
 	if err := c.client.Get(c.ctx, client.ObjectKey{Namespace: namespace, Name: name}, &cappRevision); err != nil {
 		c.logger.Error(fmt.Sprintf("%s with error: %s", fmt.Sprintf(ErrCouldNotGetCappRevision, name, namespace), err.Error()))
-		return types.CappRevision{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetCappRevision, name, namespace), err)
+		return cappv1alpha1.CappRevision{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetCappRevision, name, namespace), err)
 	}
 
-	return convertCappRevisionToType(cappRevision), nil
+	return cappRevision, nil
 }
 
-func (c *cappRevisionController) GetCappRevisions(namespace string, limit, page int, cappName string) (types.CappRevisionList, error) {
+func (c *cappRevisionController) GetCappRevisions(namespace string, limit, page int, cappName string, listQuery types.ListQuery) (types.CappRevisionList, error) {
 	cappQuery := ""
 	c.logger.Debug(fmt.Sprintf("Trying to fetch all capp revisions in namespace: %q", namespace))
 
@@ -77,17 +157,16 @@ func (c *cappRevisionController) GetCappRevisions(namespace string, limit, page
 		cappQuery:        cappQuery,
 	}
 
-	cappRevisionList, err := pagination.FetchPage[cappv1alpha1.CappRevision](limit, page, cappRevisionPaginator)
+	cappRevisionList, listMetadata, err := pagination.FetchPage[cappv1alpha1.CappRevision](limit, page, cappRevisionPaginator, listQuery)
 	if err != nil {
 		c.logger.Error(fmt.Sprintf("%s with error: %s", ErrCouldNotListCappRevisions, err.Error()))
 		return types.CappRevisionList{}, customerrors.NewAPIError(ErrCouldNotListCappRevisions, err)
 	}
 
-	result := types.CappRevisionList{}
+	result := types.CappRevisionList{ListMetadata: listMetadata}
 	for _, revision := range cappRevisionList {
 		result.CappRevisions = append(result.CappRevisions, revision.Name)
 	}
-	result.Count = len(cappRevisionList)
 
 	return result, nil
 }
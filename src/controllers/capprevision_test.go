@@ -5,6 +5,7 @@ import (
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
 	"github.com/dana-team/platform-backend/src/customerrors"
 	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils"
 	"github.com/dana-team/platform-backend/src/utils/pagination"
 	"github.com/dana-team/platform-backend/src/utils/testutils"
 	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
@@ -165,7 +166,7 @@ func TestGetCappRevisions(t *testing.T) {
 			cappRevisionController := NewCappRevisionController(dynClient, c, logger)
 
 			limit, page, _ := pagination.ExtractPaginationParamsFromCtx(c)
-			response, err := cappRevisionController.GetCappRevisions(test.requestParams.namespace, limit, page, test.requestParams.cappName)
+			response, err := cappRevisionController.GetCappRevisions(test.requestParams.namespace, limit, page, test.requestParams.cappName, types.ListQuery{})
 			if test.want.errorStatus != metav1.StatusSuccess {
 				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
 				assert.Equal(t, test.want.errorStatus, reason)
@@ -178,3 +179,167 @@ func TestGetCappRevisions(t *testing.T) {
 	}
 
 }
+
+func TestGetCappRevisionDiff(t *testing.T) {
+	namespaceName := testutils.CappRevisionNamespace + "-diff"
+	cappName := testutils.CappName + "-1"
+
+	type requestParams struct {
+		name      string
+		namespace string
+		cappName  string
+		compareTo string
+	}
+
+	type want struct {
+		diff        types.CappRevisionDiff
+		errorStatus metav1.StatusReason
+	}
+
+	cases := map[string]struct {
+		requestParams requestParams
+		want          want
+	}{
+		"ShouldSucceedDiffingTwoCappRevisions": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				cappName:  cappName,
+				name:      testutils.CappRevisionName + "-1",
+				compareTo: testutils.CappRevisionName + "-2",
+			},
+			want: want{
+				diff: types.CappRevisionDiff{
+					From: testutils.CappRevisionName + "-1",
+					To:   testutils.CappRevisionName + "-2",
+					Changes: []types.FieldDiff{
+						{Path: "labels." + testutils.LabelKey, Type: utils.DiffAdded, NewValue: testutils.LabelValue},
+					},
+					Count: 1,
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedDiffingCappRevisionWithLiveCapp": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				cappName:  cappName,
+				name:      testutils.CappRevisionName + "-1",
+			},
+			want: want{
+				diff: types.CappRevisionDiff{
+					From: testutils.CappRevisionName + "-1",
+					To:   "live capp \"" + cappName + "\"",
+					Changes: []types.FieldDiff{
+						{Path: "cappSpec.routeSpec.hostname", Type: utils.DiffAdded, NewValue: testutils.Hostname + "." + testutils.Domain},
+						{Path: "cappSpec.scaleMetric", Type: utils.DiffRemoved, OldValue: "concurrency"},
+						{Path: "cappSpec.state", Type: utils.DiffRemoved, OldValue: testutils.EnabledState},
+						{Path: "labels", Type: utils.DiffRemoved, OldValue: map[string]interface{}{testutils.LabelCappName: cappName}},
+					},
+					Count: 4,
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedDiffingIdenticalCappRevisions": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				cappName:  cappName,
+				name:      testutils.CappRevisionName + "-1",
+				compareTo: testutils.CappRevisionName + "-1",
+			},
+			want: want{
+				diff: types.CappRevisionDiff{
+					From:    testutils.CappRevisionName + "-1",
+					To:      testutils.CappRevisionName + "-1",
+					Changes: []types.FieldDiff{},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedDiffingCappRevisionOfItsOwnCappWithoutCappName": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				name:      testutils.CappRevisionName + "-1",
+				compareTo: testutils.CappRevisionName + "-2",
+			},
+			want: want{
+				diff: types.CappRevisionDiff{
+					From: testutils.CappRevisionName + "-1",
+					To:   testutils.CappRevisionName + "-2",
+					Changes: []types.FieldDiff{
+						{Path: "labels." + testutils.LabelKey, Type: utils.DiffAdded, NewValue: testutils.LabelValue},
+					},
+					Count: 1,
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailDiffingCappRevisionOfAnotherCapp": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				cappName:  cappName,
+				name:      testutils.CappRevisionName + "-other",
+			},
+			want: want{
+				diff:        types.CappRevisionDiff{},
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+		"ShouldFailDiffingWithCappRevisionOfAnotherCapp": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				cappName:  cappName,
+				name:      testutils.CappRevisionName + "-1",
+				compareTo: testutils.CappRevisionName + "-other",
+			},
+			want: want{
+				diff:        types.CappRevisionDiff{},
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+		"ShouldFailDiffingNonExistingCappRevision": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				cappName:  cappName,
+				name:      testutils.CappRevisionName + testutils.NonExistentSuffix,
+			},
+			want: want{
+				diff:        types.CappRevisionDiff{},
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+		"ShouldFailDiffingWithNonExistingCappRevision": {
+			requestParams: requestParams{
+				namespace: namespaceName,
+				cappName:  cappName,
+				name:      testutils.CappRevisionName + "-1",
+				compareTo: testutils.CappRevisionName + testutils.NonExistentSuffix,
+			},
+			want: want{
+				diff:        types.CappRevisionDiff{},
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+	setup()
+
+	cappRevisionController := NewCappRevisionController(dynClient, mocks.GinContext(), logger)
+	createTestNamespace(namespaceName, map[string]string{})
+	mocks.CreateTestCappWithHostname(dynClient, cappName, namespaceName, testutils.Hostname, testutils.Domain, nil, nil)
+	createTestCappRevision(testutils.CappRevisionName+"-1", namespaceName, map[string]string{testutils.LabelCappName: cappName}, map[string]string{})
+	createTestCappRevision(testutils.CappRevisionName+"-2", namespaceName, map[string]string{testutils.LabelCappName: cappName, testutils.LabelKey: testutils.LabelValue}, map[string]string{})
+	createTestCappRevision(testutils.CappRevisionName+"-other", namespaceName, map[string]string{testutils.LabelCappName: testutils.CappName + "-other"}, map[string]string{})
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := cappRevisionController.GetCappRevisionDiff(test.requestParams.namespace, test.requestParams.cappName, test.requestParams.name, test.requestParams.compareTo)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+				assert.Equal(t, test.want.errorStatus, reason)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want.diff, response)
+		})
+	}
+}
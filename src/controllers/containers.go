@@ -6,8 +6,10 @@ import (
 	"github.com/dana-team/platform-backend/src/customerrors"
 	"github.com/dana-team/platform-backend/src/types"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"time"
 )
 
 const (
@@ -17,6 +19,7 @@ const (
 // ContainerController defines methods to interact with pod containers.
 type ContainerController interface {
 	GetContainers(namespace, podName string) (types.GetContainersResponse, error)
+	GetContainer(namespace, podName, containerName string) (types.ContainerDetails, error)
 }
 
 // containerController implements the ContainerController interface.
@@ -35,7 +38,7 @@ func NewContainerController(client kubernetes.Interface, context context.Context
 	}
 }
 
-// GetContainers returns a list of container names for a given pod in a specific namespace.
+// GetContainers returns the status of the init containers and containers of a given pod in a specific namespace.
 func (c *containerController) GetContainers(namespace, podName string) (types.GetContainersResponse, error) {
 	c.logger.Debug(fmt.Sprintf("Trying to get all containers in %q namespace", namespace))
 
@@ -46,15 +49,135 @@ func (c *containerController) GetContainers(namespace, podName string) (types.Ge
 	}
 
 	response := types.GetContainersResponse{}
-	response.Count = len(pod.Spec.Containers)
+	response.Count = len(pod.Spec.InitContainers) + len(pod.Spec.Containers)
+	for _, container := range pod.Spec.InitContainers {
+		response.Containers = append(response.Containers, convertContainerDetailsToSummary(buildContainerDetails(container, pod.Status.InitContainerStatuses, true)))
+	}
+
 	for _, container := range pod.Spec.Containers {
-		response.Containers = append(
-			response.Containers,
-			types.Container{
-				ContainerName: container.Name,
-			})
+		response.Containers = append(response.Containers, convertContainerDetailsToSummary(buildContainerDetails(container, pod.Status.ContainerStatuses, false)))
 	}
 
 	c.logger.Debug("Fetched all containers successfully")
 	return response, nil
 }
+
+// GetContainer returns the status and resources of a container or an init container of a pod in a specific namespace.
+func (c *containerController) GetContainer(namespace, podName, containerName string) (types.ContainerDetails, error) {
+	c.logger.Debug(fmt.Sprintf("Trying to get container %q of pod %q in %q namespace", containerName, podName, namespace))
+
+	pod, err := c.client.CoreV1().Pods(namespace).Get(c.ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("%v with error: %s", fmt.Sprintf(ErrCouldNotGetPod, podName, namespace), err.Error()))
+		return types.ContainerDetails{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetPod, podName, namespace), err)
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == containerName {
+			return buildContainerDetails(container, pod.Status.InitContainerStatuses, true), nil
+		}
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if container.Name == containerName {
+			return buildContainerDetails(container, pod.Status.ContainerStatuses, false), nil
+		}
+	}
+
+	return types.ContainerDetails{}, customerrors.NewNotFoundError(fmt.Sprintf(ErrContainerNotFoundInPod, containerName, podName))
+}
+
+// convertContainerDetailsToSummary converts the details of a container to the summary returned in lists.
+func convertContainerDetailsToSummary(details types.ContainerDetails) types.Container {
+	return types.Container{
+		ContainerName: details.ContainerName,
+		Init:          details.Init,
+		Ready:         details.Ready,
+		RestartCount:  details.RestartCount,
+		State:         details.State.State,
+	}
+}
+
+// buildContainerDetails returns the details of the container, using its status from the given statuses if it has one.
+// Containers without a status, such as containers of pods that are not scheduled yet, are reported as waiting.
+func buildContainerDetails(container corev1.Container, statuses []corev1.ContainerStatus, init bool) types.ContainerDetails {
+	details := types.ContainerDetails{
+		ContainerName: container.Name,
+		Image:         container.Image,
+		Init:          init,
+		State:         types.ContainerState{State: types.ContainerStateWaiting},
+		Resources: types.ContainerResources{
+			Requests: formatResourceList(container.Resources.Requests),
+			Limits:   formatResourceList(container.Resources.Limits),
+		},
+	}
+
+	for _, status := range statuses {
+		if status.Name != container.Name {
+			continue
+		}
+
+		details.Ready = status.Ready
+		details.RestartCount = status.RestartCount
+		details.State = buildContainerState(status.State)
+		if status.LastTerminationState != (corev1.ContainerState{}) {
+			lastState := buildContainerState(status.LastTerminationState)
+			details.LastState = &lastState
+		}
+		break
+	}
+
+	return details
+}
+
+// buildContainerState returns the state of a container along with its reason, message, exit code and times.
+func buildContainerState(state corev1.ContainerState) types.ContainerState {
+	switch {
+	case state.Waiting != nil:
+		return types.ContainerState{
+			State:   types.ContainerStateWaiting,
+			Reason:  state.Waiting.Reason,
+			Message: state.Waiting.Message,
+		}
+	case state.Running != nil:
+		return types.ContainerState{
+			State:     types.ContainerStateRunning,
+			StartedAt: formatTimestamp(state.Running.StartedAt),
+		}
+	case state.Terminated != nil:
+		exitCode := state.Terminated.ExitCode
+		return types.ContainerState{
+			State:      types.ContainerStateTerminated,
+			Reason:     state.Terminated.Reason,
+			Message:    state.Terminated.Message,
+			ExitCode:   &exitCode,
+			StartedAt:  formatTimestamp(state.Terminated.StartedAt),
+			FinishedAt: formatTimestamp(state.Terminated.FinishedAt),
+		}
+	default:
+		return types.ContainerState{State: types.ContainerStateUnknown}
+	}
+}
+
+// formatResourceList returns the quantities of the resources as strings, or nil if there are none.
+func formatResourceList(resources corev1.ResourceList) map[string]string {
+	if len(resources) == 0 {
+		return nil
+	}
+
+	formatted := make(map[string]string, len(resources))
+	for name, quantity := range resources {
+		formatted[string(name)] = quantity.String()
+	}
+
+	return formatted
+}
+
+// formatTimestamp returns the time in RFC3339 format, or an empty string if it is not set.
+func formatTimestamp(timestamp metav1.Time) string {
+	if timestamp.IsZero() {
+		return ""
+	}
+
+	return timestamp.UTC().Format(time.RFC3339)
+}
@@ -34,8 +34,23 @@ func TestGetContainers(t *testing.T) {
 				response: types.GetContainersResponse{
 					ListMetadata: types.ListMetadata{Count: 2},
 					Containers: []types.Container{
-						{ContainerName: testutils.TestContainerName},
-						{ContainerName: testutils.CappName},
+						{ContainerName: testutils.TestContainerName, State: types.ContainerStateWaiting},
+						{ContainerName: testutils.CappName, State: types.ContainerStateWaiting},
+					},
+				},
+			},
+		},
+		"ShouldSucceedGettingInitContainersAndContainerStatuses": {
+			args: args{
+				namespace: namespaceName,
+				podName:   pod1,
+			},
+			want: want{
+				response: types.GetContainersResponse{
+					ListMetadata: types.ListMetadata{Count: 2},
+					Containers: []types.Container{
+						{ContainerName: testutils.InitContainerName, Init: true, Ready: true, State: types.ContainerStateTerminated},
+						{ContainerName: testutils.CappName, RestartCount: 3, State: types.ContainerStateWaiting},
 					},
 				},
 			},
@@ -55,7 +70,7 @@ func TestGetContainers(t *testing.T) {
 	setup()
 	containerController := NewContainerController(fakeClient, context.TODO(), logger)
 	createTestNamespace(namespaceName, utils.AddManagedLabel(map[string]string{}))
-	mocks.CreateTestPod(fakeClient, namespaceName, pod1, "", false)
+	mocks.CreateTestPodWithStatus(fakeClient, namespaceName, pod1, testutils.CappName)
 	mocks.CreateTestPod(fakeClient, namespaceName, pod2, testutils.CappName, true)
 
 	for name, test := range cases {
@@ -70,3 +85,106 @@ func TestGetContainers(t *testing.T) {
 		})
 	}
 }
+
+func TestGetContainer(t *testing.T) {
+	namespaceName := testutils.TestNamespace + "-getcontainer"
+	exitCode := int32(1)
+	initExitCode := int32(0)
+	type args struct {
+		podName       string
+		containerName string
+	}
+	type want struct {
+		response types.ContainerDetails
+		error    string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldSucceedGettingContainer": {
+			args: args{
+				podName:       pod1,
+				containerName: testutils.CappName,
+			},
+			want: want{
+				response: types.ContainerDetails{
+					ContainerName: testutils.CappName,
+					Image:         testutils.Image,
+					RestartCount:  3,
+					State: types.ContainerState{
+						State:  types.ContainerStateWaiting,
+						Reason: testutils.ReasonCrashLoopBackOff,
+					},
+					LastState: &types.ContainerState{
+						State:      types.ContainerStateTerminated,
+						Reason:     testutils.ReasonError,
+						ExitCode:   &exitCode,
+						StartedAt:  testutils.PodStartTime,
+						FinishedAt: testutils.PodStartTime,
+					},
+					Resources: types.ContainerResources{
+						Requests: map[string]string{"cpu": "100m", "memory": "128Mi"},
+						Limits:   map[string]string{"memory": "256Mi"},
+					},
+				},
+			},
+		},
+		"ShouldSucceedGettingInitContainer": {
+			args: args{
+				podName:       pod1,
+				containerName: testutils.InitContainerName,
+			},
+			want: want{
+				response: types.ContainerDetails{
+					ContainerName: testutils.InitContainerName,
+					Image:         testutils.Image,
+					Init:          true,
+					Ready:         true,
+					State: types.ContainerState{
+						State:      types.ContainerStateTerminated,
+						Reason:     testutils.ReasonCompleted,
+						ExitCode:   &initExitCode,
+						StartedAt:  testutils.PodStartTime,
+						FinishedAt: testutils.PodStartTime,
+					},
+				},
+			},
+		},
+		"ShouldNotFindNonExistingContainer": {
+			args: args{
+				podName:       pod1,
+				containerName: testutils.TestContainerName,
+			},
+			want: want{
+				error: fmt.Sprintf(ErrContainerNotFoundInPod, testutils.TestContainerName, pod1),
+			},
+		},
+		"ShouldNotFindContainerOfNonExistingPod": {
+			args: args{
+				podName:       pod1 + testutils.NonExistentSuffix,
+				containerName: testutils.CappName,
+			},
+			want: want{
+				error: fmt.Sprintf(ErrCouldNotGetPod, pod1+testutils.NonExistentSuffix, namespaceName),
+			},
+		},
+	}
+
+	setup()
+	containerController := NewContainerController(fakeClient, context.TODO(), logger)
+	createTestNamespace(namespaceName, utils.AddManagedLabel(map[string]string{}))
+	mocks.CreateTestPodWithStatus(fakeClient, namespaceName, pod1, testutils.CappName)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			response, err := containerController.GetContainer(namespaceName, test.args.podName, test.args.containerName)
+			if test.want.error != "" {
+				assert.ErrorContains(t, err, test.want.error)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want.response, response)
+		})
+	}
+}
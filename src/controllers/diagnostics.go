@@ -0,0 +1,476 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	knativeapis "knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+	"strings"
+)
+
+// diagnosticsEventsLimit is the number of most recent warning events of a Capp which are reported.
+const diagnosticsEventsLimit = 10
+
+const (
+	reasonCheckFailed              = "CheckFailed"
+	reasonCappDisabled             = "CappDisabled"
+	reasonKnativeServiceNotCreated = "KnativeServiceNotCreated"
+	reasonLatestRevisionNotReady   = "LatestRevisionNotReady"
+	reasonNoPods                   = "NoPods"
+	reasonUnschedulable            = "Unschedulable"
+	reasonOOMKilled                = "OOMKilled"
+	reasonDNSRecordNotFound        = "DNSRecordNotFound"
+	reasonDNSRecordNotReady        = "DNSRecordNotReady"
+	reasonSecretNotFound           = "SecretNotFound"
+	exceededQuotaMessage           = "exceeded quota"
+)
+
+const (
+	remediationCheckFailed              = "Make sure you have permissions to read the %s of the namespace and try again."
+	remediationCappDisabled             = "Enable the Capp by setting its state to enabled."
+	remediationCappCondition            = "Check the objects the Capp operator created for the Capp and the operator logs."
+	remediationKnativeCondition         = "Check the pods and events of the Capp's latest revision."
+	remediationKnativeServiceNotCreated = "Check that the Capp operator is running and the conditions of the Capp."
+	remediationNoPods                   = "The Capp may be scaled to zero. Send a request to the Capp to scale it up."
+	remediationUnschedulable            = "Lower the resource requests of the Capp or ask for more capacity in the cluster."
+	remediationOOMKilled                = "Increase the memory limit of the container or lower its memory usage."
+	remediationContainerWaiting         = "Check the events and logs of the pod."
+	remediationDNSRecordNotFound        = "Check that the hostname of the Capp is valid and that the DNS provider is reachable."
+	remediationDNSRecordNotReady        = "Check the conditions of the DNS record and that the hostname is not used by another record."
+	remediationSecretNotFound           = "Create the secret %q in namespace %q or remove its reference from the Capp."
+	remediationExceededQuota            = "Increase the resource quota of the namespace or lower the resource requests of the Capp."
+)
+
+// containerWaitingRemediations holds the remediation hints of the reasons a container may be stuck waiting for.
+var containerWaitingRemediations = map[string]string{
+	"ImagePullBackOff":           "Check that the image exists and that the namespace has the pull secret of its registry.",
+	"ErrImagePull":               "Check that the image exists and that the namespace has the pull secret of its registry.",
+	"InvalidImageName":           "Fix the image name of the container.",
+	"CrashLoopBackOff":           "Check the logs of the previous run of the container by fetching its logs with previous=true.",
+	"CreateContainerConfigError": "Check that the secrets and config maps referenced by the container exist.",
+	"CreateContainerError":       "Check the command and the volume mounts of the container.",
+}
+
+// transientWaitingReasons are the reasons a healthy container waits for while it is being started.
+var transientWaitingReasons = map[string]bool{
+	"ContainerCreating": true,
+	"PodInitializing":   true,
+}
+
+// eventRemediations holds the remediation hints of the reasons of warning events.
+var eventRemediations = map[string]string{
+	"FailedScheduling": remediationUnschedulable,
+	"FailedMount":      "Check that the volumes of the Capp and the secrets and config maps they reference exist.",
+	"BackOff":          remediationContainerWaiting,
+	"Failed":           remediationContainerWaiting,
+	"Unhealthy":        "Check the readiness and liveness probes of the Capp against the port it listens on.",
+}
+
+var severityRanks = map[string]int{
+	types.SeverityError:   0,
+	types.SeverityWarning: 1,
+	types.SeverityInfo:    2,
+}
+
+// DiagnosticsController defines methods to diagnose the health of Capps.
+type DiagnosticsController interface {
+	// GetCappDiagnostics correlates the status of a Capp with the status of its Knative service, its pods,
+	// its recent warning events, its DNS records and the secrets it references, and returns the issues found.
+	GetCappDiagnostics(namespace, name string) (types.CappDiagnostics, error)
+}
+
+// diagnosticsController implements the DiagnosticsController interface.
+type diagnosticsController struct {
+	client     client.Client
+	kubeClient kubernetes.Interface
+	ctx        context.Context
+	logger     *zap.Logger
+}
+
+// NewDiagnosticsController creates a new instance of DiagnosticsController.
+func NewDiagnosticsController(client client.Client, kubeClient kubernetes.Interface, context context.Context, logger *zap.Logger) DiagnosticsController {
+	return &diagnosticsController{
+		client:     client,
+		kubeClient: kubeClient,
+		ctx:        context,
+		logger:     logger,
+	}
+}
+
+func (d *diagnosticsController) GetCappDiagnostics(namespace, name string) (types.CappDiagnostics, error) {
+	d.logger.Debug(fmt.Sprintf("Trying to diagnose capp %q in namespace %q", name, namespace))
+
+	capp := cappv1alpha1.Capp{}
+	if err := d.client.Get(d.ctx, client.ObjectKey{Namespace: namespace, Name: name}, &capp); err != nil {
+		d.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err.Error()))
+		return types.CappDiagnostics{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err)
+	}
+
+	findings := diagnoseCappStatus(capp)
+	findings = append(findings, diagnoseKnativeStatus(capp)...)
+	findings = append(findings, d.diagnosePods(capp)...)
+	findings = append(findings, d.diagnoseEvents(capp)...)
+	findings = append(findings, d.diagnoseDNS(capp)...)
+	findings = append(findings, d.diagnoseSecrets(capp)...)
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return severityRanks[findings[i].Severity] < severityRanks[findings[j].Severity]
+	})
+
+	diagnostics := types.CappDiagnostics{CappName: name, Healthy: true, Findings: []types.Finding{}}
+	for _, finding := range findings {
+		diagnostics.Findings = append(diagnostics.Findings, finding)
+		if finding.Severity == types.SeverityError {
+			diagnostics.Healthy = false
+		}
+	}
+
+	d.logger.Debug(fmt.Sprintf("Diagnosed capp %q in namespace %q with %d findings", name, namespace, len(findings)))
+	return diagnostics, nil
+}
+
+// diagnoseCappStatus reports a disabled Capp and the conditions of the Capp which are not true.
+func diagnoseCappStatus(capp cappv1alpha1.Capp) []types.Finding {
+	var findings []types.Finding
+	if capp.Spec.State == disabledState {
+		findings = append(findings, types.Finding{
+			Severity:    types.SeverityInfo,
+			Source:      types.FindingSourceCapp,
+			Reason:      reasonCappDisabled,
+			Message:     "The Capp is disabled and has no running pods",
+			Remediation: remediationCappDisabled,
+		})
+	}
+
+	for _, condition := range capp.Status.Conditions {
+		if condition.Status == metav1.ConditionTrue {
+			continue
+		}
+
+		severity := types.SeverityError
+		if condition.Status == metav1.ConditionUnknown {
+			severity = types.SeverityWarning
+		}
+
+		findings = append(findings, types.Finding{
+			Severity:    severity,
+			Source:      types.FindingSourceCapp,
+			Object:      condition.Type,
+			Reason:      condition.Reason,
+			Message:     condition.Message,
+			Remediation: remediationCappCondition,
+		})
+	}
+
+	return findings
+}
+
+// diagnoseKnativeStatus reports the conditions of the Knative service of an enabled Capp which are not true,
+// and whether its latest revision is not ready.
+func diagnoseKnativeStatus(capp cappv1alpha1.Capp) []types.Finding {
+	if capp.Spec.State == disabledState {
+		return nil
+	}
+
+	knativeStatus := capp.Status.KnativeObjectStatus
+	if len(knativeStatus.Conditions) == 0 {
+		return []types.Finding{{
+			Severity:    types.SeverityWarning,
+			Source:      types.FindingSourceKnative,
+			Reason:      reasonKnativeServiceNotCreated,
+			Message:     "The Knative service of the Capp has no status yet",
+			Remediation: remediationKnativeServiceNotCreated,
+		}}
+	}
+
+	var findings []types.Finding
+	for _, condition := range knativeStatus.Conditions {
+		if condition.IsTrue() {
+			continue
+		}
+
+		findings = append(findings, types.Finding{
+			Severity:    knativeConditionSeverity(condition),
+			Source:      types.FindingSourceKnative,
+			Object:      string(condition.Type),
+			Reason:      condition.Reason,
+			Message:     condition.Message,
+			Remediation: remediationKnativeCondition,
+		})
+	}
+
+	latestCreated, latestReady := knativeStatus.LatestCreatedRevisionName, knativeStatus.LatestReadyRevisionName
+	if latestCreated != "" && latestCreated != latestReady {
+		findings = append(findings, types.Finding{
+			Severity:    types.SeverityWarning,
+			Source:      types.FindingSourceKnative,
+			Object:      latestCreated,
+			Reason:      reasonLatestRevisionNotReady,
+			Message:     fmt.Sprintf("The latest revision %q is not ready, the latest ready revision is %q", latestCreated, latestReady),
+			Remediation: remediationKnativeCondition,
+		})
+	}
+
+	return findings
+}
+
+// knativeConditionSeverity maps the severity of a Knative condition which is not true to the severity of a finding.
+func knativeConditionSeverity(condition knativeapis.Condition) string {
+	switch {
+	case condition.IsUnknown():
+		return types.SeverityWarning
+	case condition.Severity == knativeapis.ConditionSeverityWarning:
+		return types.SeverityWarning
+	case condition.Severity == knativeapis.ConditionSeverityInfo:
+		return types.SeverityInfo
+	default:
+		return types.SeverityError
+	}
+}
+
+// diagnosePods reports the pods of the Capp which can not be scheduled and their containers which are stuck or were killed.
+func (d *diagnosticsController) diagnosePods(capp cappv1alpha1.Capp) []types.Finding {
+	pods, err := utils.GetPodsByLabel(d.ctx, d.kubeClient, capp.Namespace, fmt.Sprintf(utils.ParentCappLabelSelector, capp.Name), metav1.ListOptions{})
+	if err != nil {
+		return []types.Finding{d.checkFailedFinding(types.FindingSourcePod, "pods", err)}
+	}
+
+	if len(pods.Items) == 0 {
+		if capp.Spec.State == disabledState {
+			return nil
+		}
+		return []types.Finding{{
+			Severity:    types.SeverityInfo,
+			Source:      types.FindingSourcePod,
+			Reason:      reasonNoPods,
+			Message:     "The Capp has no pods",
+			Remediation: remediationNoPods,
+		}}
+	}
+
+	var findings []types.Finding
+	for _, pod := range pods.Items {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+				findings = append(findings, types.Finding{
+					Severity:    types.SeverityError,
+					Source:      types.FindingSourcePod,
+					Object:      pod.Name,
+					Reason:      reasonUnschedulable,
+					Message:     condition.Message,
+					Remediation: remediationUnschedulable,
+				})
+			}
+		}
+
+		containerStatuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range containerStatuses {
+			findings = append(findings, diagnoseContainer(pod.Name, status)...)
+		}
+	}
+
+	return findings
+}
+
+// diagnoseContainer reports a container which is stuck waiting or whose last run was killed for running out of memory.
+func diagnoseContainer(podName string, status corev1.ContainerStatus) []types.Finding {
+	var findings []types.Finding
+	object := fmt.Sprintf("%s/%s", podName, status.Name)
+
+	if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "" && !transientWaitingReasons[waiting.Reason] {
+		severity := types.SeverityError
+		remediation, ok := containerWaitingRemediations[waiting.Reason]
+		if !ok {
+			severity = types.SeverityWarning
+			remediation = remediationContainerWaiting
+		}
+
+		message := waiting.Message
+		if message == "" {
+			message = fmt.Sprintf("The container is waiting with reason %q and was restarted %d times", waiting.Reason, status.RestartCount)
+		}
+
+		findings = append(findings, types.Finding{
+			Severity:    severity,
+			Source:      types.FindingSourcePod,
+			Object:      object,
+			Reason:      waiting.Reason,
+			Message:     message,
+			Remediation: remediation,
+		})
+	}
+
+	if terminated := status.LastTerminationState.Terminated; terminated != nil && terminated.Reason == reasonOOMKilled {
+		findings = append(findings, types.Finding{
+			Severity:    types.SeverityError,
+			Source:      types.FindingSourcePod,
+			Object:      object,
+			Reason:      reasonOOMKilled,
+			Message:     "The last run of the container was killed for exceeding its memory limit",
+			Remediation: remediationOOMKilled,
+		})
+	}
+
+	return findings
+}
+
+// diagnoseEvents reports the most recent warning events of the Capp.
+func (d *diagnosticsController) diagnoseEvents(capp cappv1alpha1.Capp) []types.Finding {
+	eventController := NewEventController(d.kubeClient, d.ctx, d.logger)
+	events, err := eventController.GetCappEvents(capp.Namespace, capp.Name, diagnosticsEventsLimit, 1, types.EventQuery{Type: corev1.EventTypeWarning}, types.ListQuery{})
+	if err != nil {
+		return []types.Finding{d.checkFailedFinding(types.FindingSourceEvent, "events", err)}
+	}
+
+	var findings []types.Finding
+	for _, event := range events.Events {
+		remediation := eventRemediations[event.Reason]
+		if strings.Contains(event.Message, exceededQuotaMessage) {
+			remediation = remediationExceededQuota
+		}
+
+		findings = append(findings, types.Finding{
+			Severity:    types.SeverityWarning,
+			Source:      types.FindingSourceEvent,
+			Object:      fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
+			Reason:      event.Reason,
+			Message:     event.Message,
+			Remediation: remediation,
+		})
+	}
+
+	return findings
+}
+
+// diagnoseDNS reports the DNS records of a Capp with a custom hostname which are missing or not ready.
+func (d *diagnosticsController) diagnoseDNS(capp cappv1alpha1.Capp) []types.Finding {
+	if capp.Spec.RouteSpec.Hostname == "" {
+		return nil
+	}
+
+	cappController := NewCappController(d.client, d.ctx, d.logger)
+	dns, err := cappController.GetCappDNS(capp.Namespace, capp.Name)
+	if err != nil {
+		return []types.Finding{d.checkFailedFinding(types.FindingSourceDNS, "DNS records", err)}
+	}
+
+	if len(dns.Records) == 0 {
+		return []types.Finding{{
+			Severity:    types.SeverityWarning,
+			Source:      types.FindingSourceDNS,
+			Object:      capp.Spec.RouteSpec.Hostname,
+			Reason:      reasonDNSRecordNotFound,
+			Message:     fmt.Sprintf("No DNS record was created for the hostname %q", capp.Spec.RouteSpec.Hostname),
+			Remediation: remediationDNSRecordNotFound,
+		}}
+	}
+
+	var findings []types.Finding
+	for _, record := range dns.Records {
+		if record.Status == corev1.ConditionTrue {
+			continue
+		}
+
+		severity := types.SeverityError
+		if record.Status == corev1.ConditionUnknown {
+			severity = types.SeverityWarning
+		}
+
+		findings = append(findings, types.Finding{
+			Severity:    severity,
+			Source:      types.FindingSourceDNS,
+			Object:      record.Name,
+			Reason:      reasonDNSRecordNotReady,
+			Message:     fmt.Sprintf("The DNS record %q is not ready", record.Name),
+			Remediation: remediationDNSRecordNotReady,
+		})
+	}
+
+	return findings
+}
+
+// diagnoseSecrets reports the secrets referenced by the Capp which do not exist.
+func (d *diagnosticsController) diagnoseSecrets(capp cappv1alpha1.Capp) []types.Finding {
+	var findings []types.Finding
+	for _, secretName := range getCappSecretNames(capp) {
+		_, err := d.kubeClient.CoreV1().Secrets(capp.Namespace).Get(d.ctx, secretName, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			findings = append(findings, types.Finding{
+				Severity:    types.SeverityError,
+				Source:      types.FindingSourceSecret,
+				Object:      secretName,
+				Reason:      reasonSecretNotFound,
+				Message:     fmt.Sprintf("The secret %q referenced by the Capp does not exist", secretName),
+				Remediation: fmt.Sprintf(remediationSecretNotFound, secretName, capp.Namespace),
+			})
+		} else if err != nil {
+			return append(findings, d.checkFailedFinding(types.FindingSourceSecret, "secrets", err))
+		}
+	}
+
+	return findings
+}
+
+// getCappSecretNames returns the names of the secrets the Capp requires, in the order they are referenced.
+// Optional references are ignored.
+func getCappSecretNames(capp cappv1alpha1.Capp) []string {
+	var secretNames []string
+	seen := map[string]bool{}
+	addSecret := func(name string, optional *bool) {
+		if name == "" || seen[name] || (optional != nil && *optional) {
+			return
+		}
+		seen[name] = true
+		secretNames = append(secretNames, name)
+	}
+
+	podSpec := capp.Spec.ConfigurationSpec.Template.Spec.PodSpec
+	containers := append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...)
+	for _, container := range containers {
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				addSecret(env.ValueFrom.SecretKeyRef.Name, env.ValueFrom.SecretKeyRef.Optional)
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				addSecret(envFrom.SecretRef.Name, envFrom.SecretRef.Optional)
+			}
+		}
+	}
+
+	for _, volume := range podSpec.Volumes {
+		if volume.Secret != nil {
+			addSecret(volume.Secret.SecretName, volume.Secret.Optional)
+		}
+	}
+
+	for _, imagePullSecret := range podSpec.ImagePullSecrets {
+		addSecret(imagePullSecret.Name, nil)
+	}
+
+	addSecret(capp.Spec.LogSpec.PasswordSecret, nil)
+	return secretNames
+}
+
+// checkFailedFinding reports a check which could not be completed, so that the rest of the diagnostics are still returned.
+func (d *diagnosticsController) checkFailedFinding(source, resource string, err error) types.Finding {
+	d.logger.Debug(fmt.Sprintf("Could not check %s with error: %v", resource, err))
+	return types.Finding{
+		Severity:    types.SeverityWarning,
+		Source:      source,
+		Reason:      reasonCheckFailed,
+		Message:     fmt.Sprintf("Could not check the %s of the Capp: %v", resource, err),
+		Remediation: fmt.Sprintf(remediationCheckFailed, resource),
+	}
+}
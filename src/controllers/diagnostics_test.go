@@ -0,0 +1,258 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	knativeapis "knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"testing"
+	"time"
+)
+
+const (
+	missingSecretName  = testutils.SecretName + "-missing"
+	existingSecretName = testutils.SecretName + "-existing"
+	quotaMessage       = "pods is forbidden: exceeded quota: compute-resources"
+)
+
+// prepareHealthyCapp returns a Capp whose Knative service is ready.
+func prepareHealthyCapp(name, namespace string) cappv1alpha1.Capp {
+	capp := mocks.PrepareCapp(name, namespace, testutils.Domain, nil, nil)
+	capp.Status.KnativeObjectStatus.Conditions = duckv1.Conditions{
+		{Type: knativeapis.ConditionReady, Status: corev1.ConditionTrue},
+	}
+	return capp
+}
+
+// prepareBrokenCapp returns a Capp with a custom hostname and a missing secret, whose conditions and
+// latest revision are not ready.
+func prepareBrokenCapp(name, namespace string) cappv1alpha1.Capp {
+	capp := mocks.PrepareCapp(name, namespace, testutils.Domain, nil, nil)
+	capp.Spec.RouteSpec.Hostname = testutils.Hostname
+	podSpec := &capp.Spec.ConfigurationSpec.Template.Spec.PodSpec
+	podSpec.Containers[0].Env = []corev1.EnvVar{{
+		Name: "PASSWORD",
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: missingSecretName},
+			Key:                  "password",
+		}},
+	}}
+	podSpec.Volumes = []corev1.Volume{{
+		Name:         "certs",
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: existingSecretName}},
+	}}
+
+	capp.Status.Conditions = []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionFalse, Reason: "ReconcileFailed", Message: "failed to reconcile"},
+	}
+	capp.Status.KnativeObjectStatus.Conditions = duckv1.Conditions{
+		{Type: knativeapis.ConditionReady, Status: corev1.ConditionFalse, Reason: "RevisionFailed", Message: "Revision failed"},
+	}
+	capp.Status.KnativeObjectStatus.LatestCreatedRevisionName = name + "-00002"
+	capp.Status.KnativeObjectStatus.LatestReadyRevisionName = name + "-00001"
+	return capp
+}
+
+func TestGetCappDiagnostics(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-diagnostics"
+	healthyCappName := testutils.CappName + "-healthy"
+	brokenCappName := testutils.CappName + "-broken"
+	disabledCappName := testutils.CappName + "-disabled"
+	scaledToZeroCappName := testutils.CappName + "-scaled-to-zero"
+
+	type want struct {
+		response    types.CappDiagnostics
+		errorStatus metav1.StatusReason
+	}
+	cases := map[string]struct {
+		cappName string
+		want     want
+	}{
+		"ShouldFindNothingForHealthyCapp": {
+			cappName: healthyCappName,
+			want: want{
+				response:    types.CappDiagnostics{CappName: healthyCappName, Healthy: true, Findings: []types.Finding{}},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldCorrelateFindingsOfBrokenCapp": {
+			cappName: brokenCappName,
+			want: want{
+				response: types.CappDiagnostics{
+					CappName: brokenCappName,
+					Healthy:  false,
+					Findings: []types.Finding{
+						{
+							Severity:    types.SeverityError,
+							Source:      types.FindingSourceCapp,
+							Object:      "Ready",
+							Reason:      "ReconcileFailed",
+							Message:     "failed to reconcile",
+							Remediation: remediationCappCondition,
+						},
+						{
+							Severity:    types.SeverityError,
+							Source:      types.FindingSourceKnative,
+							Object:      string(knativeapis.ConditionReady),
+							Reason:      "RevisionFailed",
+							Message:     "Revision failed",
+							Remediation: remediationKnativeCondition,
+						},
+						{
+							Severity:    types.SeverityError,
+							Source:      types.FindingSourcePod,
+							Object:      fmt.Sprintf("%s/%s", pod1, testutils.CappName),
+							Reason:      testutils.ReasonCrashLoopBackOff,
+							Message:     fmt.Sprintf("The container is waiting with reason %q and was restarted 3 times", testutils.ReasonCrashLoopBackOff),
+							Remediation: containerWaitingRemediations[testutils.ReasonCrashLoopBackOff],
+						},
+						{
+							Severity:    types.SeverityError,
+							Source:      types.FindingSourceDNS,
+							Object:      testutils.Hostname,
+							Reason:      reasonDNSRecordNotReady,
+							Message:     fmt.Sprintf("The DNS record %q is not ready", testutils.Hostname),
+							Remediation: remediationDNSRecordNotReady,
+						},
+						{
+							Severity:    types.SeverityError,
+							Source:      types.FindingSourceSecret,
+							Object:      missingSecretName,
+							Reason:      reasonSecretNotFound,
+							Message:     fmt.Sprintf("The secret %q referenced by the Capp does not exist", missingSecretName),
+							Remediation: fmt.Sprintf(remediationSecretNotFound, missingSecretName, namespaceName),
+						},
+						{
+							Severity:    types.SeverityWarning,
+							Source:      types.FindingSourceKnative,
+							Object:      brokenCappName + "-00002",
+							Reason:      reasonLatestRevisionNotReady,
+							Message:     fmt.Sprintf("The latest revision %q is not ready, the latest ready revision is %q", brokenCappName+"-00002", brokenCappName+"-00001"),
+							Remediation: remediationKnativeCondition,
+						},
+						{
+							Severity:    types.SeverityWarning,
+							Source:      types.FindingSourceEvent,
+							Object:      fmt.Sprintf("%s/%s", revisionKind, brokenCappName+"-00002"),
+							Reason:      "FailedCreate",
+							Message:     quotaMessage,
+							Remediation: remediationExceededQuota,
+						},
+					},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldOnlyReportDisabledCapp": {
+			cappName: disabledCappName,
+			want: want{
+				response: types.CappDiagnostics{
+					CappName: disabledCappName,
+					Healthy:  true,
+					Findings: []types.Finding{{
+						Severity:    types.SeverityInfo,
+						Source:      types.FindingSourceCapp,
+						Reason:      reasonCappDisabled,
+						Message:     "The Capp is disabled and has no running pods",
+						Remediation: remediationCappDisabled,
+					}},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldReportCappWithoutPodsOrKnativeStatus": {
+			cappName: scaledToZeroCappName,
+			want: want{
+				response: types.CappDiagnostics{
+					CappName: scaledToZeroCappName,
+					Healthy:  true,
+					Findings: []types.Finding{
+						{
+							Severity:    types.SeverityWarning,
+							Source:      types.FindingSourceKnative,
+							Reason:      reasonKnativeServiceNotCreated,
+							Message:     "The Knative service of the Capp has no status yet",
+							Remediation: remediationKnativeServiceNotCreated,
+						},
+						{
+							Severity:    types.SeverityInfo,
+							Source:      types.FindingSourcePod,
+							Reason:      reasonNoPods,
+							Message:     "The Capp has no pods",
+							Remediation: remediationNoPods,
+						},
+					},
+				},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldFailDiagnosingNonExistingCapp": {
+			cappName: testutils.CappName + testutils.NonExistentSuffix,
+			want: want{
+				errorStatus: metav1.StatusReasonNotFound,
+			},
+		},
+	}
+
+	setup()
+	createTestNamespace(namespaceName, utils.AddManagedLabel(map[string]string{}))
+
+	healthyCapp := prepareHealthyCapp(healthyCappName, namespaceName)
+	assert.NoError(t, dynClient.Create(context.TODO(), &healthyCapp))
+	mocks.CreateTestPod(fakeClient, namespaceName, pod2, healthyCappName, false)
+
+	brokenCapp := prepareBrokenCapp(brokenCappName, namespaceName)
+	assert.NoError(t, dynClient.Create(context.TODO(), &brokenCapp))
+	mocks.CreateTestPodWithStatus(fakeClient, namespaceName, pod1, brokenCappName)
+	mocks.CreateTestCNAMERecord(dynClient, testutils.Hostname, brokenCappName, namespaceName, testutils.Hostname, corev1.ConditionFalse, corev1.ConditionTrue)
+	createTestSecret(existingSecretName, namespaceName, nil)
+	quotaEvent := mocks.PrepareEvent(namespaceName, testutils.EventName, revisionKind, brokenCappName+"-00002", corev1.EventTypeWarning, "FailedCreate", time.Now())
+	quotaEvent.Message = quotaMessage
+	_, err := fakeClient.CoreV1().Events(namespaceName).Create(context.TODO(), quotaEvent, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	disabledCapp := mocks.PrepareCappWithState(disabledCappName, namespaceName, testutils.DisabledState, nil, nil)
+	assert.NoError(t, dynClient.Create(context.TODO(), &disabledCapp))
+
+	mocks.CreateTestCapp(dynClient, scaledToZeroCappName, namespaceName, testutils.Domain, nil, nil)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			diagnosticsController := NewDiagnosticsController(dynClient, fakeClient, context.TODO(), logger)
+			response, err := diagnosticsController.GetCappDiagnostics(namespaceName, test.cappName)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+				assert.Equal(t, test.want.errorStatus, reason)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want.response, response)
+		})
+	}
+}
+
+func TestGetCappSecretNames(t *testing.T) {
+	optional := true
+	capp := mocks.PrepareCapp(testutils.CappName, testutils.CappNamespace, testutils.Domain, nil, nil)
+	capp.Spec.LogSpec.PasswordSecret = testutils.SecretName + "-logs"
+	podSpec := &capp.Spec.ConfigurationSpec.Template.Spec.PodSpec
+	podSpec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: testutils.SecretName + "-pull"}}
+	podSpec.Containers[0].EnvFrom = []corev1.EnvFromSource{
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: testutils.SecretName + "-env"}}},
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: testutils.SecretName + "-optional"}, Optional: &optional}},
+	}
+	podSpec.Volumes = []corev1.Volume{
+		{Name: "env", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: testutils.SecretName + "-env"}}},
+	}
+
+	assert.Equal(t, []string{testutils.SecretName + "-env", testutils.SecretName + "-pull", testutils.SecretName + "-logs"}, getCappSecretNames(capp))
+}
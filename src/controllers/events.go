@@ -0,0 +1,350 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils"
+	"github.com/dana-team/platform-backend/src/utils/pagination"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
+	"regexp"
+)
+
+const (
+	ErrCouldNotGetEvents   = "Could not get events in namespace %q"
+	ErrCouldNotWatchEvents = "Could not watch events in namespace %q"
+)
+
+const (
+	cappKind          = "Capp"
+	serviceKind       = "Service"
+	configurationKind = "Configuration"
+	revisionKind      = "Revision"
+	podKind           = "Pod"
+
+	// revisionNamePattern matches the names Knative gives to the revisions of a Capp's configuration,
+	// and podNamePattern matches the names of the pods of these revisions.
+	revisionNamePattern = `^%s-\d{5}$`
+	podNamePattern      = `^%s-\d{5}-deployment-[a-z0-9]+-[a-z0-9]+$`
+)
+
+// cappEventObjectGroups maps the kinds of the objects whose events are the events of a Capp to their API groups,
+// so that objects of other groups sharing a kind and a name, such as core services, are not matched.
+var cappEventObjectGroups = map[string]string{
+	cappKind:          cappv1alpha1.GroupVersion.Group,
+	serviceKind:       knativev1.SchemeGroupVersion.Group,
+	configurationKind: knativev1.SchemeGroupVersion.Group,
+	revisionKind:      knativev1.SchemeGroupVersion.Group,
+	podKind:           corev1.GroupName,
+}
+
+// EventController defines methods to interact with Kubernetes events.
+type EventController interface {
+	// GetCappEvents returns the events of a Capp and of its Knative service, configuration, revisions and pods,
+	// sorted by their last timestamp with the most recent first unless the list query sorts them otherwise.
+	GetCappEvents(namespace, cappName string, limit, page int, query types.EventQuery, listQuery types.ListQuery) (types.EventList, error)
+
+	// GetNamespaceEvents returns all events in a namespace, sorted by their last timestamp with the most recent
+	// first unless the list query sorts them otherwise.
+	GetNamespaceEvents(namespace string, limit, page int, query types.EventQuery, listQuery types.ListQuery) (types.EventList, error)
+
+	// WatchCappEvents watches the events of a Capp and of its Knative service, configuration, revisions and pods.
+	// The channel is closed when the watch ends or the context is done.
+	WatchCappEvents(namespace, cappName string, query types.EventQuery) (<-chan types.EventWatchEvent, error)
+
+	// WatchNamespaceEvents watches all events in a namespace. The channel is closed when the watch ends or the context is done.
+	WatchNamespaceEvents(namespace string, query types.EventQuery) (<-chan types.EventWatchEvent, error)
+}
+
+// eventController implements the EventController interface.
+type eventController struct {
+	client kubernetes.Interface
+	ctx    context.Context
+	logger *zap.Logger
+}
+
+// EventPaginator paginates through the events in a specified namespace which match a filter.
+type EventPaginator struct {
+	pagination.GenericPaginator
+	namespace string
+	client    kubernetes.Interface
+	filter    func(event corev1.Event) bool
+}
+
+// NewEventController creates a new instance of EventController.
+func NewEventController(client kubernetes.Interface, context context.Context, logger *zap.Logger) EventController {
+	return &eventController{
+		client: client,
+		ctx:    context,
+		logger: logger,
+	}
+}
+
+func (e *eventController) GetCappEvents(namespace, cappName string, limit, page int, query types.EventQuery, listQuery types.ListQuery) (types.EventList, error) {
+	e.logger.Debug(fmt.Sprintf("Trying to get events of capp %q in namespace %q", cappName, namespace))
+
+	filter, err := e.cappEventFilter(namespace, cappName, query)
+	if err != nil {
+		return types.EventList{}, err
+	}
+
+	return e.getEvents(namespace, limit, page, listQuery, filter)
+}
+
+func (e *eventController) GetNamespaceEvents(namespace string, limit, page int, query types.EventQuery, listQuery types.ListQuery) (types.EventList, error) {
+	e.logger.Debug(fmt.Sprintf("Trying to get events in namespace %q", namespace))
+
+	return e.getEvents(namespace, limit, page, listQuery, eventTypeFilter(query))
+}
+
+func (e *eventController) WatchCappEvents(namespace, cappName string, query types.EventQuery) (<-chan types.EventWatchEvent, error) {
+	e.logger.Debug(fmt.Sprintf("Trying to watch events of capp %q in namespace %q", cappName, namespace))
+
+	filter, err := e.cappEventFilter(namespace, cappName, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.watchEvents(namespace, filter)
+}
+
+func (e *eventController) WatchNamespaceEvents(namespace string, query types.EventQuery) (<-chan types.EventWatchEvent, error) {
+	e.logger.Debug(fmt.Sprintf("Trying to watch events in namespace %q", namespace))
+
+	return e.watchEvents(namespace, eventTypeFilter(query))
+}
+
+// getEvents returns the specified page of the events in the namespace which match the filter and the list query.
+func (e *eventController) getEvents(namespace string, limit, page int, listQuery types.ListQuery, filter func(event corev1.Event) bool) (types.EventList, error) {
+	eventPaginator := &EventPaginator{
+		GenericPaginator: pagination.CreatePaginator(e.ctx, e.logger),
+		namespace:        namespace,
+		client:           e.client,
+		filter:           filter,
+	}
+
+	events, listMetadata, err := pagination.FetchSortedPage[corev1.Event](limit, page, eventPaginator, listQuery, isMoreRecentEvent)
+	if err != nil {
+		e.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotGetEvents, namespace), err))
+		return types.EventList{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetEvents, namespace), err)
+	}
+
+	response := types.EventList{Events: []types.Event{}, ListMetadata: listMetadata}
+	for _, event := range events {
+		response.Events = append(response.Events, convertEventToType(event))
+	}
+
+	e.logger.Debug("Fetched events successfully")
+	return response, nil
+}
+
+// watchEvents opens a watch on the events in the namespace and forwards those which match the filter.
+func (e *eventController) watchEvents(namespace string, filter func(event corev1.Event) bool) (<-chan types.EventWatchEvent, error) {
+	watcher, err := e.client.CoreV1().Events(namespace).Watch(e.ctx, metav1.ListOptions{})
+	if err != nil {
+		e.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotWatchEvents, namespace), err.Error()))
+		return nil, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotWatchEvents, namespace), err)
+	}
+
+	events := make(chan types.EventWatchEvent)
+	go func() {
+		defer close(events)
+		defer watcher.Stop()
+
+		for {
+			select {
+			case <-e.ctx.Done():
+				return
+			case event, ok := <-watcher.ResultChan():
+				if !ok {
+					return
+				}
+
+				watchEvent, ok := convertEventWatchEvent(event, filter)
+				if !ok {
+					continue
+				}
+
+				select {
+				case <-e.ctx.Done():
+					return
+				case events <- watchEvent:
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// cappEventFilter returns a filter matching the events of the given type whose involved object is the Capp,
+// its Knative service or configuration, one of its revisions or one of its pods, of their API groups. Pods are matched by the
+// parent Capp label, and by name so that events of pods which no longer exist are matched too.
+func (e *eventController) cappEventFilter(namespace, cappName string, query types.EventQuery) (func(event corev1.Event) bool, error) {
+	pods, err := utils.GetPodsByLabel(e.ctx, e.client, namespace, fmt.Sprintf(utils.ParentCappLabelSelector, cappName), metav1.ListOptions{})
+	if err != nil {
+		e.logger.Error(fmt.Sprintf("%v: %s", errFetchingCappPods, err.Error()))
+		return nil, customerrors.NewAPIError(errFetchingCappPods, err)
+	}
+
+	podNames := map[string]bool{}
+	for _, pod := range pods.Items {
+		podNames[pod.Name] = true
+	}
+
+	revisionName := regexp.MustCompile(fmt.Sprintf(revisionNamePattern, regexp.QuoteMeta(cappName)))
+	podName := regexp.MustCompile(fmt.Sprintf(podNamePattern, regexp.QuoteMeta(cappName)))
+	typeFilter := eventTypeFilter(query)
+
+	return func(event corev1.Event) bool {
+		if !typeFilter(event) {
+			return false
+		}
+
+		object := event.InvolvedObject
+		group, ok := cappEventObjectGroups[object.Kind]
+		if !ok || !isObjectOfGroup(object, group) {
+			return false
+		}
+
+		switch object.Kind {
+		case cappKind, serviceKind, configurationKind:
+			return object.Name == cappName
+		case revisionKind:
+			return revisionName.MatchString(object.Name)
+		case podKind:
+			return podNames[object.Name] || podName.MatchString(object.Name)
+		default:
+			return false
+		}
+	}, nil
+}
+
+// isObjectOfGroup returns whether the referenced object belongs to the given API group.
+func isObjectOfGroup(object corev1.ObjectReference, group string) bool {
+	groupVersion, err := schema.ParseGroupVersion(object.APIVersion)
+	return err == nil && groupVersion.Group == group
+}
+
+// eventTypeFilter returns a filter matching the events of the type of the query, or all events if it is not set.
+func eventTypeFilter(query types.EventQuery) func(event corev1.Event) bool {
+	return func(event corev1.Event) bool {
+		return query.Type == "" || event.Type == query.Type
+	}
+}
+
+func (p *EventPaginator) FetchList(listOptions metav1.ListOptions) (*types.List[corev1.Event], error) {
+	events, err := p.client.CoreV1().Events(p.namespace).List(p.Ctx, metav1.ListOptions{
+		Limit:    listOptions.Limit,
+		Continue: listOptions.Continue,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := &types.List[corev1.Event]{ListMeta: events.ListMeta}
+	for _, event := range events.Items {
+		if p.filter(event) {
+			list.Items = append(list.Items, event)
+		}
+	}
+
+	return list, nil
+}
+
+// convertEventWatchEvent converts a watch event of an event to an EventWatchEvent. It returns false
+// for events which should not be forwarded to the client, such as bookmarks or events not matching the filter.
+func convertEventWatchEvent(event watch.Event, filter func(event corev1.Event) bool) (types.EventWatchEvent, bool) {
+	watchEvent := types.EventWatchEvent{Type: string(event.Type)}
+
+	switch event.Type {
+	case watch.Added, watch.Modified, watch.Deleted:
+		k8sEvent, ok := event.Object.(*corev1.Event)
+		if !ok || !filter(*k8sEvent) {
+			return types.EventWatchEvent{}, false
+		}
+		convertedEvent := convertEventToType(*k8sEvent)
+		watchEvent.Object = &convertedEvent
+	case watch.Error:
+		watchEvent.Error = k8serrors.FromObject(event.Object).Error()
+	default:
+		return types.EventWatchEvent{}, false
+	}
+
+	return watchEvent, true
+}
+
+// convertEventToType converts an event to its type. Events created through the events.k8s.io API only set
+// the event time and series, so these are used when the deprecated timestamps and count are not set.
+func convertEventToType(event corev1.Event) types.Event {
+	count := event.Count
+	if count == 0 && event.Series != nil {
+		count = event.Series.Count
+	}
+	if count == 0 {
+		count = 1
+	}
+
+	source := event.Source.Component
+	if source == "" {
+		source = event.ReportingController
+	}
+
+	return types.Event{
+		Name:    event.Name,
+		Type:    event.Type,
+		Reason:  event.Reason,
+		Message: event.Message,
+		InvolvedObject: types.InvolvedObject{
+			Kind: event.InvolvedObject.Kind,
+			Name: event.InvolvedObject.Name,
+		},
+		Source:         source,
+		Count:          count,
+		FirstTimestamp: formatTimestamp(eventFirstTimestamp(event)),
+		LastTimestamp:  formatTimestamp(eventLastTimestamp(event)),
+	}
+}
+
+// isMoreRecentEvent orders events by their last timestamp with the most recent first, and then by name.
+func isMoreRecentEvent(first, second *corev1.Event) bool {
+	firstTimestamp, secondTimestamp := eventLastTimestamp(*first), eventLastTimestamp(*second)
+	if !firstTimestamp.Equal(&secondTimestamp) {
+		return secondTimestamp.Before(&firstTimestamp)
+	}
+
+	return first.Name < second.Name
+}
+
+// eventFirstTimestamp returns the time the event was first observed.
+func eventFirstTimestamp(event corev1.Event) metav1.Time {
+	switch {
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp
+	case !event.EventTime.IsZero():
+		return metav1.NewTime(event.EventTime.Time)
+	default:
+		return event.CreationTimestamp
+	}
+}
+
+// eventLastTimestamp returns the time the event was last observed.
+func eventLastTimestamp(event corev1.Event) metav1.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return metav1.NewTime(event.Series.LastObservedTime.Time)
+	default:
+		return eventFirstTimestamp(event)
+	}
+}
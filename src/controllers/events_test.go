@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"context"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils"
+	"github.com/dana-team/platform-backend/src/utils/pagination"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"testing"
+	"time"
+)
+
+const (
+	cappEvent            = testutils.EventName + "-capp"
+	serviceEvent         = testutils.EventName + "-service"
+	revisionEvent        = testutils.EventName + "-revision"
+	podEvent             = testutils.EventName + "-pod"
+	deletedPodEvent      = testutils.EventName + "-deleted-pod"
+	otherPodEvent        = testutils.EventName + "-other-pod"
+	otherRevisionEvent   = testutils.EventName + "-other-revision"
+	coreServiceEvent     = testutils.EventName + "-core-service"
+	deletedCappPodSuffix = "-deployment-5d8f7b9c4-x7k2p"
+)
+
+// createTestEvents creates events about a Capp and its objects, one minute apart, along with events about other objects.
+func createTestEvents(namespace string) {
+	eventTime, _ := time.Parse(time.RFC3339, testutils.EventTimestamp)
+	mocks.CreateTestPod(fakeClient, namespace, pod1, testutils.CappName, false)
+	mocks.CreateTestPod(fakeClient, namespace, pod2, "", false)
+
+	mocks.CreateTestEvent(fakeClient, namespace, cappEvent, cappKind, testutils.CappName, corev1.EventTypeNormal, testutils.EventReasonCreated, eventTime)
+	mocks.CreateTestEvent(fakeClient, namespace, deletedPodEvent, podKind, testutils.RevisionName+deletedCappPodSuffix, corev1.EventTypeNormal, testutils.EventReasonCreated, eventTime.Add(time.Minute))
+	mocks.CreateTestEvent(fakeClient, namespace, revisionEvent, revisionKind, testutils.RevisionName, corev1.EventTypeWarning, testutils.EventReasonBackOff, eventTime.Add(2*time.Minute))
+	mocks.CreateTestEvent(fakeClient, namespace, podEvent, podKind, pod1, corev1.EventTypeWarning, testutils.EventReasonBackOff, eventTime.Add(3*time.Minute))
+	mocks.CreateTestEvent(fakeClient, namespace, serviceEvent, serviceKind, testutils.CappName, corev1.EventTypeNormal, testutils.EventReasonCreated, eventTime.Add(4*time.Minute))
+	mocks.CreateTestEvent(fakeClient, namespace, otherPodEvent, podKind, pod2, corev1.EventTypeWarning, testutils.EventReasonBackOff, eventTime.Add(5*time.Minute))
+	mocks.CreateTestEvent(fakeClient, namespace, otherRevisionEvent, revisionKind, testutils.CappName+"-other-00001", corev1.EventTypeNormal, testutils.EventReasonCreated, eventTime.Add(6*time.Minute))
+
+	coreService := mocks.PrepareEvent(namespace, coreServiceEvent, serviceKind, testutils.CappName, corev1.EventTypeNormal, testutils.EventReasonCreated, eventTime.Add(7*time.Minute))
+	coreService.InvolvedObject.APIVersion = corev1.SchemeGroupVersion.String()
+	_, err := fakeClient.CoreV1().Events(namespace).Create(context.TODO(), coreService, metav1.CreateOptions{})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func eventNames(events []types.Event) []string {
+	var names []string
+	for _, event := range events {
+		names = append(names, event.Name)
+	}
+	return names
+}
+
+func TestGetCappEvents(t *testing.T) {
+	namespaceName := testutils.TestNamespace + "-getCappEvents"
+	remainingItemCount := int64(3)
+	type args struct {
+		cappName  string
+		limit     int
+		page      int
+		query     types.EventQuery
+		listQuery types.ListQuery
+	}
+	type want struct {
+		names        []string
+		listMetadata types.ListMetadata
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldGetCappEventsSortedByLastTimestamp": {
+			args: args{cappName: testutils.CappName, limit: 10, page: 1},
+			want: want{
+				names:        []string{serviceEvent, podEvent, revisionEvent, deletedPodEvent, cappEvent},
+				listMetadata: types.ListMetadata{Count: 5},
+			},
+		},
+		"ShouldGetWarningCappEvents": {
+			args: args{cappName: testutils.CappName, limit: 10, page: 1, query: types.EventQuery{Type: corev1.EventTypeWarning}},
+			want: want{
+				names:        []string{podEvent, revisionEvent},
+				listMetadata: types.ListMetadata{Count: 2},
+			},
+		},
+		"ShouldGetFirstPageOfCappEvents": {
+			args: args{cappName: testutils.CappName, limit: 2, page: 1},
+			want: want{
+				names:        []string{serviceEvent, podEvent},
+				listMetadata: types.ListMetadata{Count: 2, HasMore: true, RemainingItemCount: &remainingItemCount},
+			},
+		},
+		"ShouldGetLastPageOfCappEvents": {
+			args: args{cappName: testutils.CappName, limit: 2, page: 3},
+			want: want{
+				names:        []string{cappEvent},
+				listMetadata: types.ListMetadata{Count: 1},
+			},
+		},
+		"ShouldGetCappEventsSortedByName": {
+			args: args{cappName: testutils.CappName, limit: 10, page: 1, listQuery: types.ListQuery{SortBy: pagination.SortByName}},
+			want: want{
+				names:        []string{cappEvent, deletedPodEvent, podEvent, revisionEvent, serviceEvent},
+				listMetadata: types.ListMetadata{Count: 5},
+			},
+		},
+		"ShouldSearchCappEvents": {
+			args: args{cappName: testutils.CappName, limit: 10, page: 1, listQuery: types.ListQuery{Search: "pod"}},
+			want: want{
+				names:        []string{podEvent, deletedPodEvent},
+				listMetadata: types.ListMetadata{Count: 2},
+			},
+		},
+		"ShouldGetNoEventsOfCappWithoutEvents": {
+			args: args{cappName: testutils.CappName + testutils.NonExistentSuffix, limit: 10, page: 1},
+			want: want{},
+		},
+	}
+
+	setup()
+	createTestNamespace(namespaceName, utils.AddManagedLabel(map[string]string{}))
+	createTestEvents(namespaceName)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			eventController := NewEventController(fakeClient, context.TODO(), logger)
+			response, err := eventController.GetCappEvents(namespaceName, test.args.cappName, test.args.limit, test.args.page, test.args.query, test.args.listQuery)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want.names, eventNames(response.Events))
+			assert.Equal(t, test.want.listMetadata, response.ListMetadata)
+		})
+	}
+}
+
+func TestGetNamespaceEvents(t *testing.T) {
+	namespaceName := testutils.TestNamespace + "-getNamespaceEvents"
+	type args struct {
+		query types.EventQuery
+	}
+	cases := map[string]struct {
+		args args
+		want []string
+	}{
+		"ShouldGetAllEventsSortedByLastTimestamp": {
+			want: []string{coreServiceEvent, otherRevisionEvent, otherPodEvent, serviceEvent, podEvent, revisionEvent, deletedPodEvent, cappEvent},
+		},
+		"ShouldGetWarningEvents": {
+			args: args{query: types.EventQuery{Type: corev1.EventTypeWarning}},
+			want: []string{otherPodEvent, podEvent, revisionEvent},
+		},
+	}
+
+	setup()
+	createTestNamespace(namespaceName, utils.AddManagedLabel(map[string]string{}))
+	createTestEvents(namespaceName)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			eventController := NewEventController(fakeClient, context.TODO(), logger)
+			response, err := eventController.GetNamespaceEvents(namespaceName, 10, 1, test.args.query, types.ListQuery{})
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, eventNames(response.Events))
+		})
+	}
+}
+
+func TestWatchCappEvents(t *testing.T) {
+	namespaceName := testutils.TestNamespace + "-watchCappEvents"
+	eventTime, _ := time.Parse(time.RFC3339, testutils.EventTimestamp)
+
+	setup()
+	createTestNamespace(namespaceName, utils.AddManagedLabel(map[string]string{}))
+	mocks.CreateTestPod(fakeClient, namespaceName, pod1, testutils.CappName, false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventController := NewEventController(fakeClient, ctx, logger)
+	events, err := eventController.WatchCappEvents(namespaceName, testutils.CappName, types.EventQuery{})
+	assert.NoError(t, err)
+
+	mocks.CreateTestEvent(fakeClient, namespaceName, otherPodEvent, podKind, pod2, corev1.EventTypeWarning, testutils.EventReasonBackOff, eventTime)
+	mocks.CreateTestEvent(fakeClient, namespaceName, podEvent, podKind, pod1, corev1.EventTypeWarning, testutils.EventReasonBackOff, eventTime)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, types.EventWatchEvent{Type: string(watch.Added), Object: &types.Event{
+			Name:           podEvent,
+			Type:           corev1.EventTypeWarning,
+			Reason:         testutils.EventReasonBackOff,
+			Message:        testutils.EventReasonBackOff,
+			InvolvedObject: types.InvolvedObject{Kind: podKind, Name: pod1},
+			Source:         "kubelet",
+			Count:          1,
+			FirstTimestamp: testutils.EventTimestamp,
+			LastTimestamp:  testutils.EventTimestamp,
+		}}, event)
+	case <-time.After(watchTimeout):
+		t.Fatal("Timed out waiting for event")
+	}
+}
+
+func TestConvertEventToType(t *testing.T) {
+	eventTime, _ := time.Parse(time.RFC3339, testutils.EventTimestamp)
+	lastObservedTime := eventTime.Add(time.Minute)
+
+	cases := map[string]struct {
+		event corev1.Event
+		want  types.Event
+	}{
+		"ShouldConvertEventsAPIEvent": {
+			event: corev1.Event{
+				ObjectMeta:          metav1.ObjectMeta{Name: testutils.EventName},
+				InvolvedObject:      corev1.ObjectReference{Kind: podKind, Name: pod1},
+				Type:                corev1.EventTypeWarning,
+				Reason:              testutils.EventReasonBackOff,
+				EventTime:           metav1.NewMicroTime(eventTime),
+				Series:              &corev1.EventSeries{Count: 4, LastObservedTime: metav1.NewMicroTime(lastObservedTime)},
+				ReportingController: "kubelet",
+			},
+			want: types.Event{
+				Name:           testutils.EventName,
+				Type:           corev1.EventTypeWarning,
+				Reason:         testutils.EventReasonBackOff,
+				InvolvedObject: types.InvolvedObject{Kind: podKind, Name: pod1},
+				Source:         "kubelet",
+				Count:          4,
+				FirstTimestamp: testutils.EventTimestamp,
+				LastTimestamp:  lastObservedTime.Format(time.RFC3339),
+			},
+		},
+		"ShouldConvertEventWithoutTimestamps": {
+			event: corev1.Event{
+				ObjectMeta:     metav1.ObjectMeta{Name: testutils.EventName, CreationTimestamp: metav1.NewTime(eventTime)},
+				InvolvedObject: corev1.ObjectReference{Kind: cappKind, Name: testutils.CappName},
+				Type:           corev1.EventTypeNormal,
+			},
+			want: types.Event{
+				Name:           testutils.EventName,
+				Type:           corev1.EventTypeNormal,
+				InvolvedObject: types.InvolvedObject{Kind: cappKind, Name: testutils.CappName},
+				Count:          1,
+				FirstTimestamp: testutils.EventTimestamp,
+				LastTimestamp:  testutils.EventTimestamp,
+			},
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, convertEventToType(test.event))
+		})
+	}
+}
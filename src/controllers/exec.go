@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/utils"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+	"net/http"
+)
+
+const (
+	podsResource    = "pods"
+	execSubresource = "exec"
+)
+
+const (
+	ErrContainerNotFoundInPod = "container %q not found in the pod %q"
+	errCreatingExecutor       = "error creating executor"
+	errExecInContainer        = "error executing command in container"
+)
+
+// DefaultExecCommand is the command that is executed when no command is given.
+var DefaultExecCommand = []string{"/bin/sh"}
+
+// ExecInContainer executes the command in a container of a pod and attaches the given streams to it until the command
+// exits or the context is done. The session is opened over the WebSocket executor, falling back to SPDY for API
+// servers that do not support it. The exit code of the command is returned.
+func ExecInContainer(ctx context.Context, client kubernetes.Interface, config *rest.Config, namespace, podName, containerName string, command []string, tty bool, streams remotecommand.StreamOptions, logger *zap.Logger) (int, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		logger.Error(fmt.Sprintf("%v with error: %s", fmt.Sprintf(ErrCouldNotGetPod, podName, namespace), err.Error()))
+		return 0, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetPod, podName, namespace), err)
+	}
+
+	if !utils.IsContainerInPod(pod, containerName) {
+		return 0, customerrors.NewNotFoundError(fmt.Sprintf(ErrContainerNotFoundInPod, containerName, podName))
+	}
+
+	if len(command) == 0 {
+		command = DefaultExecCommand
+	}
+
+	executor, err := newPodExecutor(config, namespace, podName, &corev1.PodExecOptions{
+		Container: containerName,
+		Command:   command,
+		Stdin:     streams.Stdin != nil,
+		Stdout:    streams.Stdout != nil,
+		Stderr:    streams.Stderr != nil && !tty,
+		TTY:       tty,
+	})
+	if err != nil {
+		logger.Error(fmt.Sprintf("%v: %v", errCreatingExecutor, err))
+		return 0, customerrors.NewAPIError(errCreatingExecutor, err)
+	}
+
+	if tty {
+		streams.Stderr = nil
+	}
+	streams.Tty = tty
+
+	err = executor.StreamWithContext(ctx, streams)
+	var exitErr exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.Exited() {
+		return exitErr.ExitStatus(), nil
+	}
+
+	if err != nil {
+		logger.Debug(fmt.Sprintf("%v: %v", errExecInContainer, err))
+		return 0, customerrors.NewAPIError(errExecInContainer, err)
+	}
+
+	return 0, nil
+}
+
+// newPodExecutor creates an executor for the exec subresource of the pod, using the WebSocket executor
+// with a fallback to the SPDY executor.
+func newPodExecutor(config *rest.Config, namespace, podName string, execOptions *corev1.PodExecOptions) (remotecommand.Executor, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	execURL := clientset.CoreV1().RESTClient().Post().
+		Resource(podsResource).
+		Namespace(namespace).
+		Name(podName).
+		SubResource(execSubresource).
+		VersionedParams(execOptions, scheme.ParameterCodec).
+		URL()
+
+	websocketExecutor, err := remotecommand.NewWebSocketExecutor(config, http.MethodGet, execURL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	spdyExecutor, err := remotecommand.NewSPDYExecutor(config, http.MethodPost, execURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return remotecommand.NewFallbackExecutor(websocketExecutor, spdyExecutor, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/utils"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"strings"
+	"testing"
+)
+
+func TestExecInContainer(t *testing.T) {
+	namespaceName := testutils.TestNamespace + "-exec"
+	type args struct {
+		podName       string
+		containerName string
+	}
+	type want struct {
+		error string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldFailWithNonExistingPod": {
+			args: args{
+				podName:       pod1 + testutils.NonExistentSuffix,
+				containerName: testutils.TestContainerName,
+			},
+			want: want{
+				error: fmt.Sprintf("%v, %v", fmt.Sprintf(ErrCouldNotGetPod, pod1+testutils.NonExistentSuffix, namespaceName), fmt.Sprintf(`pods %q not found`, pod1+testutils.NonExistentSuffix)),
+			},
+		},
+		"ShouldFailWithNonExistingContainer": {
+			args: args{
+				podName:       pod1,
+				containerName: testutils.CappName,
+			},
+			want: want{
+				error: fmt.Sprintf(ErrContainerNotFoundInPod, testutils.CappName, pod1),
+			},
+		},
+		"ShouldFailWhenAPIServerIsUnreachable": {
+			args: args{
+				podName:       pod1,
+				containerName: testutils.TestContainerName,
+			},
+			want: want{
+				error: errExecInContainer,
+			},
+		},
+	}
+
+	setup()
+	createTestNamespace(namespaceName, utils.AddManagedLabel(map[string]string{}))
+	mocks.CreateTestPod(fakeClient, namespaceName, pod1, "", false)
+	config := &rest.Config{Host: "http://127.0.0.1:1"}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			streams := remotecommand.StreamOptions{Stdin: strings.NewReader("")}
+			_, err := ExecInContainer(context.TODO(), fakeClient, config, namespaceName, tc.args.podName, tc.args.containerName, nil, false, streams, logger)
+			assert.ErrorContains(t, err, tc.want.error)
+		})
+	}
+}
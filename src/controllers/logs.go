@@ -1,13 +1,18 @@
 package controllers
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils/logfilter"
 	"go.uber.org/zap"
-	"io"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	"sync"
 
 	"github.com/dana-team/platform-backend/src/utils"
 	"k8s.io/client-go/kubernetes"
@@ -18,45 +23,69 @@ const (
 	errFetchingCappPods      = "error fetching Capp pods"
 	errNoPodsFound           = "no pods found for Capp %q in namespace %q"
 	errPodNotFound           = "pod %q not found for Capp %q in namespace %q"
+	errWatchingCappPods      = "error watching Capp pods"
 )
 
+const logLinesBufferSize = 100
+
 // FetchPodLogs retrieves the logs of a specific container in a pod.
-// It opens a log stream, reads the logs, and returns them as a string.
-func FetchPodLogs(ctx context.Context, client kubernetes.Interface, namespace, podName, containerName string, previous bool, logger *zap.Logger) (io.ReadCloser, error) {
-	logStream, err := utils.GetPodLogStream(ctx, client, namespace, podName, containerName, previous)
+// It opens a log stream and returns it along with the pod and container it is read from.
+// Only the lines matching the include, exclude and level options are read from the stream.
+func FetchPodLogs(ctx context.Context, client kubernetes.Interface, namespace, podName, containerName string, logOptions types.LogOptions, logger *zap.Logger) (*types.LogStream, error) {
+	filter, err := logfilter.New(logOptions.Include, logOptions.Exclude, logOptions.Level)
+	if err != nil {
+		return nil, customerrors.NewValidationError(err.Error())
+	}
+
+	logStream, containerName, err := utils.GetPodLogStream(ctx, client, namespace, podName, containerName, buildPodLogOptions(logOptions))
 	if err != nil {
 		logger.Error(fmt.Sprintf("%v: %v", errCouldNotOpenLogStream, err))
 		return nil, customerrors.NewAPIError(errCouldNotOpenLogStream, err)
 	}
 
-	return logStream, nil
+	if filter != nil {
+		logStream = filter.Stream(logStream)
+	}
+
+	return &types.LogStream{ReadCloser: logStream, Pod: podName, Container: containerName}, nil
 }
 
 // FetchCappLogs retrieves the logs of a Capp's Knative service.
 // It fetches the pods associated with the service, selects the first pod, and retrieves its logs.
-func FetchCappLogs(ctx context.Context, client kubernetes.Interface, namespace, cappName, containerName, podName string, previous bool, logger *zap.Logger) (io.ReadCloser, error) {
+func FetchCappLogs(ctx context.Context, client kubernetes.Interface, namespace, cappName, containerName, podName string, logOptions types.LogOptions, logger *zap.Logger) (*types.LogStream, error) {
+	podName, err := GetCappPodName(ctx, client, namespace, cappName, podName, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if containerName == "" {
+		containerName = cappName
+	}
+
+	return FetchPodLogs(ctx, client, namespace, podName, containerName, logOptions, logger)
+}
+
+// GetCappPodName returns the name of the Capp's pod with the given name, or the name of its first pod if
+// podName is empty. An error is returned if the Capp has no pods or the pod does not belong to the Capp.
+func GetCappPodName(ctx context.Context, client kubernetes.Interface, namespace, cappName, podName string, logger *zap.Logger) (string, error) {
 	pods, err := utils.GetPodsByLabel(ctx, client, namespace, fmt.Sprintf(utils.ParentCappLabelSelector, cappName), metav1.ListOptions{})
 	if err != nil {
 		logger.Error(fmt.Sprintf("%v: %v", errFetchingCappPods, err))
-		return nil, customerrors.NewAPIError(errFetchingCappPods, err)
+		return "", customerrors.NewAPIError(errFetchingCappPods, err)
 	}
 
 	if len(pods.Items) == 0 {
 		logger.Error(fmt.Sprintf(errNoPodsFound, cappName, namespace))
-		return nil, customerrors.NewAPIError(fmt.Sprintf(errNoPodsFound, cappName, namespace), err)
+		return "", customerrors.NewAPIError(fmt.Sprintf(errNoPodsFound, cappName, namespace), err)
 	}
 
 	podName, ok := FetchCappPodName(podName, pods)
-	logger.Error(fmt.Sprintf(errPodNotFound, podName, cappName, namespace))
 	if !ok {
-		return nil, customerrors.NewAPIError(fmt.Sprintf(errPodNotFound, podName, cappName, namespace), err)
-	}
-
-	if containerName == "" {
-		containerName = cappName
+		logger.Error(fmt.Sprintf(errPodNotFound, podName, cappName, namespace))
+		return "", customerrors.NewAPIError(fmt.Sprintf(errPodNotFound, podName, cappName, namespace), err)
 	}
 
-	return FetchPodLogs(ctx, client, namespace, podName, containerName, previous, logger)
+	return podName, nil
 }
 
 // FetchCappPodName returns the validated pod name from the provided list of pods.
@@ -73,3 +102,199 @@ func FetchCappPodName(podName string, pods *corev1.PodList) (string, bool) {
 
 	return podName, false
 }
+
+// FetchAggregatedCappLogs streams the logs of all the containers of all the pods of a Capp into a single channel.
+// Every line is tagged with the pod and container it was read from. Pods that are created while streaming,
+// for example when the Capp scales up, are attached automatically. If containerName is set, only containers
+// with that name are streamed. Only the lines matching the include, exclude and level options are sent. The channel is closed once the context is done and all streams have ended.
+// When the logs are not followed, new pods are not attached and the channel is closed once all streams have ended.
+func FetchAggregatedCappLogs(ctx context.Context, client kubernetes.Interface, namespace, cappName, containerName string, logOptions types.LogOptions, logger *zap.Logger) (<-chan types.LogLine, error) {
+	labelSelector := fmt.Sprintf(utils.ParentCappLabelSelector, cappName)
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, customerrors.NewValidationError(err.Error())
+	}
+
+	filter, err := logfilter.New(logOptions.Include, logOptions.Exclude, logOptions.Level)
+	if err != nil {
+		return nil, customerrors.NewValidationError(err.Error())
+	}
+
+	pods, err := utils.GetPodsByLabel(ctx, client, namespace, labelSelector, metav1.ListOptions{})
+	if err != nil {
+		logger.Error(fmt.Sprintf("%v: %v", errFetchingCappPods, err))
+		return nil, customerrors.NewAPIError(errFetchingCappPods, err)
+	}
+
+	podLogOptions := buildPodLogOptions(logOptions)
+	var podWatcher watch.Interface
+	if podLogOptions.Follow {
+		podWatcher, err = client.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{LabelSelector: labelSelector, ResourceVersion: pods.ResourceVersion})
+		if err != nil {
+			logger.Error(fmt.Sprintf("%v: %v", errWatchingCappPods, err))
+			return nil, customerrors.NewAPIError(errWatchingCappPods, err)
+		}
+	}
+
+	aggregator := &cappLogsAggregator{
+		ctx:           ctx,
+		client:        client,
+		namespace:     namespace,
+		containerName: containerName,
+		logOptions:    podLogOptions,
+		filter:        filter,
+		logger:        logger,
+		lines:         make(chan types.LogLine, logLinesBufferSize),
+		attached:      map[string]bool{},
+	}
+
+	for i := range pods.Items {
+		aggregator.attachPod(&pods.Items[i])
+	}
+
+	if podWatcher != nil {
+		aggregator.wg.Add(1)
+		go aggregator.watchPods(podWatcher, selector)
+	}
+
+	go func() {
+		aggregator.wg.Wait()
+		close(aggregator.lines)
+	}()
+
+	return aggregator.lines, nil
+}
+
+// cappLogsAggregator merges the log streams of the containers of a Capp's pods.
+type cappLogsAggregator struct {
+	ctx           context.Context
+	client        kubernetes.Interface
+	namespace     string
+	containerName string
+	logOptions    corev1.PodLogOptions
+	filter        *logfilter.LogFilter
+	logger        *zap.Logger
+	lines         chan types.LogLine
+	wg            sync.WaitGroup
+	mu            sync.Mutex
+	attached      map[string]bool
+}
+
+// watchPods attaches the pods matching the selector as they are added or become ready to stream logs.
+func (a *cappLogsAggregator) watchPods(podWatcher watch.Interface, selector labels.Selector) {
+	defer a.wg.Done()
+	defer podWatcher.Stop()
+
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case event, ok := <-podWatcher.ResultChan():
+			if !ok {
+				return
+			}
+
+			pod, isPod := event.Object.(*corev1.Pod)
+			if !isPod || !selector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+
+			if event.Type == watch.Added || event.Type == watch.Modified {
+				a.attachPod(pod)
+			}
+		}
+	}
+}
+
+// attachPod starts streaming the logs of the pod's containers that are not streamed yet.
+// Pending pods are skipped since their containers have no logs yet, they are attached once they are modified.
+func (a *cappLogsAggregator) attachPod(pod *corev1.Pod) {
+	if pod.Status.Phase == corev1.PodPending || pod.DeletionTimestamp != nil {
+		return
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if a.containerName != "" && container.Name != a.containerName {
+			continue
+		}
+
+		if a.markAttached(pod.Name, container.Name) {
+			a.wg.Add(1)
+			go a.streamContainer(pod.Name, container.Name)
+		}
+	}
+}
+
+// markAttached marks the container as attached and returns false if it was already attached.
+func (a *cappLogsAggregator) markAttached(podName, containerName string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := podName + "/" + containerName
+	if a.attached[key] {
+		return false
+	}
+
+	a.attached[key] = true
+	return true
+}
+
+// unmarkAttached allows the container to be attached again, e.g. after its log stream could not be opened or ended.
+func (a *cappLogsAggregator) unmarkAttached(podName, containerName string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.attached, podName+"/"+containerName)
+}
+
+// streamContainer reads the log of a single container and sends its lines, tagged with their source.
+// When following, a stream that ends while the context is still live means the container stopped, so the
+// container is unmarked to be attached again once its pod is modified, e.g. when the container restarts.
+func (a *cappLogsAggregator) streamContainer(podName, containerName string) {
+	defer a.wg.Done()
+
+	logStream, _, err := utils.GetPodLogStream(a.ctx, a.client, a.namespace, podName, containerName, a.logOptions)
+	if err != nil {
+		a.logger.Debug(fmt.Sprintf("%v of container %q in pod %q: %v", errCouldNotOpenLogStream, containerName, podName, err))
+		a.unmarkAttached(podName, containerName)
+		return
+	}
+	defer logStream.Close()
+
+	scanner := bufio.NewScanner(logStream)
+	for scanner.Scan() {
+		if a.filter != nil && !a.filter.Matches(scanner.Text()) {
+			continue
+		}
+
+		select {
+		case <-a.ctx.Done():
+			return
+		case a.lines <- types.LogLine{Pod: podName, Container: containerName, Line: scanner.Text()}:
+		}
+	}
+
+	if a.logOptions.Follow && a.ctx.Err() == nil {
+		a.unmarkAttached(podName, containerName)
+	}
+}
+
+// buildPodLogOptions maps the requested log options onto the PodLogOptions. Logs are followed unless stated otherwise,
+// and timestamps are always requested for the json format so that every frame carries the time of its line.
+func buildPodLogOptions(logOptions types.LogOptions) corev1.PodLogOptions {
+	podLogOptions := corev1.PodLogOptions{
+		Follow:       logOptions.Follow == nil || *logOptions.Follow,
+		Previous:     logOptions.Previous,
+		TailLines:    logOptions.TailLines,
+		SinceSeconds: logOptions.SinceSeconds,
+		Timestamps:   logOptions.Timestamps || logOptions.Format == types.LogFormatJSON,
+		LimitBytes:   logOptions.LimitBytes,
+	}
+
+	if logOptions.SinceTime != nil {
+		sinceTime := metav1.NewTime(*logOptions.SinceTime)
+		podLogOptions.SinceTime = &sinceTime
+	}
+
+	return podLogOptions
+}
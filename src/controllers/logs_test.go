@@ -3,6 +3,8 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils/logfilter"
 	"github.com/dana-team/platform-backend/src/utils/testutils"
 	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
 	"go.uber.org/zap"
@@ -10,7 +12,9 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -19,6 +23,8 @@ const (
 	pod2    = testutils.PodName + "-2"
 	pod3    = testutils.PodName + "-3"
 	cappPod = testutils.PodName
+
+	logsTimeout = 5 * time.Second
 )
 
 func TestFetchCappPodName(t *testing.T) {
@@ -104,23 +110,37 @@ func TestFetchPodLogs(t *testing.T) {
 		namespace     string
 		podName       string
 		containerName string
-		previous      bool
+		logOptions    types.LogOptions
 	}
 	type want struct {
 		errContains string
+		logStream   *types.LogStream
 	}
 
+	setup()
+	mocks.CreateTestPod(fakeClient, testutils.TestNamespace, pod1, "", false)
+
 	cases := map[string]struct {
 		args args
 		want want
 	}{
+		"ShouldStreamLogsOfDefaultContainer": {
+			args: args{
+				client:    fakeClient,
+				namespace: testutils.TestNamespace,
+				podName:   pod1,
+			},
+			want: want{
+				logStream: &types.LogStream{Pod: pod1, Container: testutils.TestContainerName},
+			},
+		},
 		"ShouldFailGettingLogsOnNonExistingPod": {
 			args: args{
 				client:        fakeClient,
 				namespace:     testutils.TestNamespace,
 				podName:       testutils.PodName + testutils.NonExistentSuffix,
 				containerName: testutils.TestContainerName,
-				previous:      true,
+				logOptions:    types.LogOptions{Previous: true},
 			},
 			want: want{
 				errContains: "error opening log stream",
@@ -128,16 +148,20 @@ func TestFetchPodLogs(t *testing.T) {
 		},
 	}
 
-	setup()
-	mocks.CreateTestPod(fakeClient, testutils.TestNamespace, pod1, "", false)
 	mockLogger, _ := zap.NewDevelopment()
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			_, err := FetchPodLogs(context.TODO(), tc.args.client, tc.args.namespace, tc.args.podName, tc.args.containerName, tc.args.previous, mockLogger)
+			logStream, err := FetchPodLogs(context.TODO(), tc.args.client, tc.args.namespace, tc.args.podName, tc.args.containerName, tc.args.logOptions, mockLogger)
 			if tc.want.errContains == "" {
 				if err != nil {
 					t.Errorf("Unexpected error: %v", err)
+					return
+				}
+				defer logStream.Close()
+
+				if logStream.Pod != tc.want.logStream.Pod || logStream.Container != tc.want.logStream.Container {
+					t.Errorf("Expected logs of %s/%s, but got %s/%s", tc.want.logStream.Pod, tc.want.logStream.Container, logStream.Pod, logStream.Container)
 				}
 			} else {
 				if err == nil || !strings.Contains(err.Error(), tc.want.errContains) {
@@ -155,7 +179,7 @@ func TestFetchCappLogs(t *testing.T) {
 		cappName      string
 		containerName string
 		podName       string
-		previous      bool
+		logOptions    types.LogOptions
 	}
 	type want struct {
 		errContains string
@@ -172,7 +196,7 @@ func TestFetchCappLogs(t *testing.T) {
 				cappName:      testutils.CappName + testutils.NonExistentSuffix,
 				containerName: testutils.TestContainerName,
 				podName:       cappPod,
-				previous:      true,
+				logOptions:    types.LogOptions{Previous: true},
 			},
 			want: want{
 				errContains: "no pods found for Capp",
@@ -185,7 +209,7 @@ func TestFetchCappLogs(t *testing.T) {
 				cappName:      testutils.CappName,
 				containerName: testutils.TestContainerName,
 				podName:       testutils.PodName + testutils.NonExistentSuffix,
-				previous:      false,
+				logOptions:    types.LogOptions{},
 			},
 			want: want{
 				errContains: fmt.Sprintf("no pods found for Capp %q in namespace %q", testutils.CappName, testutils.TestNamespace),
@@ -199,7 +223,7 @@ func TestFetchCappLogs(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			_, err := FetchCappLogs(context.TODO(), tc.args.client, tc.args.namespace, tc.args.cappName, tc.args.containerName, tc.args.podName, tc.args.previous, mockLogger)
+			_, err := FetchCappLogs(context.TODO(), tc.args.client, tc.args.namespace, tc.args.cappName, tc.args.containerName, tc.args.podName, tc.args.logOptions, mockLogger)
 			if tc.want.errContains == "" {
 				if err != nil {
 					t.Errorf("Unexpected error: %v", err)
@@ -212,3 +236,229 @@ func TestFetchCappLogs(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchAggregatedCappLogs(t *testing.T) {
+	namespace := testutils.TestNamespace + "-aggregated-logs"
+	fakeLogLine := "fake logs"
+	notFollow := false
+
+	type args struct {
+		containerName string
+		logOptions    types.LogOptions
+	}
+	type want struct {
+		lines          []types.LogLine
+		newLines       []types.LogLine
+		restartedLines []types.LogLine
+		closed         bool
+		errContains    string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldStreamAllPodsAndContainers": {
+			args: args{},
+			want: want{
+				lines: []types.LogLine{
+					{Pod: pod1, Container: testutils.CappName, Line: fakeLogLine},
+					{Pod: pod2, Container: testutils.TestContainerName, Line: fakeLogLine},
+					{Pod: pod2, Container: testutils.CappName, Line: fakeLogLine},
+				},
+				newLines: []types.LogLine{
+					{Pod: pod3, Container: testutils.CappName, Line: fakeLogLine},
+				},
+			},
+		},
+		"ShouldStreamOnlyMatchingContainers": {
+			args: args{
+				containerName: testutils.TestContainerName,
+			},
+			want: want{
+				lines: []types.LogLine{
+					{Pod: pod2, Container: testutils.TestContainerName, Line: fakeLogLine},
+				},
+			},
+		},
+		"ShouldReattachRestartedContainer": {
+			args: args{
+				containerName: testutils.TestContainerName,
+			},
+			want: want{
+				lines: []types.LogLine{
+					{Pod: pod2, Container: testutils.TestContainerName, Line: fakeLogLine},
+				},
+				restartedLines: []types.LogLine{
+					{Pod: pod2, Container: testutils.TestContainerName, Line: fakeLogLine},
+				},
+			},
+		},
+		"ShouldCloseStreamWhenNotFollowing": {
+			args: args{
+				containerName: testutils.TestContainerName,
+				logOptions:    types.LogOptions{Follow: &notFollow},
+			},
+			want: want{
+				lines: []types.LogLine{
+					{Pod: pod2, Container: testutils.TestContainerName, Line: fakeLogLine},
+				},
+				closed: true,
+			},
+		},
+		"ShouldSkipLinesNotMatchingFilter": {
+			args: args{
+				logOptions: types.LogOptions{Follow: &notFollow, Exclude: fakeLogLine},
+			},
+			want: want{
+				closed: true,
+			},
+		},
+		"ShouldFailWithInvalidIncludePattern": {
+			args: args{
+				logOptions: types.LogOptions{Include: "("},
+			},
+			want: want{
+				errContains: logfilter.ErrInvalidIncludePattern,
+			},
+		},
+	}
+
+	mockLogger, _ := zap.NewDevelopment()
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			setup()
+			mocks.CreateTestPod(fakeClient, namespace, pod1, testutils.CappName, false)
+			mocks.CreateTestPod(fakeClient, namespace, pod2, testutils.CappName, true)
+			mocks.CreateTestPod(fakeClient, namespace, cappPod, "", false)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			lines, err := FetchAggregatedCappLogs(ctx, fakeClient, namespace, testutils.CappName, tc.args.containerName, tc.args.logOptions, mockLogger)
+			if tc.want.errContains != "" {
+				assert.ErrorContains(t, err, tc.want.errContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tc.want.lines, readLogLines(t, lines, len(tc.want.lines)))
+
+			if tc.want.closed {
+				assert.Eventually(t, func() bool {
+					_, ok := <-lines
+					return !ok
+				}, logsTimeout, time.Millisecond*10)
+				return
+			}
+
+			if len(tc.want.newLines) > 0 {
+				mocks.CreateTestPod(fakeClient, namespace, pod3, testutils.CappName, false)
+				assert.ElementsMatch(t, tc.want.newLines, readLogLines(t, lines, len(tc.want.newLines)))
+			}
+
+			if len(tc.want.restartedLines) > 0 {
+				assert.ElementsMatch(t, tc.want.restartedLines, restartContainerUntilLogged(t, namespace, pod2, testutils.TestContainerName, lines))
+			}
+
+			cancel()
+			assert.Eventually(t, func() bool {
+				_, ok := <-lines
+				return !ok
+			}, logsTimeout, time.Millisecond*10)
+		})
+	}
+}
+
+// restartContainerUntilLogged restarts the container of the pod until a line of its new log stream is read.
+// The restart is repeated since the previous stream may not have ended yet when the pod is first modified.
+func restartContainerUntilLogged(t *testing.T, namespace, podName, containerName string, lines <-chan types.LogLine) []types.LogLine {
+	deadline := time.After(logsTimeout)
+	for restartCount := int32(1); ; restartCount++ {
+		pod, err := fakeClient.CoreV1().Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
+		assert.NoError(t, err)
+
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: containerName, RestartCount: restartCount}}
+		_, err = fakeClient.CoreV1().Pods(namespace).UpdateStatus(context.TODO(), pod, metav1.UpdateOptions{})
+		assert.NoError(t, err)
+
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatalf("Log lines channel closed before the restarted container was attached")
+			}
+			return []types.LogLine{line}
+		case <-time.After(time.Millisecond * 50):
+		case <-deadline:
+			t.Fatalf("Timed out waiting for the restarted container to be attached")
+		}
+	}
+}
+
+// readLogLines reads the given number of lines from the channel, failing the test if they do not arrive in time.
+func readLogLines(t *testing.T, lines <-chan types.LogLine, count int) []types.LogLine {
+	var result []types.LogLine
+	for len(result) < count {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatalf("Log lines channel closed after %d lines, expected %d", len(result), count)
+			}
+			result = append(result, line)
+		case <-time.After(logsTimeout):
+			t.Fatalf("Timed out after reading %d log lines, expected %d", len(result), count)
+		}
+	}
+
+	return result
+}
+
+func TestBuildPodLogOptions(t *testing.T) {
+	follow := false
+	tailLines := int64(10)
+	sinceSeconds := int64(60)
+	limitBytes := int64(1024)
+	sinceTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	metaSinceTime := metav1.NewTime(sinceTime)
+
+	cases := map[string]struct {
+		logOptions types.LogOptions
+		want       corev1.PodLogOptions
+	}{
+		"ShouldFollowByDefault": {
+			logOptions: types.LogOptions{},
+			want:       corev1.PodLogOptions{Follow: true},
+		},
+		"ShouldMapAllOptions": {
+			logOptions: types.LogOptions{
+				Previous:     true,
+				Follow:       &follow,
+				TailLines:    &tailLines,
+				SinceSeconds: &sinceSeconds,
+				Timestamps:   true,
+				LimitBytes:   &limitBytes,
+			},
+			want: corev1.PodLogOptions{
+				Previous:     true,
+				TailLines:    &tailLines,
+				SinceSeconds: &sinceSeconds,
+				Timestamps:   true,
+				LimitBytes:   &limitBytes,
+			},
+		},
+		"ShouldRequestTimestampsForJSONFormat": {
+			logOptions: types.LogOptions{Format: types.LogFormatJSON},
+			want:       corev1.PodLogOptions{Follow: true, Timestamps: true},
+		},
+		"ShouldMapSinceTime": {
+			logOptions: types.LogOptions{SinceTime: &sinceTime},
+			want:       corev1.PodLogOptions{Follow: true, SinceTime: &metaSinceTime},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, buildPodLogOptions(tc.logOptions))
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+	"time"
+)
+
+const (
+	ErrCouldNotGetMetrics          = "Could not get metrics of capp %q in namespace %q"
+	ErrCouldNotGetMetricsHistory   = "Could not get metrics history of capp %q in namespace %q"
+	ErrMetricsHistoryNotConfigured = "Metrics history is not available as no query backend is configured"
+	ErrTooManyMetricsSamples       = "The range %v with a step of %v exceeds the maximum of %d samples"
+)
+
+const (
+	// defaultMetricsHistorySamples is the number of samples returned when no step is given,
+	// and maxMetricsHistorySamples is the maximum number of samples Prometheus returns for a range query.
+	defaultMetricsHistorySamples = 60
+	maxMetricsHistorySamples     = 11000
+
+	// historyPodNamePattern matches the names of the pods of the revisions of a Capp in PromQL.
+	historyPodNamePattern = `%s-[0-9]{5}-deployment-.+`
+	cpuHistoryQuery       = `sum(rate(container_cpu_usage_seconds_total{namespace=%q,pod=~%q,container!="",container!="POD"}[5m])) * 1000`
+	memoryHistoryQuery    = `sum(container_memory_working_set_bytes{namespace=%q,pod=~%q,container!="",container!="POD"})`
+)
+
+// MetricsController defines methods to get the resource usage of Capps.
+type MetricsController interface {
+	// GetCappMetrics returns the CPU and memory usage of the pods of a Capp next to the requests and limits
+	// of the Capp. When the query has a range, the total usage over time is returned as well.
+	GetCappMetrics(namespace, name string, query types.MetricsQuery) (types.CappMetrics, error)
+}
+
+// metricsController implements the MetricsController interface.
+type metricsController struct {
+	client        client.Client
+	historyClient promv1.API
+	ctx           context.Context
+	logger        *zap.Logger
+}
+
+// NewMetricsController creates a new instance of MetricsController. The history client is optional
+// and may be nil when no Prometheus-compatible query backend is configured.
+func NewMetricsController(client client.Client, historyClient promv1.API, context context.Context, logger *zap.Logger) MetricsController {
+	return &metricsController{
+		client:        client,
+		historyClient: historyClient,
+		ctx:           context,
+		logger:        logger,
+	}
+}
+
+func (m *metricsController) GetCappMetrics(namespace, name string, query types.MetricsQuery) (types.CappMetrics, error) {
+	m.logger.Debug(fmt.Sprintf("Trying to get metrics of capp %q in namespace %q", name, namespace))
+
+	if query.Range > 0 {
+		if err := m.validateHistoryQuery(&query); err != nil {
+			return types.CappMetrics{}, err
+		}
+	}
+
+	capp := cappv1alpha1.Capp{}
+	if err := m.client.Get(m.ctx, client.ObjectKey{Namespace: namespace, Name: name}, &capp); err != nil {
+		m.logger.Error(fmt.Sprintf("Could not get capp %q in namespace %q with error: %v", name, namespace, err.Error()))
+		return types.CappMetrics{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetCapp, name, namespace), err)
+	}
+
+	selector, err := labels.Parse(fmt.Sprintf(utils.ParentCappLabelSelector, name))
+	if err != nil {
+		m.logger.Error(fmt.Sprintf("%s with error: %v", ErrParsingLabelSelector, err.Error()))
+		return types.CappMetrics{}, customerrors.NewValidationError(ErrParsingLabelSelector)
+	}
+
+	podMetricsList := metricsv1beta1.PodMetricsList{}
+	if err := m.client.List(m.ctx, &podMetricsList, &client.ListOptions{Namespace: namespace, LabelSelector: selector}); err != nil {
+		m.logger.Error(fmt.Sprintf("Could not get metrics of capp %q in namespace %q with error: %v", name, namespace, err.Error()))
+		return types.CappMetrics{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetMetrics, name, namespace), err)
+	}
+
+	cappMetrics := convertPodMetricsToCappMetrics(capp, podMetricsList.Items)
+	if query.Range > 0 {
+		history, err := m.getCappMetricsHistory(namespace, name, query)
+		if err != nil {
+			m.logger.Error(fmt.Sprintf("Could not get metrics history of capp %q in namespace %q with error: %v", name, namespace, err.Error()))
+			return types.CappMetrics{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetMetricsHistory, name, namespace), err)
+		}
+		cappMetrics.History = &history
+	}
+
+	m.logger.Debug(fmt.Sprintf("Fetched metrics of %d pods of capp %q in namespace %q", len(cappMetrics.Pods), name, namespace))
+	return cappMetrics, nil
+}
+
+// validateHistoryQuery makes sure the metrics history can be queried, and defaults the step of the query.
+func (m *metricsController) validateHistoryQuery(query *types.MetricsQuery) error {
+	if m.historyClient == nil {
+		return customerrors.NewValidationError(ErrMetricsHistoryNotConfigured)
+	}
+
+	if query.Step == 0 {
+		query.Step = query.Range / defaultMetricsHistorySamples
+	}
+
+	if query.Range/query.Step > maxMetricsHistorySamples {
+		return customerrors.NewValidationError(fmt.Sprintf(ErrTooManyMetricsSamples, query.Range, query.Step, maxMetricsHistorySamples))
+	}
+
+	return nil
+}
+
+// getCappMetricsHistory queries the total CPU and memory usage of the pods of a Capp over the range of the query.
+func (m *metricsController) getCappMetricsHistory(namespace, name string, query types.MetricsQuery) (types.MetricsHistory, error) {
+	end := time.Now().UTC().Truncate(time.Second)
+	queryRange := promv1.Range{Start: end.Add(-query.Range), End: end, Step: query.Step}
+	podNames := fmt.Sprintf(historyPodNamePattern, name)
+
+	cpu, err := m.querySamples(fmt.Sprintf(cpuHistoryQuery, namespace, podNames), queryRange)
+	if err != nil {
+		return types.MetricsHistory{}, err
+	}
+
+	memory, err := m.querySamples(fmt.Sprintf(memoryHistoryQuery, namespace, podNames), queryRange)
+	if err != nil {
+		return types.MetricsHistory{}, err
+	}
+
+	return types.MetricsHistory{
+		Start:  queryRange.Start.Format(time.RFC3339),
+		End:    queryRange.End.Format(time.RFC3339),
+		Step:   query.Step.String(),
+		CPU:    cpu,
+		Memory: memory,
+	}, nil
+}
+
+// querySamples runs a range query which results in a single series and returns its samples.
+func (m *metricsController) querySamples(query string, queryRange promv1.Range) ([]types.MetricsSample, error) {
+	value, warnings, err := m.historyClient.QueryRange(m.ctx, query, queryRange)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, warning := range warnings {
+		m.logger.Warn(fmt.Sprintf("Metrics history query %q returned warning: %s", query, warning))
+	}
+
+	samples := []types.MetricsSample{}
+	matrix, ok := value.(model.Matrix)
+	if !ok || len(matrix) == 0 {
+		return samples, nil
+	}
+
+	for _, pair := range matrix[0].Values {
+		samples = append(samples, types.MetricsSample{
+			Timestamp: pair.Timestamp.Time().UTC().Format(time.RFC3339),
+			Value:     float64(pair.Value),
+		})
+	}
+
+	return samples, nil
+}
+
+// convertPodMetricsToCappMetrics sums the usage of the containers of the pods of a Capp, and puts it next to
+// the requests and limits of the containers in the configuration of the Capp.
+func convertPodMetricsToCappMetrics(capp cappv1alpha1.Capp, podMetrics []metricsv1beta1.PodMetrics) types.CappMetrics {
+	containers := capp.Spec.ConfigurationSpec.Template.Spec.Containers
+	containerResources := make(map[string]corev1.ResourceRequirements, len(containers))
+	cappMetrics := types.CappMetrics{CappName: capp.Name, Pods: []types.PodMetrics{}}
+	for _, container := range containers {
+		containerResources[container.Name] = container.Resources
+		cappMetrics.Requests = addResources(cappMetrics.Requests, convertResourceList(container.Resources.Requests))
+		cappMetrics.Limits = addResources(cappMetrics.Limits, convertResourceList(container.Resources.Limits))
+	}
+
+	sort.Slice(podMetrics, func(i, j int) bool {
+		return podMetrics[i].Name < podMetrics[j].Name
+	})
+
+	for _, pod := range podMetrics {
+		podMetric := types.PodMetrics{
+			Name:       pod.Name,
+			Timestamp:  pod.Timestamp.UTC().Format(time.RFC3339),
+			Window:     pod.Window.Duration.String(),
+			Containers: []types.ContainerMetrics{},
+		}
+
+		for _, container := range pod.Containers {
+			usage := convertResourceList(container.Usage)
+			podMetric.Usage = addResources(podMetric.Usage, usage)
+			podMetric.Containers = append(podMetric.Containers, types.ContainerMetrics{
+				Name:     container.Name,
+				Requests: convertResourceList(containerResources[container.Name].Requests),
+				Limits:   convertResourceList(containerResources[container.Name].Limits),
+				Usage:    usage,
+			})
+		}
+
+		cappMetrics.Usage = addResources(cappMetrics.Usage, podMetric.Usage)
+		cappMetrics.Pods = append(cappMetrics.Pods, podMetric)
+	}
+
+	return cappMetrics
+}
+
+// convertResourceList converts the CPU of a resource list to millicores and its memory to bytes.
+func convertResourceList(resources corev1.ResourceList) types.Resources {
+	var converted types.Resources
+	if cpu, ok := resources[corev1.ResourceCPU]; ok {
+		converted.CPU = cpu.MilliValue()
+	}
+
+	if memory, ok := resources[corev1.ResourceMemory]; ok {
+		converted.Memory = memory.Value()
+	}
+
+	return converted
+}
+
+// addResources returns the sum of two amounts of resources.
+func addResources(first, second types.Resources) types.Resources {
+	return types.Resources{
+		CPU:    first.CPU + second.CPU,
+		Memory: first.Memory + second.Memory,
+	}
+}
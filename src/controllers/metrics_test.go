@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils/prometheus"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	historyToken       = "history-token"
+	historyCPUValue    = 250
+	historyMemoryValue = 1048576
+)
+
+// newTestHistoryServer returns a server which answers range queries of users with the history token
+// with a single sample of CPU or memory usage.
+func newTestHistoryServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+historyToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		value := historyMemoryValue
+		if strings.Contains(r.FormValue("query"), "container_cpu_usage_seconds_total") {
+			value = historyCPUValue
+		}
+
+		sampleTime, _ := time.Parse(time.RFC3339, testutils.MetricsTimestamp)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[%d,"%d"]]}]}}`,
+			sampleTime.Unix(), value)
+	}))
+}
+
+func TestGetCappMetrics(t *testing.T) {
+	namespaceName := testutils.CappNamespace + "-metrics"
+	mebibyte := int64(1024 * 1024)
+
+	server := newTestHistoryServer()
+	defer server.Close()
+	historyClient, err := prometheus.NewClient(server.URL, historyToken, false)
+	assert.NoError(t, err)
+	unauthorizedHistoryClient, err := prometheus.NewClient(server.URL, historyToken+testutils.NonExistentSuffix, false)
+	assert.NoError(t, err)
+
+	requests := types.Resources{CPU: 100, Memory: 128 * mebibyte}
+	limits := types.Resources{CPU: 500, Memory: 256 * mebibyte}
+	queueProxyUsage := types.Resources{CPU: 1, Memory: 10 * mebibyte}
+	expectedMetrics := types.CappMetrics{
+		CappName: testutils.CappName,
+		Requests: requests,
+		Limits:   limits,
+		Usage:    types.Resources{CPU: 202, Memory: 320 * mebibyte},
+		Pods: []types.PodMetrics{
+			{
+				Name:      pod1,
+				Timestamp: testutils.MetricsTimestamp,
+				Window:    testutils.MetricsWindow.String(),
+				Usage:     types.Resources{CPU: 51, Memory: 110 * mebibyte},
+				Containers: []types.ContainerMetrics{
+					{Name: testutils.ContainerName, Requests: requests, Limits: limits, Usage: types.Resources{CPU: 50, Memory: 100 * mebibyte}},
+					{Name: testutils.QueueProxyContainerName, Usage: queueProxyUsage},
+				},
+			},
+			{
+				Name:      pod2,
+				Timestamp: testutils.MetricsTimestamp,
+				Window:    testutils.MetricsWindow.String(),
+				Usage:     types.Resources{CPU: 151, Memory: 210 * mebibyte},
+				Containers: []types.ContainerMetrics{
+					{Name: testutils.ContainerName, Requests: requests, Limits: limits, Usage: types.Resources{CPU: 150, Memory: 200 * mebibyte}},
+					{Name: testutils.QueueProxyContainerName, Usage: queueProxyUsage},
+				},
+			},
+		},
+	}
+
+	expectedMetricsWithHistory := expectedMetrics
+	expectedMetricsWithHistory.History = &types.MetricsHistory{
+		Step:   time.Minute.String(),
+		CPU:    []types.MetricsSample{{Timestamp: testutils.MetricsTimestamp, Value: historyCPUValue}},
+		Memory: []types.MetricsSample{{Timestamp: testutils.MetricsTimestamp, Value: historyMemoryValue}},
+	}
+
+	type args struct {
+		cappName      string
+		query         types.MetricsQuery
+		historyClient promv1.API
+	}
+	type want struct {
+		response    types.CappMetrics
+		errorStatus metav1.StatusReason
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldSucceedGettingCappMetrics": {
+			args: args{cappName: testutils.CappName},
+			want: want{response: expectedMetrics, errorStatus: metav1.StatusSuccess},
+		},
+		"ShouldSucceedGettingCappMetricsWithoutPods": {
+			args: args{cappName: testutils.CappName + "-idle"},
+			want: want{
+				response:    types.CappMetrics{CappName: testutils.CappName + "-idle", Pods: []types.PodMetrics{}},
+				errorStatus: metav1.StatusSuccess,
+			},
+		},
+		"ShouldSucceedGettingCappMetricsWithHistory": {
+			args: args{
+				cappName:      testutils.CappName,
+				query:         types.MetricsQuery{Range: time.Hour},
+				historyClient: historyClient,
+			},
+			want: want{response: expectedMetricsWithHistory, errorStatus: metav1.StatusSuccess},
+		},
+		"ShouldFailGettingHistoryWithoutQueryBackend": {
+			args: args{
+				cappName: testutils.CappName,
+				query:    types.MetricsQuery{Range: time.Hour},
+			},
+			want: want{errorStatus: metav1.StatusReasonBadRequest},
+		},
+		"ShouldFailGettingHistoryWithTooManySamples": {
+			args: args{
+				cappName:      testutils.CappName,
+				query:         types.MetricsQuery{Range: 24 * time.Hour, Step: time.Second},
+				historyClient: historyClient,
+			},
+			want: want{errorStatus: metav1.StatusReasonBadRequest},
+		},
+		"ShouldFailGettingHistoryWhenQueryBackendRejectsUser": {
+			args: args{
+				cappName:      testutils.CappName,
+				query:         types.MetricsQuery{Range: time.Hour},
+				historyClient: unauthorizedHistoryClient,
+			},
+			want: want{errorStatus: metav1.StatusReasonInternalError},
+		},
+		"ShouldFailGettingMetricsOfNonExistingCapp": {
+			args: args{cappName: testutils.CappName + testutils.NonExistentSuffix},
+			want: want{errorStatus: metav1.StatusReasonNotFound},
+		},
+	}
+
+	setup()
+	capp := mocks.PrepareCapp(testutils.CappName, namespaceName, testutils.Domain, nil, nil)
+	capp.Spec.ConfigurationSpec.Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("128Mi")},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+	}
+	assert.NoError(t, dynClient.Create(context.TODO(), &capp))
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-idle", namespaceName, testutils.Domain, nil, nil)
+	mocks.CreateTestPodMetrics(dynClient, namespaceName, pod2, testutils.CappName, "150m", "200Mi")
+	mocks.CreateTestPodMetrics(dynClient, namespaceName, pod1, testutils.CappName, "50m", "100Mi")
+	mocks.CreateTestPodMetrics(dynClient, namespaceName, testutils.PodName+"-other", testutils.CappName+"-other", "1", "1Gi")
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			metricsController := NewMetricsController(dynClient, test.args.historyClient, context.TODO(), logger)
+			response, err := metricsController.GetCappMetrics(namespaceName, test.args.cappName, test.args.query)
+			if test.want.errorStatus != metav1.StatusSuccess {
+				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
+				assert.Equal(t, test.want.errorStatus, reason)
+				return
+			}
+
+			assert.NoError(t, err)
+			if response.History != nil {
+				start, _ := time.Parse(time.RFC3339, response.History.Start)
+				end, _ := time.Parse(time.RFC3339, response.History.End)
+				assert.Equal(t, test.args.query.Range, end.Sub(start))
+				response.History.Start, response.History.End = "", ""
+			}
+			assert.Equal(t, test.want.response, response)
+		})
+	}
+}
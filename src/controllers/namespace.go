@@ -21,7 +21,7 @@ const (
 )
 
 type NamespaceController interface {
-	GetNamespaces(limit, page int) (types.NamespaceList, error)
+	GetNamespaces(limit, page int, listQuery types.ListQuery) (types.NamespaceList, error)
 	GetNamespace(name string) (types.Namespace, error)
 	CreateNamespace(name string) (types.Namespace, error)
 	DeleteNamespace(name string) error
@@ -47,7 +47,7 @@ func NewNamespaceController(client kubernetes.Interface, context context.Context
 	}
 }
 
-func (n *namespaceController) GetNamespaces(limit, page int) (types.NamespaceList, error) {
+func (n *namespaceController) GetNamespaces(limit, page int, listQuery types.ListQuery) (types.NamespaceList, error) {
 	namespaceList := types.NamespaceList{}
 	n.logger.Debug("Trying to fetch all namespaces")
 
@@ -56,7 +56,7 @@ func (n *namespaceController) GetNamespaces(limit, page int) (types.NamespaceLis
 		client:           n.client,
 	}
 
-	namespaces, err := pagination.FetchPage[corev1.Namespace](limit, page, namespacePaginator)
+	namespaces, listMetadata, err := pagination.FetchPage[corev1.Namespace](limit, page, namespacePaginator, listQuery)
 	if err != nil {
 		n.logger.Error(fmt.Sprintf("%v with error: %v", ErrCouldNotGetNamespaces, err))
 		return types.NamespaceList{}, customerrors.NewAPIError(ErrCouldNotGetNamespaces, err)
@@ -65,7 +65,7 @@ func (n *namespaceController) GetNamespaces(limit, page int) (types.NamespaceLis
 	for _, namespace := range namespaces {
 		namespaceList.Namespaces = append(namespaceList.Namespaces, types.Namespace{Name: namespace.Name})
 	}
-	namespaceList.Count = len(namespaceList.Namespaces)
+	namespaceList.ListMetadata = listMetadata
 	return namespaceList, nil
 }
 
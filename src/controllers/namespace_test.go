@@ -154,7 +154,7 @@ func TestGetNamespaces(t *testing.T) {
 			namespaceController := NewNamespaceController(fakeClient, c, logger)
 
 			limit, page, _ := pagination.ExtractPaginationParamsFromCtx(c)
-			response, err := namespaceController.GetNamespaces(limit, page)
+			response, err := namespaceController.GetNamespaces(limit, page, types.ListQuery{})
 			if test.want.errorStatus != metav1.StatusSuccess {
 				reason := err.(customerrors.ErrorWithStatusCode).StatusReason()
 
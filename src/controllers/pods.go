@@ -11,15 +11,20 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"knative.dev/serving/pkg/apis/serving"
 )
 
 const (
-	ErrCouldNotGetPods = "Could not get pods"
+	ErrCouldNotGetPods   = "Could not get pods"
+	ErrPodNotInCapp      = "pod %q does not belong to Capp %q"
+	ErrCouldNotDeletePod = "Could not delete pod %q in namespace %q"
 )
 
 // PodController defines methods to interact with pod pods.
 type PodController interface {
-	GetPods(namespace, cappName string, limit, page int) (types.GetPodsResponse, error)
+	GetPods(namespace, cappName string, limit, page int, listQuery types.ListQuery) (types.GetPodsResponse, error)
+	GetPod(namespace, cappName, podName string) (types.PodDetails, error)
+	DeletePod(namespace, cappName, podName string, gracePeriodSeconds *int64) (types.DeletePodResponse, error)
 }
 
 // podController implements the PodController interface.
@@ -47,7 +52,7 @@ func NewPodController(client kubernetes.Interface, context context.Context, logg
 }
 
 // GetPods returns a list of pod names for a given capp in a specific namespace.
-func (n *podController) GetPods(namespace, cappName string, limit, page int) (types.GetPodsResponse, error) {
+func (n *podController) GetPods(namespace, cappName string, limit, page int, listQuery types.ListQuery) (types.GetPodsResponse, error) {
 	n.logger.Debug(fmt.Sprintf("Trying to get all pods in %q namespace", namespace))
 
 	podPaginator := &PodPaginator{
@@ -57,26 +62,143 @@ func (n *podController) GetPods(namespace, cappName string, limit, page int) (ty
 		cappName:         cappName,
 	}
 
-	pods, err := pagination.FetchPage[corev1.Pod](limit, page, podPaginator)
+	pods, listMetadata, err := pagination.FetchPage[corev1.Pod](limit, page, podPaginator, listQuery)
 	if err != nil {
 		n.logger.Error(fmt.Sprintf("%v with error: %v", ErrCouldNotGetPods, err))
 		return types.GetPodsResponse{}, customerrors.NewAPIError(ErrCouldNotGetPods, err)
 	}
 
-	response := types.GetPodsResponse{}
-	response.Count = len(pods)
+	response := types.GetPodsResponse{ListMetadata: listMetadata}
 	for _, pod := range pods {
-		response.Pods = append(
-			response.Pods,
-			types.Pod{
-				PodName: pod.Name,
-			})
+		response.Pods = append(response.Pods, convertPodToSummary(&pod))
 	}
 
 	n.logger.Debug("Fetched all pods successfully")
 	return response, nil
 }
 
+// GetPod returns the status of a pod of a given capp in a specific namespace, along with the status of its containers.
+func (n *podController) GetPod(namespace, cappName, podName string) (types.PodDetails, error) {
+	n.logger.Debug(fmt.Sprintf("Trying to get pod %q in %q namespace", podName, namespace))
+
+	pod, err := n.getCappPod(namespace, cappName, podName)
+	if err != nil {
+		return types.PodDetails{}, err
+	}
+
+	n.logger.Debug("Fetched pod successfully")
+	return buildPodDetails(pod), nil
+}
+
+// DeletePod deletes a pod of a given capp in a specific namespace, so that it is replaced by a new pod.
+// If gracePeriodSeconds is set, it overrides the termination grace period of the pod.
+func (n *podController) DeletePod(namespace, cappName, podName string, gracePeriodSeconds *int64) (types.DeletePodResponse, error) {
+	n.logger.Debug(fmt.Sprintf("Trying to delete pod %q in %q namespace", podName, namespace))
+
+	if _, err := n.getCappPod(namespace, cappName, podName); err != nil {
+		return types.DeletePodResponse{}, err
+	}
+
+	if err := n.client.CoreV1().Pods(namespace).Delete(n.ctx, podName, metav1.DeleteOptions{GracePeriodSeconds: gracePeriodSeconds}); err != nil {
+		n.logger.Error(fmt.Sprintf("%v with error: %v", fmt.Sprintf(ErrCouldNotDeletePod, podName, namespace), err.Error()))
+		return types.DeletePodResponse{}, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotDeletePod, podName, namespace), err)
+	}
+
+	return types.DeletePodResponse{
+		Message: fmt.Sprintf("Deleted pod %q in namespace %q successfully", podName, namespace),
+	}, nil
+}
+
+// getCappPod returns the pod with the given name if it belongs to the capp.
+func (n *podController) getCappPod(namespace, cappName, podName string) (*corev1.Pod, error) {
+	pod, err := n.client.CoreV1().Pods(namespace).Get(n.ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		n.logger.Error(fmt.Sprintf("%v with error: %s", fmt.Sprintf(ErrCouldNotGetPod, podName, namespace), err.Error()))
+		return nil, customerrors.NewAPIError(fmt.Sprintf(ErrCouldNotGetPod, podName, namespace), err)
+	}
+
+	if pod.Labels[utils.ParentCappLabel] != cappName {
+		return nil, customerrors.NewNotFoundError(fmt.Sprintf(ErrPodNotInCapp, podName, cappName))
+	}
+
+	return pod, nil
+}
+
+// buildPodDetails returns the status of the pod along with the details of its init containers and containers.
+func buildPodDetails(pod *corev1.Pod) types.PodDetails {
+	details := types.PodDetails{
+		PodName:    pod.Name,
+		Phase:      string(pod.Status.Phase),
+		Ready:      isPodReady(pod),
+		Reason:     pod.Status.Reason,
+		Message:    pod.Status.Message,
+		NodeName:   pod.Spec.NodeName,
+		HostIP:     pod.Status.HostIP,
+		Revision:   pod.Labels[serving.RevisionLabelKey],
+		QOSClass:   string(pod.Status.QOSClass),
+		Conditions: []types.PodCondition{},
+		Containers: []types.ContainerDetails{},
+	}
+
+	if pod.Status.StartTime != nil {
+		details.StartTime = formatTimestamp(*pod.Status.StartTime)
+	}
+
+	for _, podIP := range pod.Status.PodIPs {
+		details.PodIPs = append(details.PodIPs, podIP.IP)
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		details.Conditions = append(details.Conditions, types.PodCondition{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: formatTimestamp(condition.LastTransitionTime),
+		})
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		details.InitContainers = append(details.InitContainers, buildContainerDetails(container, pod.Status.InitContainerStatuses, true))
+	}
+
+	for _, container := range pod.Spec.Containers {
+		details.Containers = append(details.Containers, buildContainerDetails(container, pod.Status.ContainerStatuses, false))
+	}
+
+	return details
+}
+
+// convertPodToSummary returns the phase and readiness of a pod, along with the total restart count of its containers.
+func convertPodToSummary(pod *corev1.Pod) types.Pod {
+	summary := types.Pod{
+		PodName: pod.Name,
+		Phase:   string(pod.Status.Phase),
+		Ready:   isPodReady(pod),
+	}
+
+	for _, status := range pod.Status.InitContainerStatuses {
+		summary.Restarts += status.RestartCount
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		summary.Restarts += status.RestartCount
+	}
+
+	return summary
+}
+
+// isPodReady returns whether the Ready condition of a pod is true.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
 // FetchList retrieves a list of secrets from the specified namespace with given options.
 func (p *PodPaginator) FetchList(listOptions metav1.ListOptions) (*types.List[corev1.Pod], error) {
 	pods, err := utils.GetPodsByLabel(p.Ctx, p.client, p.namespace, fmt.Sprintf(utils.ParentCappLabelSelector, p.cappName), metav1.ListOptions{
@@ -1,12 +1,17 @@
 package controllers
 
 import (
+	"context"
+	"fmt"
 	"github.com/dana-team/platform-backend/src/types"
 	"github.com/dana-team/platform-backend/src/utils"
 	"github.com/dana-team/platform-backend/src/utils/pagination"
 	"github.com/dana-team/platform-backend/src/utils/testutils"
 	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"testing"
 )
 
@@ -35,7 +40,7 @@ func TestGetPods(t *testing.T) {
 				response: types.GetPodsResponse{
 					ListMetadata: types.ListMetadata{Count: 2},
 					Pods: []types.Pod{
-						{PodName: pod1},
+						{PodName: pod1, Phase: string(corev1.PodRunning), Ready: false, Restarts: 3},
 						{PodName: pod2},
 					},
 				},
@@ -54,7 +59,7 @@ func TestGetPods(t *testing.T) {
 
 	setup()
 	createTestNamespace(namespaceName, utils.AddManagedLabel(map[string]string{}))
-	mocks.CreateTestPod(fakeClient, namespaceName, pod1, testutils.CappName, false)
+	mocks.CreateTestPodWithStatus(fakeClient, namespaceName, pod1, testutils.CappName)
 	mocks.CreateTestPod(fakeClient, namespaceName, pod2, testutils.CappName, true)
 
 	for name, test := range cases {
@@ -64,11 +69,240 @@ func TestGetPods(t *testing.T) {
 			podController := NewPodController(fakeClient, c, logger)
 
 			limit, page, _ := pagination.ExtractPaginationParamsFromCtx(c)
-			response, err := podController.GetPods(test.args.namespace, test.args.cappName, limit, page)
+			response, err := podController.GetPods(test.args.namespace, test.args.cappName, limit, page, types.ListQuery{})
+			if test.want.error != "" {
+				assert.ErrorContains(t, err, test.want.error)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want.response, response)
+		})
+	}
+}
+
+func TestGetPod(t *testing.T) {
+	namespaceName := testutils.TestNamespace + "-getPod"
+	exitCode := int32(1)
+	initExitCode := int32(0)
+	type args struct {
+		namespace string
+		cappName  string
+		podName   string
+	}
+	type want struct {
+		response types.PodDetails
+		error    string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldSucceedGettingPodDetails": {
+			args: args{
+				namespace: namespaceName,
+				cappName:  testutils.CappName,
+				podName:   pod1,
+			},
+			want: want{
+				response: types.PodDetails{
+					PodName:   pod1,
+					Phase:     string(corev1.PodRunning),
+					Ready:     false,
+					NodeName:  testutils.NodeName,
+					HostIP:    testutils.HostIP,
+					PodIPs:    []string{testutils.PodIP},
+					StartTime: testutils.PodStartTime,
+					Revision:  testutils.RevisionName,
+					QOSClass:  string(corev1.PodQOSBurstable),
+					Conditions: []types.PodCondition{
+						{
+							Type:               string(corev1.PodReady),
+							Status:             string(corev1.ConditionFalse),
+							Reason:             testutils.ReasonNotReady,
+							LastTransitionTime: testutils.PodStartTime,
+						},
+					},
+					InitContainers: []types.ContainerDetails{
+						{
+							ContainerName: testutils.InitContainerName,
+							Image:         testutils.Image,
+							Init:          true,
+							Ready:         true,
+							State: types.ContainerState{
+								State:      types.ContainerStateTerminated,
+								Reason:     testutils.ReasonCompleted,
+								ExitCode:   &initExitCode,
+								StartedAt:  testutils.PodStartTime,
+								FinishedAt: testutils.PodStartTime,
+							},
+						},
+					},
+					Containers: []types.ContainerDetails{
+						{
+							ContainerName: testutils.CappName,
+							Image:         testutils.Image,
+							RestartCount:  3,
+							State: types.ContainerState{
+								State:  types.ContainerStateWaiting,
+								Reason: testutils.ReasonCrashLoopBackOff,
+							},
+							LastState: &types.ContainerState{
+								State:      types.ContainerStateTerminated,
+								Reason:     testutils.ReasonError,
+								ExitCode:   &exitCode,
+								StartedAt:  testutils.PodStartTime,
+								FinishedAt: testutils.PodStartTime,
+							},
+							Resources: types.ContainerResources{
+								Requests: map[string]string{"cpu": "100m", "memory": "128Mi"},
+								Limits:   map[string]string{"memory": "256Mi"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"ShouldSucceedGettingPodWithoutStatus": {
+			args: args{
+				namespace: namespaceName,
+				cappName:  testutils.CappName,
+				podName:   pod2,
+			},
+			want: want{
+				response: types.PodDetails{
+					PodName:    pod2,
+					Conditions: []types.PodCondition{},
+					Containers: []types.ContainerDetails{
+						{
+							ContainerName: testutils.CappName,
+							Image:         testutils.Image,
+							State:         types.ContainerState{State: types.ContainerStateWaiting},
+						},
+					},
+				},
+			},
+		},
+		"ShouldNotGetPodOfAnotherCapp": {
+			args: args{
+				namespace: namespaceName,
+				cappName:  testutils.CappName + testutils.NonExistentSuffix,
+				podName:   pod1,
+			},
+			want: want{
+				error: fmt.Sprintf(ErrPodNotInCapp, pod1, testutils.CappName+testutils.NonExistentSuffix),
+			},
+		},
+		"ShouldNotGetNonExistingPod": {
+			args: args{
+				namespace: namespaceName,
+				cappName:  testutils.CappName,
+				podName:   pod1 + testutils.NonExistentSuffix,
+			},
+			want: want{
+				error: fmt.Sprintf(ErrCouldNotGetPod, pod1+testutils.NonExistentSuffix, namespaceName),
+			},
+		},
+	}
+
+	setup()
+	createTestNamespace(namespaceName, utils.AddManagedLabel(map[string]string{}))
+	mocks.CreateTestPodWithStatus(fakeClient, namespaceName, pod1, testutils.CappName)
+	mocks.CreateTestPod(fakeClient, namespaceName, pod2, testutils.CappName, false)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			podController := NewPodController(fakeClient, context.TODO(), logger)
+			response, err := podController.GetPod(test.args.namespace, test.args.cappName, test.args.podName)
+			if test.want.error != "" {
+				assert.ErrorContains(t, err, test.want.error)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want.response, response)
+		})
+	}
+}
+
+func TestDeletePod(t *testing.T) {
+	namespaceName := testutils.TestNamespace + "-deletePod"
+	gracePeriodSeconds := int64(0)
+	type args struct {
+		namespace          string
+		cappName           string
+		podName            string
+		gracePeriodSeconds *int64
+	}
+	type want struct {
+		response types.DeletePodResponse
+		error    string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldSucceedDeletingPod": {
+			args: args{
+				namespace: namespaceName,
+				cappName:  testutils.CappName,
+				podName:   pod1,
+			},
+			want: want{
+				response: types.DeletePodResponse{
+					Message: fmt.Sprintf("Deleted pod %q in namespace %q successfully", pod1, namespaceName),
+				},
+			},
+		},
+		"ShouldSucceedDeletingPodWithGracePeriod": {
+			args: args{
+				namespace:          namespaceName,
+				cappName:           testutils.CappName,
+				podName:            pod2,
+				gracePeriodSeconds: &gracePeriodSeconds,
+			},
+			want: want{
+				response: types.DeletePodResponse{
+					Message: fmt.Sprintf("Deleted pod %q in namespace %q successfully", pod2, namespaceName),
+				},
+			},
+		},
+		"ShouldNotDeletePodOfAnotherCapp": {
+			args: args{
+				namespace: namespaceName,
+				cappName:  testutils.CappName,
+				podName:   pod3,
+			},
+			want: want{
+				error: fmt.Sprintf(ErrPodNotInCapp, pod3, testutils.CappName),
+			},
+		},
+		"ShouldNotDeleteNonExistingPod": {
+			args: args{
+				namespace: namespaceName,
+				cappName:  testutils.CappName,
+				podName:   pod1 + testutils.NonExistentSuffix,
+			},
+			want: want{
+				error: fmt.Sprintf(ErrCouldNotGetPod, pod1+testutils.NonExistentSuffix, namespaceName),
+			},
+		},
+	}
+
+	setup()
+	createTestNamespace(namespaceName, utils.AddManagedLabel(map[string]string{}))
+	mocks.CreateTestPod(fakeClient, namespaceName, pod1, testutils.CappName, false)
+	mocks.CreateTestPod(fakeClient, namespaceName, pod2, testutils.CappName, false)
+	mocks.CreateTestPod(fakeClient, namespaceName, pod3, testutils.CappName+testutils.NonExistentSuffix, false)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			podController := NewPodController(fakeClient, context.TODO(), logger)
+			response, err := podController.DeletePod(test.args.namespace, test.args.cappName, test.args.podName, test.args.gracePeriodSeconds)
 			if test.want.error != "" {
 				assert.ErrorContains(t, err, test.want.error)
 			} else {
 				assert.NoError(t, err)
+				_, err := fakeClient.CoreV1().Pods(test.args.namespace).Get(context.TODO(), test.args.podName, metav1.GetOptions{})
+				assert.True(t, errors.IsNotFound(err))
 			}
 			assert.Equal(t, test.want.response, response)
 		})
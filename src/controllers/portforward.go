@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"go.uber.org/zap"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"net/http"
+	"strconv"
+)
+
+const portForwardSubresource = "portforward"
+
+const (
+	errCreatingPortForwardDialer = "error creating port-forward dialer"
+	errOpeningPortForward        = "error opening port-forward session"
+	errForwardingPort            = "error forwarding port %d of pod %q"
+)
+
+// PortForwardCapp forwards a single connection to the port of a Capp's pod, copying raw bytes between the tunnel and
+// the port until either side closes or the context is done. The pod is selected the same way as for the Capp's logs.
+// The session is opened over a WebSocket, falling back to SPDY for API servers that do not support it.
+func PortForwardCapp(ctx context.Context, client kubernetes.Interface, config *rest.Config, namespace, cappName, podName string, port int, tunnel io.ReadWriter, logger *zap.Logger) error {
+	podName, err := GetCappPodName(ctx, client, namespace, cappName, podName, logger)
+	if err != nil {
+		return err
+	}
+
+	dialer, err := newPortForwardDialer(config, namespace, podName)
+	if err != nil {
+		logger.Error(fmt.Sprintf("%v: %v", errCreatingPortForwardDialer, err))
+		return customerrors.NewAPIError(errCreatingPortForwardDialer, err)
+	}
+
+	streamConn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("%v: %v", errOpeningPortForward, err))
+		return customerrors.NewAPIError(errOpeningPortForward, err)
+	}
+	defer streamConn.Close()
+
+	if err := forwardPort(ctx, streamConn, port, tunnel); err != nil {
+		logger.Debug(fmt.Sprintf("%v: %v", fmt.Sprintf(errForwardingPort, port, podName), err))
+		return customerrors.NewAPIError(fmt.Sprintf(errForwardingPort, port, podName), err)
+	}
+
+	return nil
+}
+
+// forwardPort opens the error and data streams of a port-forward request on the connection and copies the data
+// stream to and from the tunnel. It returns once the remote side is done, the tunnel is closed or the context is done,
+// along with the error reported by the remote side, if any.
+func forwardPort(ctx context.Context, streamConn httpstream.Connection, port int, tunnel io.ReadWriter) error {
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	headers.Set(corev1.PortHeader, strconv.Itoa(port))
+	headers.Set(corev1.PortForwardRequestIDHeader, "0")
+	errorStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		return err
+	}
+	// The error stream is only read from.
+	_ = errorStream.Close()
+	defer streamConn.RemoveStreams(errorStream)
+
+	remoteErr := make(chan error, 1)
+	go func() {
+		message, err := io.ReadAll(errorStream)
+		if err == nil && len(message) > 0 {
+			err = errors.New(string(message))
+		}
+		remoteErr <- err
+	}()
+
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+	dataStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		return err
+	}
+	defer streamConn.RemoveStreams(dataStream)
+
+	remoteDone := make(chan struct{})
+	go func() {
+		defer close(remoteDone)
+		_, _ = io.Copy(tunnel, dataStream)
+	}()
+
+	tunnelDone := make(chan struct{})
+	go func() {
+		defer close(tunnelDone)
+		// Tell the remote side that no more data is sent once the tunnel is closed.
+		defer dataStream.Close()
+		_, _ = io.Copy(dataStream, tunnel)
+	}()
+
+	select {
+	case <-remoteDone:
+	case <-tunnelDone:
+		return nil
+	case <-ctx.Done():
+		return nil
+	}
+
+	select {
+	case err := <-remoteErr:
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// newPortForwardDialer creates a dialer for the portforward subresource of the pod, tunneling SPDY over a WebSocket
+// with a fallback to SPDY.
+func newPortForwardDialer(config *rest.Config, namespace, podName string) (httpstream.Dialer, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	portForwardURL := clientset.CoreV1().RESTClient().Post().
+		Resource(podsResource).
+		Namespace(namespace).
+		Name(podName).
+		SubResource(portForwardSubresource).
+		URL()
+
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return nil, err
+	}
+	spdyDialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, portForwardURL)
+
+	websocketDialer, err := portforward.NewSPDYOverWebsocketDialer(portForwardURL, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return portforward.NewFallbackDialer(websocketDialer, spdyDialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	}), nil
+}
@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/utils"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeStream is a stream of a fake port-forward connection which reads from the given reader and records what is written to it.
+type fakeStream struct {
+	io.Reader
+	headers http.Header
+	mu      sync.Mutex
+	written bytes.Buffer
+	closed  bool
+}
+
+func (s *fakeStream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.written.Write(p)
+}
+
+func (s *fakeStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *fakeStream) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+func (s *fakeStream) writtenString() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.written.String()
+}
+
+func (s *fakeStream) Reset() error         { return s.Close() }
+func (s *fakeStream) Headers() http.Header { return s.headers }
+func (s *fakeStream) Identifier() uint32   { return 0 }
+
+// fakeStreamConnection is a port-forward connection returning the given error and data streams.
+type fakeStreamConnection struct {
+	errorStream *fakeStream
+	dataStream  *fakeStream
+}
+
+func (c *fakeStreamConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	stream := c.dataStream
+	if headers.Get(corev1.StreamType) == corev1.StreamTypeError {
+		stream = c.errorStream
+	}
+	stream.headers = headers.Clone()
+	return stream, nil
+}
+
+func (c *fakeStreamConnection) Close() error                         { return nil }
+func (c *fakeStreamConnection) CloseChan() <-chan bool               { return nil }
+func (c *fakeStreamConnection) SetIdleTimeout(_ time.Duration)       {}
+func (c *fakeStreamConnection) RemoveStreams(_ ...httpstream.Stream) {}
+
+// fakeTunnel is the client side of a port-forward session, which reads from the given reader and records what is written to it.
+type fakeTunnel struct {
+	reader  io.Reader
+	mu      sync.Mutex
+	written bytes.Buffer
+}
+
+func (t *fakeTunnel) Read(p []byte) (int, error) {
+	return t.reader.Read(p)
+}
+
+func (t *fakeTunnel) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.written.Write(p)
+}
+
+func (t *fakeTunnel) writtenString() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.written.String()
+}
+
+func TestForwardPort(t *testing.T) {
+	t.Run("ShouldCopyDataBetweenTunnelAndPort", func(t *testing.T) {
+		clientReader, clientWriter := io.Pipe()
+		defer clientWriter.Close()
+		go func() { _, _ = clientWriter.Write([]byte("ping")) }()
+
+		tunnel := &fakeTunnel{reader: clientReader}
+		streamConn := &fakeStreamConnection{
+			errorStream: &fakeStream{Reader: strings.NewReader("")},
+			dataStream:  &fakeStream{Reader: strings.NewReader("pong")},
+		}
+
+		err := forwardPort(context.TODO(), streamConn, 8080, tunnel)
+		assert.NoError(t, err)
+		assert.Equal(t, "pong", tunnel.writtenString())
+		assert.Eventually(t, func() bool { return streamConn.dataStream.writtenString() == "ping" }, time.Second, 10*time.Millisecond)
+		assert.Equal(t, "8080", streamConn.dataStream.Headers().Get(corev1.PortHeader))
+		assert.True(t, streamConn.errorStream.isClosed())
+	})
+
+	t.Run("ShouldReturnRemoteError", func(t *testing.T) {
+		clientReader, clientWriter := io.Pipe()
+		defer clientWriter.Close()
+
+		streamConn := &fakeStreamConnection{
+			errorStream: &fakeStream{Reader: strings.NewReader("connection refused")},
+			dataStream:  &fakeStream{Reader: strings.NewReader("")},
+		}
+
+		err := forwardPort(context.TODO(), streamConn, 8080, &fakeTunnel{reader: clientReader})
+		assert.EqualError(t, err, "connection refused")
+	})
+
+	t.Run("ShouldCloseDataStreamWhenTunnelIsClosed", func(t *testing.T) {
+		remoteReader, remoteWriter := io.Pipe()
+		defer remoteWriter.Close()
+
+		streamConn := &fakeStreamConnection{
+			errorStream: &fakeStream{Reader: strings.NewReader("")},
+			dataStream:  &fakeStream{Reader: remoteReader},
+		}
+
+		err := forwardPort(context.TODO(), streamConn, 8080, &fakeTunnel{reader: strings.NewReader("")})
+		assert.NoError(t, err)
+		assert.True(t, streamConn.dataStream.isClosed())
+	})
+}
+
+func TestPortForwardCapp(t *testing.T) {
+	namespaceName := testutils.TestNamespace + "-portforward"
+	type args struct {
+		cappName string
+		podName  string
+	}
+	cases := map[string]struct {
+		args args
+		want string
+	}{
+		"ShouldFailWithCappWithoutPods": {
+			args: args{cappName: testutils.CappName + testutils.NonExistentSuffix},
+			want: fmt.Sprintf(errNoPodsFound, testutils.CappName+testutils.NonExistentSuffix, namespaceName),
+		},
+		"ShouldFailWithPodOfAnotherCapp": {
+			args: args{cappName: testutils.CappName, podName: pod2},
+			want: fmt.Sprintf(errPodNotFound, pod2, testutils.CappName, namespaceName),
+		},
+		"ShouldFailWhenAPIServerIsUnreachable": {
+			args: args{cappName: testutils.CappName},
+			want: errOpeningPortForward,
+		},
+	}
+
+	setup()
+	createTestNamespace(namespaceName, utils.AddManagedLabel(map[string]string{}))
+	mocks.CreateTestPod(fakeClient, namespaceName, pod1, testutils.CappName, false)
+	mocks.CreateTestPod(fakeClient, namespaceName, pod2, "", false)
+	config := &rest.Config{Host: "http://127.0.0.1:1"}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := PortForwardCapp(context.TODO(), fakeClient, config, namespaceName, tc.args.cappName, tc.args.podName, 8080, &fakeTunnel{reader: strings.NewReader("")}, logger)
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
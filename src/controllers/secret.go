@@ -33,7 +33,7 @@ type SecretController interface {
 	CreateSecret(namespace string, request types.CreateSecretRequest) (types.CreateSecretResponse, error)
 
 	// GetSecrets gets all secretes from the specified namespace.
-	GetSecrets(namespace string, limit, page int) (types.GetSecretsResponse, error)
+	GetSecrets(namespace string, limit, page int, listQuery types.ListQuery) (types.GetSecretsResponse, error)
 
 	// GetSecret gets a specific secret from the specified namespace.
 	GetSecret(namespace, name string) (types.GetSecretResponse, error)
@@ -92,7 +92,7 @@ func (n *secretController) CreateSecret(namespace string, request types.CreateSe
 }
 
 // GetSecrets retrieves all secrets from the specified namespace.
-func (n *secretController) GetSecrets(namespace string, limit, page int) (types.GetSecretsResponse, error) {
+func (n *secretController) GetSecrets(namespace string, limit, page int, listQuery types.ListQuery) (types.GetSecretsResponse, error) {
 	n.logger.Debug(fmt.Sprintf("Trying to get all secrets in %q namespace", namespace))
 
 	secretPaginator := &SecretPaginator{
@@ -101,7 +101,7 @@ func (n *secretController) GetSecrets(namespace string, limit, page int) (types.
 		client:           n.client,
 	}
 
-	secrets, err := pagination.FetchPage[corev1.Secret](limit, page, secretPaginator)
+	secrets, listMetadata, err := pagination.FetchPage[corev1.Secret](limit, page, secretPaginator, listQuery)
 	if err != nil {
 		n.logger.Error(fmt.Sprintf("%v with error: %v", ErrCouldNotListSecrets, err))
 		return types.GetSecretsResponse{}, customerrors.NewAPIError(ErrCouldNotListSecrets, err)
@@ -109,8 +109,7 @@ func (n *secretController) GetSecrets(namespace string, limit, page int) (types.
 
 	n.logger.Debug("Fetched all secrets successfully")
 
-	response := types.GetSecretsResponse{}
-	response.Count = len(secrets)
+	response := types.GetSecretsResponse{ListMetadata: listMetadata}
 	for _, secret := range secrets {
 		response.Secrets = append(
 			response.Secrets,
@@ -139,7 +139,7 @@ func TestGetSecrets(t *testing.T) {
 			secretController := NewSecretController(fakeClient, c, logger)
 
 			limit, page, _ := pagination.ExtractPaginationParamsFromCtx(c)
-			response, err := secretController.GetSecrets(test.requestParams.namespace, limit, page)
+			response, err := secretController.GetSecrets(test.requestParams.namespace, limit, page, types.ListQuery{})
 			assert.NoError(t, err)
 			assert.Equal(t, test.want.response, response)
 		})
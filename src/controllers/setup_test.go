@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	"os"
 	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
 	runtimeFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -68,5 +69,6 @@ func setupScheme() *runtime.Scheme {
 	schema := scheme.Scheme
 	_ = cappv1alpha1.AddToScheme(schema)
 	_ = dnsrecordv1alpha1.AddToScheme(schema)
+	_ = metricsv1beta1.AddToScheme(schema)
 	return schema
 }
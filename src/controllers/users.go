@@ -34,7 +34,7 @@ const (
 
 type UserController interface {
 	// GetUsers get users from specified namespace and returns them as users.
-	GetUsers(namespace string, limit, page int) (types.UsersOutput, error)
+	GetUsers(namespace string, limit, page int, listQuery types.ListQuery) (types.UsersOutput, error)
 
 	// GetUser gets a specific user from specified namespace and returns it as user.
 	GetUser(userIdentifier types.UserIdentifier) (types.User, error)
@@ -70,7 +70,7 @@ func NewUserController(client kubernetes.Interface, context context.Context, log
 	}
 }
 
-func (u *userController) GetUsers(namespace string, limit, page int) (types.UsersOutput, error) {
+func (u *userController) GetUsers(namespace string, limit, page int, listQuery types.ListQuery) (types.UsersOutput, error) {
 	userOutputs := types.UsersOutput{}
 	u.logger.Debug(fmt.Sprintf("Trying to get all rolebindings in %q namespace", namespace))
 
@@ -80,7 +80,7 @@ func (u *userController) GetUsers(namespace string, limit, page int) (types.User
 		client:           u.client,
 	}
 
-	roleBindings, err := pagination.FetchPage[rbacv1.RoleBinding](limit, page, userPaginator)
+	roleBindings, listMetadata, err := pagination.FetchPage[rbacv1.RoleBinding](limit, page, userPaginator, listQuery)
 	if err != nil {
 		u.logger.Error(fmt.Sprintf("%v with error: %v", ErrCouldNotListUsers, err))
 		return types.UsersOutput{}, customerrors.NewAPIError(ErrCouldNotListUsers, err)
@@ -88,7 +88,7 @@ func (u *userController) GetUsers(namespace string, limit, page int) (types.User
 	for _, roleBinding := range roleBindings {
 		userOutputs.Users = append(userOutputs.Users, types.User{Name: roleBinding.Name, Role: convertToPlatformRole(roleBinding.RoleRef.Name)})
 	}
-	userOutputs.Count = len(roleBindings)
+	userOutputs.ListMetadata = listMetadata
 
 	return userOutputs, nil
 }
@@ -32,6 +32,8 @@ const (
 	KubeClientCtxKey    = "kubeClient"
 	DynamicClientCtxKey = "dynClient"
 	TokenCtxKey         = "token"
+	RestConfigCtxKey    = "restConfig"
+	UsernameCtxKey      = "username"
 )
 
 const (
@@ -80,7 +82,7 @@ func TokenAuthMiddleware(tokenProvider auth.TokenProvider, scheme *runtime.Schem
 			return
 		}
 
-		dynClient, err := client.New(config, client.Options{Scheme: scheme})
+		dynClient, err := client.NewWithWatch(config, client.Options{Scheme: scheme})
 		if err != nil {
 			userLogger.Error("Failed to create Kubernetes dynamic client", zap.Error(err))
 			AddErrorToContext(c, customerrors.NewInternalServerError("failed to create Kubernetes dynamic client"))
@@ -96,6 +98,8 @@ func TokenAuthMiddleware(tokenProvider auth.TokenProvider, scheme *runtime.Schem
 		c.Set(KubeClientCtxKey, kubeClient)
 		c.Set(DynamicClientCtxKey, dynClient)
 		c.Set(TokenCtxKey, token)
+		c.Set(RestConfigCtxKey, config)
+		c.Set(UsernameCtxKey, username)
 		c.Next()
 	}
 }
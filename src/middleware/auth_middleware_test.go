@@ -57,6 +57,12 @@ func TestTokenAuthMiddleware(t *testing.T) {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "dynClient not set in context"})
 			return
 		}
+		username, err := GetUsername(c)
+		if err != nil || username != "user" {
+			t.Errorf("Expected username %q to be set in context, got %q", "user", username)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "username not set in context"})
+			return
+		}
 
 		c.JSON(http.StatusOK, gin.H{
 			"message": "pong",
@@ -5,6 +5,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -26,6 +27,15 @@ func GetDynClient(c *gin.Context) (client.Client, error) {
 	return kube.(client.Client), nil
 }
 
+// GetRestConfig retrieves the Kubernetes client config of the user from the gin.Context.
+func GetRestConfig(c *gin.Context) (*rest.Config, error) {
+	config, exists := c.Get(RestConfigCtxKey)
+	if !exists {
+		return nil, c.Error(customerrors.NewNotFoundError("kubernetes client config not found in context"))
+	}
+	return config.(*rest.Config), nil
+}
+
 // GetLogger retrieves the logger from the gin.Context.
 func GetLogger(c *gin.Context) (*zap.Logger, error) {
 	logger, exists := c.Get(LoggerCtxKey)
@@ -35,6 +45,15 @@ func GetLogger(c *gin.Context) (*zap.Logger, error) {
 	return logger.(*zap.Logger), nil
 }
 
+// GetUsername retrieves the username of the authenticated user from the gin.Context.
+func GetUsername(c *gin.Context) (string, error) {
+	username, exists := c.Get(UsernameCtxKey)
+	if !exists {
+		return "", c.Error(customerrors.NewNotFoundError("username not found in context"))
+	}
+	return username.(string), nil
+}
+
 // GetCluster retrieves the cluster from the gin.Context.
 func GetCluster(c *gin.Context) (string, bool) {
 	cluster, exists := c.Get(ClusterCtxKey)
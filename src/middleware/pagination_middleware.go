@@ -8,8 +8,9 @@ import (
 )
 
 const (
-	PageCtxKey  = "page"
-	LimitCtxKey = "limit"
+	PageCtxKey      = "page"
+	LimitCtxKey     = "limit"
+	ListQueryCtxKey = "listQuery"
 )
 
 // PaginationMiddleware is a middleware for extracting and setting pagination parameters from the request context
@@ -28,8 +29,16 @@ func PaginationMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		var listQuery types.ListQuery
+		if err := c.BindQuery(&listQuery); err != nil {
+			AddErrorToContext(c, customerrors.NewValidationError(fmt.Sprintf("invalid request, %s", err)))
+			c.Abort()
+			return
+		}
+
 		c.Set(LimitCtxKey, paginationParams.Limit)
 		c.Set(PageCtxKey, paginationParams.Page)
+		c.Set(ListQueryCtxKey, listQuery)
 		c.Next()
 	}
 }
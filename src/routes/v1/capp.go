@@ -1,17 +1,26 @@
 package v1
 
 import (
+	"fmt"
 	"github.com/dana-team/platform-backend/src/customerrors"
 	"github.com/dana-team/platform-backend/src/middleware"
 	"github.com/dana-team/platform-backend/src/routes"
 	"github.com/dana-team/platform-backend/src/utils/pagination"
 	"net/http"
+	"strings"
 
 	"github.com/dana-team/platform-backend/src/controllers"
 	"github.com/dana-team/platform-backend/src/types"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	eTagHeader     = "ETag"
+	ifMatchHeader  = "If-Match"
+	weakETagPrefix = "W/"
+	anyETag        = "*"
+)
+
 func cappHandler(handler func(controller controllers.CappController, c *gin.Context) (interface{}, error)) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		kubeClient, err := middleware.GetDynClient(c)
@@ -57,7 +66,7 @@ func GetCapps() gin.HandlerFunc {
 		}
 
 		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
-			return controller.GetCapps(cappUri.NamespaceName, limit, page, cappQuery)
+			return controller.GetCapps(cappUri.NamespaceName, limit, page, cappQuery, pagination.ExtractListQueryFromCtx(c))
 		})(c)
 	}
 }
@@ -71,7 +80,11 @@ func GetCapp() gin.HandlerFunc {
 		}
 
 		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
-			return controller.GetCapp(cappUri.NamespaceName, cappUri.CappName)
+			capp, err := controller.GetCapp(cappUri.NamespaceName, cappUri.CappName)
+			if err == nil {
+				setCappETag(c, capp)
+			}
+			return capp, err
 		})(c)
 	}
 }
@@ -88,9 +101,14 @@ func CreateCapp() gin.HandlerFunc {
 			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
 			return
 		}
+		var cappDryRunQuery types.CappDryRunQuery
+		if err := c.BindQuery(&cappDryRunQuery); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
 
 		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
-			return controller.CreateCapp(cappUri.NamespaceName, capp)
+			return controller.CreateCapp(cappUri.NamespaceName, capp, cappDryRunQuery.DryRun)
 		})(c)
 	}
 }
@@ -107,9 +125,100 @@ func UpdateCapp() gin.HandlerFunc {
 			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
 			return
 		}
+		var cappDryRunQuery types.CappDryRunQuery
+		if err := c.BindQuery(&cappDryRunQuery); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
 
 		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
-			return controller.UpdateCapp(cappUri.NamespaceName, cappUri.CappName, capp)
+			return controller.UpdateCapp(cappUri.NamespaceName, cappUri.CappName, capp, cappDryRunQuery.DryRun)
+		})(c)
+	}
+}
+
+func PatchCapp() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+		patch, err := c.GetRawData()
+		if err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		request := types.PatchCappRequest{
+			PatchType:        c.ContentType(),
+			Patch:            patch,
+			ResourceVersions: parseIfMatch(c.GetHeader(ifMatchHeader)),
+		}
+
+		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
+			capp, err := controller.PatchCapp(cappUri.NamespaceName, cappUri.CappName, request)
+			if err == nil {
+				setCappETag(c, capp)
+			}
+			return capp, err
+		})(c)
+	}
+}
+
+func RollbackCapp() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+		var request types.RollbackCappRequest
+		if err := c.BindJSON(&request); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
+			return controller.RollbackCapp(cappUri.NamespaceName, cappUri.CappName, request)
+		})(c)
+	}
+}
+
+func RestartCapp() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
+			return controller.RestartCapp(cappUri.NamespaceName, cappUri.CappName)
+		})(c)
+	}
+}
+
+func DeployCapp() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+		var request types.DeployCappRequest
+		if err := c.BindJSON(&request); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		username, err := middleware.GetUsername(c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
+			return controller.DeployCapp(cappUri.NamespaceName, cappUri.CappName, username, request)
 		})(c)
 	}
 }
@@ -161,6 +270,53 @@ func GetCappDNS() gin.HandlerFunc {
 	}
 }
 
+func GetCappTraffic() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
+			return controller.GetCappTraffic(cappUri.NamespaceName, cappUri.CappName)
+		})(c)
+	}
+}
+
+func UpdateCappTraffic() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+		var request types.UpdateCappTrafficRequest
+		if err := c.BindJSON(&request); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
+			return controller.UpdateCappTraffic(cappUri.NamespaceName, cappUri.CappName, request)
+		})(c)
+	}
+}
+
+func GetCappHistory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
+			return controller.GetCappHistory(cappUri.NamespaceName, cappUri.CappName)
+		})(c)
+	}
+}
+
 func DeleteCapp() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var cappUri types.CappUri
@@ -174,3 +330,29 @@ func DeleteCapp() gin.HandlerFunc {
 		})(c)
 	}
 }
+
+// setCappETag sets the ETag header of the response to the resourceVersion of the Capp.
+func setCappETag(c *gin.Context, capp types.Capp) {
+	if capp.Metadata.ResourceVersion != "" {
+		c.Header(eTagHeader, fmt.Sprintf("%q", capp.Metadata.ResourceVersion))
+	}
+}
+
+// parseIfMatch returns the resourceVersions from an If-Match header, which may contain a
+// comma separated list of bare resourceVersions or ETags as returned by setCappETag.
+// No resourceVersions are returned for an empty header or "*", which match any Capp.
+func parseIfMatch(ifMatch string) []string {
+	var resourceVersions []string
+	for _, eTag := range strings.Split(ifMatch, ",") {
+		eTag = strings.TrimSpace(eTag)
+		if eTag == anyETag {
+			return nil
+		}
+
+		if resourceVersion := strings.Trim(strings.TrimPrefix(eTag, weakETagPrefix), `"`); resourceVersion != "" {
+			resourceVersions = append(resourceVersions, resourceVersion)
+		}
+	}
+
+	return resourceVersions
+}
@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"github.com/dana-team/platform-backend/src/controllers"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/gin-gonic/gin"
+)
+
+func StartCappRollout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+		var request types.StartCappRolloutRequest
+		if err := c.BindJSON(&request); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
+			return controller.StartCappRollout(cappUri.NamespaceName, cappUri.CappName, request)
+		})(c)
+	}
+}
+
+func GetCappRollout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
+			return controller.GetCappRollout(cappUri.NamespaceName, cappUri.CappName)
+		})(c)
+	}
+}
+
+func AbortCappRollout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
+			return controller.AbortCappRollout(cappUri.NamespaceName, cappUri.CappName)
+		})(c)
+	}
+}
+
+func ResumeCappRollout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		cappHandler(func(controller controllers.CappController, c *gin.Context) (interface{}, error) {
+			return controller.ResumeCappRollout(cappUri.NamespaceName, cappUri.CappName)
+		})(c)
+	}
+}
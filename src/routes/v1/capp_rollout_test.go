@@ -0,0 +1,165 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/controllers"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCappRollout(t *testing.T) {
+	testNamespaceName := testutils.CappNamespace + "-rollout"
+	revisionName := testutils.CappName + "-v2"
+	knativeRevisionName := testutils.CappName + "-00002"
+
+	type want struct {
+		statusCode int
+		state      string
+		response   map[string]interface{}
+	}
+
+	// The steps share the Capp and run in order, since every step acts on the rollout left by the previous one.
+	steps := []struct {
+		name        string
+		method      string
+		path        string
+		requestData interface{}
+		want        want
+	}{
+		{
+			name:        "ShouldHandleRolloutWithoutRevision",
+			method:      http.MethodPost,
+			path:        "rollout",
+			requestData: types.StartCappRolloutRequest{},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  "Key: 'StartCappRolloutRequest.RevisionName' Error:Field validation for 'RevisionName' failed on the 'required' tag",
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
+		{
+			name:        "ShouldHandleGettingRolloutBeforeStart",
+			method:      http.MethodGet,
+			path:        "rollout",
+			requestData: nil,
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(controllers.ErrRolloutNotFound, testutils.CappName, testNamespaceName),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+		},
+		{
+			name:        "ShouldSucceedStartingRollout",
+			method:      http.MethodPost,
+			path:        "rollout",
+			requestData: types.StartCappRolloutRequest{RevisionName: revisionName},
+			want: want{
+				statusCode: http.StatusOK,
+				state:      types.RolloutStateProgressing,
+			},
+		},
+		{
+			name:        "ShouldHandleStartingRolloutWhileProgressing",
+			method:      http.MethodPost,
+			path:        "rollout",
+			requestData: types.StartCappRolloutRequest{RevisionName: revisionName},
+			want: want{
+				statusCode: http.StatusConflict,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(controllers.ErrRolloutInProgress, testutils.CappName, testNamespaceName, revisionName),
+					testutils.ReasonKey: metav1.StatusReasonConflict,
+				},
+			},
+		},
+		{
+			name:   "ShouldSucceedGettingProgressingRollout",
+			method: http.MethodGet,
+			path:   "rollout",
+			want: want{
+				statusCode: http.StatusOK,
+				state:      types.RolloutStateProgressing,
+			},
+		},
+		{
+			name:   "ShouldSucceedAbortingRollout",
+			method: http.MethodPost,
+			path:   "rollout/abort",
+			want: want{
+				statusCode: http.StatusOK,
+				state:      types.RolloutStateAborted,
+			},
+		},
+		{
+			name:   "ShouldHandleAbortingAbortedRollout",
+			method: http.MethodPost,
+			path:   "rollout/abort",
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(controllers.ErrRolloutNotAbortable, testutils.CappName, testNamespaceName, types.RolloutStateAborted),
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
+		{
+			name:   "ShouldSucceedResumingAbortedRollout",
+			method: http.MethodPost,
+			path:   "rollout/resume",
+			want: want{
+				statusCode: http.StatusOK,
+				state:      types.RolloutStateProgressing,
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestCappWithServingRevision(dynClient, testutils.CappName, testNamespaceName, testutils.Domain, testutils.CappName+"-00001")
+	mocks.CreateTestCappRevisionOfCapp(dynClient, revisionName, testNamespaceName, testutils.CappName, testutils.Image, 2, nil)
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			var body *bytes.Buffer
+			if step.requestData != nil {
+				payload, err := json.Marshal(step.requestData)
+				assert.NoError(t, err)
+				body = bytes.NewBuffer(payload)
+			} else {
+				body = bytes.NewBuffer(nil)
+			}
+
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/%s", testNamespaceName, testutils.CappName, step.path)
+			request, err := http.NewRequest(step.method, baseURI, body)
+			assert.NoError(t, err)
+			request.Header.Set(testutils.ContentType, testutils.ApplicationJson)
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, step.want.statusCode, writer.Code)
+			if step.want.statusCode != http.StatusOK {
+				var response map[string]interface{}
+				assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+				assert.Equal(t, normalizeJSON(t, step.want.response), response)
+				return
+			}
+
+			var rollout types.CappRollout
+			assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &rollout))
+			assert.Equal(t, revisionName, rollout.RevisionName)
+			assert.Equal(t, knativeRevisionName, rollout.KnativeRevisionName)
+			assert.Equal(t, step.want.state, rollout.State)
+		})
+	}
+}
@@ -2,11 +2,13 @@ package v1
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
 	"github.com/dana-team/platform-backend/src/controllers"
 	"github.com/dana-team/platform-backend/src/middleware"
+	paginationpkg "github.com/dana-team/platform-backend/src/utils/pagination"
 	"github.com/dana-team/platform-backend/src/utils/testutils"
 	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
 	corev1 "k8s.io/api/core/v1"
@@ -38,6 +40,9 @@ func TestGetCapps(t *testing.T) {
 		namespace        string
 		labelSelector    selector
 		paginationParams pagination
+		state            string
+		site             string
+		listQuery        types.ListQuery
 	}
 
 	type want struct {
@@ -58,8 +63,8 @@ func TestGetCapps(t *testing.T) {
 				response: map[string]interface{}{
 					testutils.CountKey: 4,
 					testutils.CappsKey: []types.CappSummary{
-						{Name: testutils.CappName + "-1", URL: fmt.Sprintf("https://%s-%s.%s", testutils.CappName+"-1", testNamespaceName, testutils.Domain), Images: []string{testutils.CappImage}},
-						{Name: testutils.CappName + "-2", URL: fmt.Sprintf("https://%s-%s.%s", testutils.CappName+"-2", testNamespaceName, testutils.Domain), Images: []string{testutils.CappImage}},
+						mocks.PrepareCappSummary(testutils.CappName+"-1", testNamespaceName),
+						mocks.PrepareCappSummaryWithSite(testutils.CappName+"-2", testNamespaceName, testutils.Site),
 						{Name: testutils.CappName + "-3", URL: fmt.Sprintf("https://%s.%s", testutils.Hostname, testutils.Domain), Images: []string{testutils.CappImage}},
 						{Name: testutils.CappName + "-4", URL: fmt.Sprintf("https://%s.%s", testutils.Hostname, testutils.Domain), Images: []string{testutils.CappImage}},
 					},
@@ -76,8 +81,8 @@ func TestGetCapps(t *testing.T) {
 				response: map[string]interface{}{
 					testutils.CountKey: 4,
 					testutils.CappsKey: []types.CappSummary{
-						{Name: testutils.CappName + "-1", URL: fmt.Sprintf("https://%s-%s.%s", testutils.CappName+"-1", testNamespaceName, testutils.Domain), Images: []string{testutils.CappImage}},
-						{Name: testutils.CappName + "-2", URL: fmt.Sprintf("https://%s-%s.%s", testutils.CappName+"-2", testNamespaceName, testutils.Domain), Images: []string{testutils.CappImage}},
+						mocks.PrepareCappSummary(testutils.CappName+"-1", testNamespaceName),
+						mocks.PrepareCappSummaryWithSite(testutils.CappName+"-2", testNamespaceName, testutils.Site),
 						{Name: testutils.CappName + "-3", URL: fmt.Sprintf("https://%s.%s", testutils.Hostname, testutils.Domain), Images: []string{testutils.CappImage}},
 						{Name: testutils.CappName + "-4", URL: fmt.Sprintf("https://%s.%s", testutils.Hostname, testutils.Domain), Images: []string{testutils.CappImage}},
 					},
@@ -97,7 +102,7 @@ func TestGetCapps(t *testing.T) {
 				response: map[string]interface{}{
 					testutils.CountKey: 1,
 					testutils.CappsKey: []types.CappSummary{
-						{Name: testutils.CappName + "-1", URL: fmt.Sprintf("https://%s-%s.%s", testutils.CappName+"-1", testNamespaceName, testutils.Domain), Images: []string{testutils.CappImage}},
+						mocks.PrepareCappSummary(testutils.CappName+"-1", testNamespaceName),
 					},
 				},
 			},
@@ -118,6 +123,147 @@ func TestGetCapps(t *testing.T) {
 				},
 			},
 		},
+		"ShouldSucceedGettingCappsByStateAndSite": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				state:     testutils.EnabledState,
+				site:      testutils.Site,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.CountKey: 1,
+					testutils.CappsKey: []types.CappSummary{
+						mocks.PrepareCappSummaryWithSite(testutils.CappName+"-2", testNamespaceName, testutils.Site),
+					},
+				},
+			},
+		},
+		"ShouldSucceedGettingNoCappsByState": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				state:     testutils.DisabledState,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.CountKey: 0,
+					testutils.CappsKey: nil,
+				},
+			},
+		},
+		"ShouldFailGettingCappsWithInvalidState": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				state:     testutils.Unknown,
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  "Key: 'CappQuery.State' Error:Field validation for 'State' failed on the 'oneof' tag",
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
+		"ShouldSucceedGettingCappsSortedByNameDescending": {
+			requestURI: requestURI{
+				namespace:        testNamespaceName,
+				paginationParams: pagination{limit: "2", page: "2"},
+				listQuery:        types.ListQuery{SortBy: paginationpkg.SortByName, Order: paginationpkg.OrderDesc},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.CountKey: 2,
+					testutils.CappsKey: []types.CappSummary{
+						mocks.PrepareCappSummaryWithSite(testutils.CappName+"-2", testNamespaceName, testutils.Site),
+						mocks.PrepareCappSummary(testutils.CappName+"-1", testNamespaceName),
+					},
+				},
+			},
+		},
+		"ShouldSucceedGettingCappsBySearch": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				listQuery: types.ListQuery{Search: "-1"},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.CountKey: 1,
+					testutils.CappsKey: []types.CappSummary{
+						mocks.PrepareCappSummary(testutils.CappName+"-1", testNamespaceName),
+					},
+				},
+			},
+		},
+		"ShouldSucceedGettingCappsWithFieldSelector": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				listQuery: types.ListQuery{FieldSelector: fmt.Sprintf("%s=%s-2", testutils.MetadataNameField, testutils.CappName)},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.CountKey: 1,
+					testutils.CappsKey: []types.CappSummary{
+						mocks.PrepareCappSummaryWithSite(testutils.CappName+"-2", testNamespaceName, testutils.Site),
+					},
+				},
+			},
+		},
+		"ShouldFailGettingCappsWithInvalidFieldSelector": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				listQuery: types.ListQuery{FieldSelector: testutils.InvalidFieldSelector},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf("%s: invalid selector: '%s'; can't understand '%s'", paginationpkg.ErrParsingFieldSelector, testutils.InvalidFieldSelector, testutils.InvalidFieldSelector),
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
+		"ShouldFailGettingCappsWithContinueAndSortBy": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				listQuery: types.ListQuery{Continue: testutils.ContinueKey, SortBy: paginationpkg.SortByName},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  paginationpkg.ErrContinueWithListQuery,
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
+		"ShouldFailGettingCappsWithOrderWithoutSortBy": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				listQuery: types.ListQuery{Order: paginationpkg.OrderDesc},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  paginationpkg.ErrOrderWithoutSortBy,
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
+		"ShouldFailGettingCappsWithInvalidSortBy": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				listQuery: types.ListQuery{SortBy: testutils.Unknown},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  "invalid request, Key: 'ListQuery.SortBy' Error:Field validation for 'SortBy' failed on the 'oneof' tag",
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
 		"ShouldSucceedGettingNoCappsWithLabelSelector": {
 			requestURI: requestURI{
 				namespace: testNamespaceName,
@@ -140,7 +286,7 @@ func TestGetCapps(t *testing.T) {
 	mocks.CreateTestNamespace(fakeClient, testNamespaceName)
 	mocks.CreateTestCapp(dynClient, testutils.CappName+"-1", testNamespaceName, testutils.Domain,
 		map[string]string{testutils.LabelKey + "-1": testutils.LabelValue + "-1"}, nil)
-	mocks.CreateTestCapp(dynClient, testutils.CappName+"-2", testNamespaceName, testutils.Domain,
+	mocks.CreateTestCappWithSite(dynClient, testutils.CappName+"-2", testNamespaceName, testutils.Site, testutils.Domain,
 		map[string]string{testutils.LabelKey + "-2": testutils.LabelValue + "-2"}, nil)
 	mocks.CreateTestCappWithHostname(dynClient, testutils.CappName+"-3", testNamespaceName, testutils.Hostname, testutils.Domain,
 		map[string]string{testutils.LabelKey + "-3": testutils.LabelValue + "-3"}, nil)
@@ -163,6 +309,16 @@ func TestGetCapps(t *testing.T) {
 				params.Add(middleware.PageCtxKey, test.requestURI.paginationParams.page)
 			}
 
+			if test.requestURI.state != "" {
+				params.Add(testutils.StateKey, test.requestURI.state)
+			}
+
+			if test.requestURI.site != "" {
+				params.Add(testutils.SiteKey, test.requestURI.site)
+			}
+
+			addListQueryParams(params, test.requestURI.listQuery)
+
 			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s?%s", baseURI, params.Encode()), nil)
 			assert.NoError(t, err)
 			writer := httptest.NewRecorder()
@@ -389,6 +545,168 @@ func TestGetCappState(t *testing.T) {
 	}
 }
 
+func TestGetCappTraffic(t *testing.T) {
+	testNamespaceName := testutils.CappNamespace + "-get-traffic"
+
+	type want struct {
+		statusCode int
+		response   map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		cappName string
+		want     want
+	}{
+		"ShouldSucceedGettingCappTraffic": {
+			cappName: testutils.CappName,
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					"cappName": testutils.CappName,
+					"url":      fmt.Sprintf("https://%s-%s.%s", testutils.CappName, testNamespaceName, testutils.Domain),
+					"targets": []types.TrafficTarget{
+						{RevisionName: testutils.CappName + "-00002", Percent: 100 - testutils.PreviousRevisionPercent, LatestRevision: true},
+						{
+							RevisionName: testutils.CappName + "-00001",
+							Tag:          testutils.TrafficTag,
+							Percent:      testutils.PreviousRevisionPercent,
+							URL:          fmt.Sprintf("https://%s-%s-%s.%s", testutils.TrafficTag, testutils.CappName, testNamespaceName, testutils.Domain),
+						},
+					},
+				},
+			},
+		},
+		"ShouldHandleNotFoundCapp": {
+			cappName: testutils.CappName + testutils.NonExistentSuffix,
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey: fmt.Sprintf("%v, %v",
+						fmt.Sprintf(controllers.ErrCouldNotGetCapp, testutils.CappName+testutils.NonExistentSuffix, testNamespaceName),
+						fmt.Sprintf("%s.%s %q not found", testutils.CappsKey, cappv1alpha1.GroupVersion.Group, testutils.CappName+testutils.NonExistentSuffix),
+					),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestCappWithTraffic(dynClient, testutils.CappName, testNamespaceName, testutils.Domain, testutils.TrafficTag)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/traffic", testNamespaceName, test.cappName)
+			request, err := http.NewRequest(http.MethodGet, baseURI, nil)
+			assert.NoError(t, err)
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+			assert.Equal(t, normalizeJSON(t, test.want.response), response)
+		})
+	}
+}
+
+func TestUpdateCappTraffic(t *testing.T) {
+	testNamespaceName := testutils.CappNamespace + "-update-traffic"
+	cappRevisionName := testutils.CappRevisionName + "-traffic"
+
+	type want struct {
+		statusCode int
+		response   map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		requestData types.UpdateCappTrafficRequest
+		want        want
+	}{
+		"ShouldSucceedRoutingTrafficToRevision": {
+			requestData: types.UpdateCappTrafficRequest{Targets: []types.CappTrafficTargetRequest{
+				{RevisionName: cappRevisionName + "-1", Percent: 100, Tag: testutils.TrafficTag},
+			}},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					"cappName": testutils.CappName,
+					"url":      fmt.Sprintf("https://%s-%s.%s", testutils.CappName, testNamespaceName, testutils.Domain),
+					"targets": []types.TrafficTarget{
+						{RevisionName: testutils.CappName + "-00001", Tag: testutils.TrafficTag, Percent: 100},
+					},
+				},
+			},
+		},
+		"ShouldHandlePercentagesNotSummingTo100": {
+			requestData: types.UpdateCappTrafficRequest{Targets: []types.CappTrafficTargetRequest{
+				{RevisionName: cappRevisionName + "-1", Percent: 50},
+			}},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(controllers.ErrTrafficPercentSum, 50),
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
+		"ShouldHandleTrafficSplitBetweenRevisions": {
+			requestData: types.UpdateCappTrafficRequest{Targets: []types.CappTrafficTargetRequest{
+				{RevisionName: cappRevisionName + "-2", Percent: 100 - testutils.PreviousRevisionPercent},
+				{RevisionName: cappRevisionName + "-1", Percent: testutils.PreviousRevisionPercent},
+			}},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(controllers.ErrTrafficSplitNotSupported, testutils.CappName, testNamespaceName, 2),
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
+		"ShouldHandleRevisionOfAnotherCapp": {
+			requestData: types.UpdateCappTrafficRequest{Targets: []types.CappTrafficTargetRequest{
+				{RevisionName: cappRevisionName + "-other", Percent: 100},
+			}},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(controllers.ErrCappRevisionNotOfCapp, cappRevisionName+"-other", testutils.CappName, testNamespaceName),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespaceName, testutils.Domain, nil, nil)
+	mocks.CreateTestCappRevisionOfCapp(dynClient, cappRevisionName+"-1", testNamespaceName, testutils.CappName, testutils.Image, 1, nil)
+	mocks.CreateTestCappRevisionOfCapp(dynClient, cappRevisionName+"-2", testNamespaceName, testutils.CappName, testutils.Image, 2, nil)
+	mocks.CreateTestCappRevisionOfCapp(dynClient, cappRevisionName+"-other", testNamespaceName, testutils.CappName+"-other", testutils.Image, 1, nil)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			payload, err := json.Marshal(test.requestData)
+			assert.NoError(t, err)
+
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/traffic", testNamespaceName, testutils.CappName)
+			request, err := http.NewRequest(http.MethodPut, baseURI, bytes.NewBuffer(payload))
+			assert.NoError(t, err)
+			request.Header.Set(testutils.ContentType, testutils.ApplicationJson)
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+			assert.Equal(t, normalizeJSON(t, test.want.response), response)
+		})
+	}
+}
+
 func TestGetCappDNS(t *testing.T) {
 	testNamespaceName := testutils.CappNamespace + "-get-dns"
 
@@ -580,6 +898,7 @@ func TestCreateCapp(t *testing.T) {
 	cases := map[string]struct {
 		requestURI  requestURI
 		want        want
+		dryRun      string
 		requestData interface{}
 	}{
 		"ShouldSucceedCreatingCapp": {
@@ -598,6 +917,37 @@ func TestCreateCapp(t *testing.T) {
 			},
 			requestData: mocks.PrepareCreateCappType(testutils.CappName, []types.KeyValue{{Key: testutils.LabelKey, Value: testutils.LabelValue}}, nil),
 		},
+		"ShouldSucceedCreatingCappInDryRun": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.MetadataKey:    types.Metadata{Name: testutils.CappName + "-dry-run", Namespace: testNamespaceName},
+					testutils.LabelsKey:      []types.KeyValue{{Key: testutils.LabelKey, Value: testutils.LabelValue}},
+					testutils.AnnotationsKey: nil,
+					testutils.SpecKey:        mocks.PrepareCappSpec(),
+					testutils.StatusKey:      cappv1alpha1.CappStatus{},
+				},
+			},
+			dryRun:      "true",
+			requestData: mocks.PrepareCreateCappType(testutils.CappName+"-dry-run", []types.KeyValue{{Key: testutils.LabelKey, Value: testutils.LabelValue}}, nil),
+		},
+		"ShouldFailWithInvalidDryRun": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  `strconv.ParseBool: parsing "invalid": invalid syntax`,
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+			dryRun:      "invalid",
+			requestData: mocks.PrepareCreateCappType(testutils.CappName+"-dry-run", []types.KeyValue{{Key: testutils.LabelKey, Value: testutils.LabelValue}}, nil),
+		},
 		"ShouldFailWithBadRequestBody": {
 			requestURI: requestURI{
 				namespace: testNamespaceName,
@@ -638,6 +988,9 @@ func TestCreateCapp(t *testing.T) {
 			assert.NoError(t, err)
 
 			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps", test.requestURI.namespace)
+			if test.dryRun != "" {
+				baseURI += "?dryRun=" + test.dryRun
+			}
 			request, err := http.NewRequest(http.MethodPost, baseURI, bytes.NewBuffer(payload))
 			assert.NoError(t, err)
 			request.Header.Set(testutils.ContentType, testutils.ApplicationJson)
@@ -677,6 +1030,7 @@ func TestUpdateCapp(t *testing.T) {
 	cases := map[string]struct {
 		requestURI  requestURI
 		want        want
+		dryRun      string
 		requestData interface{}
 	}{
 		"ShouldSucceedUpdatingCapp": {
@@ -732,6 +1086,7 @@ func TestUpdateCapp(t *testing.T) {
 
 	setup()
 	mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespaceName, testutils.Domain, map[string]string{testutils.LabelKey: testutils.LabelValue}, nil)
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-dry-run", testNamespaceName, testutils.Domain, map[string]string{testutils.LabelKey: testutils.LabelValue}, nil)
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -739,6 +1094,9 @@ func TestUpdateCapp(t *testing.T) {
 			assert.NoError(t, err)
 
 			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s", test.requestURI.namespace, test.requestURI.name)
+			if test.dryRun != "" {
+				baseURI += "?dryRun=" + test.dryRun
+			}
 			request, err := http.NewRequest(http.MethodPut, baseURI, bytes.NewBuffer(payload))
 			assert.NoError(t, err)
 			request.Header.Set(testutils.ContentType, testutils.ApplicationJson)
@@ -762,8 +1120,8 @@ func TestUpdateCapp(t *testing.T) {
 	}
 }
 
-func TestEditCappState(t *testing.T) {
-	testNamespaceName := testutils.CappNamespace + "-update"
+func TestPatchCapp(t *testing.T) {
+	testNamespaceName := testutils.CappNamespace + "-patch"
 
 	type requestURI struct {
 		name      string
@@ -772,31 +1130,193 @@ func TestEditCappState(t *testing.T) {
 
 	type want struct {
 		statusCode int
+		eTag       string
 		response   map[string]interface{}
 	}
 
 	cases := map[string]struct {
 		requestURI  requestURI
 		want        want
-		requestData interface{}
+		contentType string
+		ifMatch     string
+		requestData string
 	}{
-		"ShouldSucceedEditingState": {
+		"ShouldSucceedMergePatchingCapp": {
 			requestURI: requestURI{
-				name:      testutils.CappName,
+				name:      testutils.CappName + "-merge",
 				namespace: testNamespaceName,
 			},
 			want: want{
 				statusCode: http.StatusOK,
+				eTag:       `"2"`,
 				response: map[string]interface{}{
-					testutils.NameKey:  testutils.CappName,
-					testutils.StateKey: testutils.DisabledState,
+					testutils.MetadataKey:    types.Metadata{Name: testutils.CappName + "-merge", Namespace: testNamespaceName},
+					testutils.LabelsKey:      []types.KeyValue{{Key: testutils.LabelKey + "-patched", Value: testutils.LabelValue + "-patched"}},
+					testutils.AnnotationsKey: nil,
+					testutils.SpecKey:        mocks.PrepareCappSpec(),
+					testutils.StatusKey:      mocks.PrepareCappStatus(testutils.CappName+"-merge", testNamespaceName, testutils.Domain),
 				},
 			},
-			requestData: types.CappState{State: testutils.DisabledState},
+			contentType: testutils.ApplicationMergePatchJson,
+			ifMatch:     `"0", W/"1"`,
+			requestData: fmt.Sprintf(`{"metadata":{"labels":{%q:%q}}}`, testutils.LabelKey+"-patched", testutils.LabelValue+"-patched"),
 		},
-		"ShouldHandleNotFoundCapp": {
+		"ShouldSucceedJSONPatchingCapp": {
 			requestURI: requestURI{
-				name:      testutils.CappName + testutils.NonExistentSuffix,
+				name:      testutils.CappName + "-json",
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				eTag:       `"2"`,
+				response: map[string]interface{}{
+					testutils.MetadataKey:    types.Metadata{Name: testutils.CappName + "-json", Namespace: testNamespaceName},
+					testutils.LabelsKey:      nil,
+					testutils.AnnotationsKey: nil,
+					testutils.SpecKey:        mocks.PrepareCappSpecWithState(testutils.DisabledState),
+					testutils.StatusKey:      mocks.PrepareCappStatus(testutils.CappName+"-json", testNamespaceName, testutils.Domain),
+				},
+			},
+			contentType: testutils.ApplicationJsonPatchJson,
+			requestData: fmt.Sprintf(`[{"op":"replace","path":"/spec/state","value":%q}]`, testutils.DisabledState),
+		},
+		"ShouldHandleResourceVersionConflict": {
+			requestURI: requestURI{
+				name:      testutils.CappName,
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusConflict,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(controllers.ErrCappVersionConflict, testutils.CappName, testNamespaceName, "1"),
+					testutils.ReasonKey: metav1.StatusReasonConflict,
+				},
+			},
+			contentType: testutils.ApplicationMergePatchJson,
+			ifMatch:     `W/"999"`,
+			requestData: `{"spec":{"state":"disabled"}}`,
+		},
+		"ShouldHandleResourceVersionConflictWithETagList": {
+			requestURI: requestURI{
+				name:      testutils.CappName,
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusConflict,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(controllers.ErrCappVersionConflict, testutils.CappName, testNamespaceName, "1"),
+					testutils.ReasonKey: metav1.StatusReasonConflict,
+				},
+			},
+			contentType: testutils.ApplicationMergePatchJson,
+			ifMatch:     `"998", "999"`,
+			requestData: `{"spec":{"state":"disabled"}}`,
+		},
+		"ShouldHandleUnsupportedPatchType": {
+			requestURI: requestURI{
+				name:      testutils.CappName,
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey: fmt.Sprintf(controllers.ErrUnsupportedPatchType, testutils.ApplicationJson,
+						testutils.ApplicationMergePatchJson, testutils.ApplicationJsonPatchJson),
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+			contentType: testutils.ApplicationJson,
+			requestData: `{"spec":{"state":"disabled"}}`,
+		},
+		"ShouldHandleNotFoundCapp": {
+			requestURI: requestURI{
+				name:      testutils.CappName + testutils.NonExistentSuffix,
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey: fmt.Sprintf("%v, %v",
+						fmt.Sprintf(controllers.ErrCouldNotGetCapp, testutils.CappName+testutils.NonExistentSuffix, testNamespaceName),
+						fmt.Sprintf("%s.%s %q not found", testutils.CappsKey, cappv1alpha1.GroupVersion.Group, testutils.CappName+testutils.NonExistentSuffix)),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+			contentType: testutils.ApplicationMergePatchJson,
+			requestData: `{"spec":{"state":"disabled"}}`,
+		},
+	}
+
+	setup()
+	mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespaceName, testutils.Domain, nil, nil)
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-merge", testNamespaceName, testutils.Domain, nil, nil)
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-json", testNamespaceName, testutils.Domain, nil, nil)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s", test.requestURI.namespace, test.requestURI.name)
+			request, err := http.NewRequest(http.MethodPatch, baseURI, bytes.NewBufferString(test.requestData))
+			assert.NoError(t, err)
+			request.Header.Set(testutils.ContentType, test.contentType)
+			if test.ifMatch != "" {
+				request.Header.Set("If-Match", test.ifMatch)
+			}
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+			assert.Equal(t, test.want.eTag, writer.Header().Get("ETag"))
+
+			var response map[string]interface{}
+			err = json.Unmarshal(writer.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			wantResponseJSON, err := json.Marshal(test.want.response)
+			assert.NoError(t, err)
+			var wantResponseNormalized map[string]interface{}
+			err = json.Unmarshal(wantResponseJSON, &wantResponseNormalized)
+			assert.NoError(t, err)
+			assert.Equal(t, wantResponseNormalized, response)
+		})
+	}
+}
+
+func TestEditCappState(t *testing.T) {
+	testNamespaceName := testutils.CappNamespace + "-update"
+
+	type requestURI struct {
+		name      string
+		namespace string
+	}
+
+	type want struct {
+		statusCode int
+		response   map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		requestURI  requestURI
+		want        want
+		requestData interface{}
+	}{
+		"ShouldSucceedEditingState": {
+			requestURI: requestURI{
+				name:      testutils.CappName,
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.NameKey:  testutils.CappName,
+					testutils.StateKey: testutils.DisabledState,
+				},
+			},
+			requestData: types.CappState{State: testutils.DisabledState},
+		},
+		"ShouldHandleNotFoundCapp": {
+			requestURI: requestURI{
+				name:      testutils.CappName + testutils.NonExistentSuffix,
 				namespace: testNamespaceName,
 			},
 			want: want{
@@ -962,3 +1482,416 @@ func TestDeleteCapp(t *testing.T) {
 		})
 	}
 }
+
+func TestRollbackCapp(t *testing.T) {
+	testNamespaceName := testutils.CappNamespace + "-rollback"
+
+	type requestURI struct {
+		name      string
+		namespace string
+	}
+
+	type want struct {
+		statusCode int
+		response   map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		requestURI  requestURI
+		want        want
+		requestData interface{}
+	}{
+		"ShouldSucceedRollingBackCapp": {
+			requestURI: requestURI{
+				name:      testutils.CappName,
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					"revisionName":   cappRevisionName + "-1",
+					"revisionNumber": 1,
+					"capp": types.Capp{
+						Metadata: types.Metadata{Name: testutils.CappName, Namespace: testNamespaceName},
+						Labels:   []types.KeyValue{{Key: testutils.LabelCappName, Value: testutils.CappName}},
+						Spec:     mocks.PrepareCappSpec(),
+						Status:   mocks.PrepareCappStatusWithHostname(testutils.CappName, testNamespaceName, testutils.Hostname, testutils.Domain),
+					},
+				},
+			},
+			requestData: types.RollbackCappRequest{RevisionName: cappRevisionName + "-1"},
+		},
+		"ShouldFailRollingBackWithoutRevision": {
+			requestURI: requestURI{
+				name:      testutils.CappName,
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  controllers.ErrNoRevisionSpecified,
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+			requestData: types.RollbackCappRequest{},
+		},
+		"ShouldHandleRevisionOfAnotherCapp": {
+			requestURI: requestURI{
+				name:      testutils.CappName,
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(controllers.ErrCappRevisionNotOfCapp, cappRevisionName+"-2", testutils.CappName, testNamespaceName),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+			requestData: types.RollbackCappRequest{RevisionName: cappRevisionName + "-2"},
+		},
+		"ShouldHandleNotFoundRevisionNumber": {
+			requestURI: requestURI{
+				name:      testutils.CappName,
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(controllers.ErrRevisionNotFound, 3, testutils.CappName, testNamespaceName),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+			requestData: types.RollbackCappRequest{RevisionNumber: 3},
+		},
+		"ShouldHandleNotFoundCapp": {
+			requestURI: requestURI{
+				name:      testutils.CappName + testutils.NonExistentSuffix,
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey: fmt.Sprintf("%v, %v",
+						fmt.Sprintf(controllers.ErrCouldNotGetCapp, testutils.CappName+testutils.NonExistentSuffix, testNamespaceName),
+						fmt.Sprintf("%s.%s %q not found", testutils.CappsKey, cappv1alpha1.GroupVersion.Group, testutils.CappName+testutils.NonExistentSuffix)),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+			requestData: types.RollbackCappRequest{RevisionNumber: 1},
+		},
+	}
+
+	setup()
+	mocks.CreateTestCappWithHostname(dynClient, testutils.CappName, testNamespaceName, testutils.Hostname, testutils.Domain, nil, nil)
+	mocks.CreateTestCappRevision(dynClient, cappRevisionName+"-1", testNamespaceName, map[string]string{testutils.LabelCappName: testutils.CappName}, nil)
+	mocks.CreateTestCappRevision(dynClient, cappRevisionName+"-2", testNamespaceName, map[string]string{testutils.LabelCappName: testutils.CappName + "-other"}, nil)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			payload, err := json.Marshal(test.requestData)
+			assert.NoError(t, err)
+
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/rollback", test.requestURI.namespace, test.requestURI.name)
+			request, err := http.NewRequest(http.MethodPost, baseURI, bytes.NewBuffer(payload))
+			assert.NoError(t, err)
+			request.Header.Set(testutils.ContentType, testutils.ApplicationJson)
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+
+			var response map[string]interface{}
+			err = json.Unmarshal(writer.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			wantResponseJSON, err := json.Marshal(test.want.response)
+			assert.NoError(t, err)
+			var wantResponseNormalized map[string]interface{}
+			err = json.Unmarshal(wantResponseJSON, &wantResponseNormalized)
+			assert.NoError(t, err)
+			assert.Equal(t, wantResponseNormalized, response)
+		})
+	}
+}
+
+func TestRestartCapp(t *testing.T) {
+	testNamespaceName := testutils.CappNamespace + "-restart"
+
+	type requestURI struct {
+		name      string
+		namespace string
+	}
+
+	type want struct {
+		statusCode int
+		response   map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		requestURI requestURI
+		want       want
+	}{
+		"ShouldFailRestartingDisabledCapp": {
+			requestURI: requestURI{
+				name:      testutils.CappName + "-disabled",
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(controllers.ErrCappDisabled, testutils.CappName+"-disabled", testNamespaceName),
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
+		"ShouldHandleNotFoundCapp": {
+			requestURI: requestURI{
+				name:      testutils.CappName + testutils.NonExistentSuffix,
+				namespace: testNamespaceName,
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey: fmt.Sprintf("%v, %v",
+						fmt.Sprintf(controllers.ErrCouldNotGetCapp, testutils.CappName+testutils.NonExistentSuffix, testNamespaceName),
+						fmt.Sprintf("%s.%s %q not found", testutils.CappsKey, cappv1alpha1.GroupVersion.Group, testutils.CappName+testutils.NonExistentSuffix)),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespaceName, testutils.Domain, nil, nil)
+	disabledCapp := mocks.PrepareCappWithState(testutils.CappName+"-disabled", testNamespaceName, testutils.DisabledState, nil, nil)
+	assert.NoError(t, dynClient.Create(context.TODO(), &disabledCapp))
+
+	t.Run("ShouldSucceedRestartingCapp", func(t *testing.T) {
+		baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/restart", testNamespaceName, testutils.CappName)
+		request, err := http.NewRequest(http.MethodPost, baseURI, nil)
+		assert.NoError(t, err)
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, request)
+
+		assert.Equal(t, http.StatusOK, writer.Code)
+
+		var response types.RestartCappResponse
+		assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+		assert.NotEmpty(t, response.RestartedAt)
+		assert.Equal(t, testutils.CappName, response.Capp.Metadata.Name)
+		assert.Contains(t, response.Capp.Annotations, types.KeyValue{Key: "rcs.dana.io/restartedAt", Value: response.RestartedAt})
+	})
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/restart", test.requestURI.namespace, test.requestURI.name)
+			request, err := http.NewRequest(http.MethodPost, baseURI, nil)
+			assert.NoError(t, err)
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+
+			var response map[string]interface{}
+			err = json.Unmarshal(writer.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			wantResponseJSON, err := json.Marshal(test.want.response)
+			assert.NoError(t, err)
+			var wantResponseNormalized map[string]interface{}
+			err = json.Unmarshal(wantResponseJSON, &wantResponseNormalized)
+			assert.NoError(t, err)
+			assert.Equal(t, wantResponseNormalized, response)
+		})
+	}
+}
+
+func TestDeployCapp(t *testing.T) {
+	testNamespaceName := testutils.CappNamespace + "-deploy"
+
+	type want struct {
+		statusCode int
+		response   map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		cappName    string
+		requestData interface{}
+		want        want
+	}{
+		"ShouldFailDeployingWithoutImage": {
+			cappName:    testutils.CappName,
+			requestData: types.DeployCappRequest{ContainerName: testutils.ContainerName},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  "Key: 'DeployCappRequest.Image' Error:Field validation for 'Image' failed on the 'required' tag",
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
+		"ShouldFailDeployingToNonExistingContainer": {
+			cappName:    testutils.CappName,
+			requestData: types.DeployCappRequest{Image: testutils.DeployImage, ContainerName: testutils.TestContainerName},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(controllers.ErrContainerNotInCapp, testutils.TestContainerName, testutils.CappName, testNamespaceName),
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
+		"ShouldHandleNotFoundCapp": {
+			cappName:    testutils.CappName + testutils.NonExistentSuffix,
+			requestData: types.DeployCappRequest{Image: testutils.DeployImage},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey: fmt.Sprintf("%v, %v",
+						fmt.Sprintf(controllers.ErrCouldNotGetCapp, testutils.CappName+testutils.NonExistentSuffix, testNamespaceName),
+						fmt.Sprintf("%s.%s %q not found", testutils.CappsKey, cappv1alpha1.GroupVersion.Group, testutils.CappName+testutils.NonExistentSuffix)),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespaceName, testutils.Domain, nil, nil)
+
+	t.Run("ShouldSucceedDeployingCapp", func(t *testing.T) {
+		payload, err := json.Marshal(types.DeployCappRequest{
+			Image:       testutils.DeployImage,
+			Env:         []types.KeyValue{{Key: testutils.EnvKey, Value: testutils.EnvValue}},
+			ChangeCause: testutils.ChangeCause,
+		})
+		assert.NoError(t, err)
+
+		baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/deploy", testNamespaceName, testutils.CappName)
+		request, err := http.NewRequest(http.MethodPost, baseURI, bytes.NewBuffer(payload))
+		assert.NoError(t, err)
+		request.Header.Set(testutils.ContentType, testutils.ApplicationJson)
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, request)
+
+		assert.Equal(t, http.StatusOK, writer.Code)
+
+		var response types.DeployCappResponse
+		assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+		assert.Equal(t, testutils.ChangeCause, response.ChangeCause)
+		assert.Equal(t, testutils.Username, response.DeployedBy)
+		assert.Contains(t, response.Capp.Annotations, types.KeyValue{Key: testutils.ChangeCauseAnnotation, Value: testutils.ChangeCause})
+
+		container := response.Capp.Spec.ConfigurationSpec.Template.Spec.Containers[0]
+		assert.Equal(t, testutils.DeployImage, container.Image)
+		assert.Equal(t, []corev1.EnvVar{{Name: testutils.EnvKey, Value: testutils.EnvValue}}, container.Env)
+	})
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			payload, err := json.Marshal(test.requestData)
+			assert.NoError(t, err)
+
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/deploy", testNamespaceName, test.cappName)
+			request, err := http.NewRequest(http.MethodPost, baseURI, bytes.NewBuffer(payload))
+			assert.NoError(t, err)
+			request.Header.Set(testutils.ContentType, testutils.ApplicationJson)
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+			assert.Equal(t, normalizeJSON(t, test.want.response), response)
+		})
+	}
+}
+
+func TestGetCappHistory(t *testing.T) {
+	testNamespaceName := testutils.CappNamespace + "-get-history"
+	previousRevisionName := testutils.CappRevisionName + "-v1"
+	latestRevisionName := testutils.CappRevisionName + "-v2"
+
+	type want struct {
+		statusCode int
+		response   map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		cappName string
+		want     want
+	}{
+		"ShouldSucceedGettingCappHistory": {
+			cappName: testutils.CappName,
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					"cappName": testutils.CappName,
+					"revisions": []types.CappHistoryEntry{
+						{
+							RevisionName:   latestRevisionName,
+							RevisionNumber: 2,
+							CreatedAt:      testutils.RevisionTimestamp,
+							Images:         []string{testutils.CappImage},
+							ChangeCause:    testutils.ChangeCause,
+							DeployedBy:     testutils.Username,
+							DeployedAt:     testutils.RevisionTimestamp,
+							Serving:        true,
+						},
+						{
+							RevisionName:   previousRevisionName,
+							RevisionNumber: 1,
+							CreatedAt:      testutils.RevisionTimestamp,
+							Images:         []string{testutils.Image},
+						},
+					},
+				},
+			},
+		},
+		"ShouldHandleNotFoundCapp": {
+			cappName: testutils.CappName + testutils.NonExistentSuffix,
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey: fmt.Sprintf("%v, %v",
+						fmt.Sprintf(controllers.ErrCouldNotGetCapp, testutils.CappName+testutils.NonExistentSuffix, testNamespaceName),
+						fmt.Sprintf("%s.%s %q not found", testutils.CappsKey, cappv1alpha1.GroupVersion.Group, testutils.CappName+testutils.NonExistentSuffix),
+					),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestCappWithServingRevision(dynClient, testutils.CappName, testNamespaceName, testutils.Domain, testutils.CappName+"-00002")
+	mocks.CreateTestCappRevisionOfCapp(dynClient, previousRevisionName, testNamespaceName, testutils.CappName, testutils.Image, 1, nil)
+	mocks.CreateTestCappRevisionOfCapp(dynClient, latestRevisionName, testNamespaceName, testutils.CappName, testutils.CappImage, 2, map[string]string{
+		testutils.ChangeCauseAnnotation: testutils.ChangeCause,
+		testutils.DeployedByAnnotation:  testutils.Username,
+		testutils.DeployedAtAnnotation:  testutils.RevisionTimestamp,
+	})
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/history", testNamespaceName, test.cappName)
+			request, err := http.NewRequest(http.MethodGet, baseURI, nil)
+			assert.NoError(t, err)
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+			assert.Equal(t, normalizeJSON(t, test.want.response), response)
+		})
+	}
+}
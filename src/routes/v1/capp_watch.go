@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/controllers"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/routes"
+	"github.com/dana-team/platform-backend/src/types"
+	websocketpkg "github.com/dana-team/platform-backend/src/websocket"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/watch"
+	"net/http"
+	"time"
+)
+
+const watchWriteWait = 10 * time.Second
+
+// WatchCapps returns a handler function that streams events of all Capps in a namespace over a WebSocket.
+func WatchCapps() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappNamespaceUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		var cappQuery types.CappQuery
+		if err := c.BindQuery(&cappQuery); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		cappWatchHandler(func(controller controllers.CappController) (<-chan types.CappWatchEvent, error) {
+			return controller.WatchCapps(cappUri.NamespaceName, cappQuery)
+		})(c)
+	}
+}
+
+// WatchCapp returns a handler function that streams events of a specific Capp over a WebSocket.
+func WatchCapp() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		cappWatchHandler(func(controller controllers.CappController) (<-chan types.CappWatchEvent, error) {
+			return controller.WatchCapp(cappUri.NamespaceName, cappUri.CappName)
+		})(c)
+	}
+}
+
+// cappWatchHandler opens a watch using the provided watch function and writes every event as
+// a JSON message to the WebSocket. The watch is stopped once the client closes the connection.
+func cappWatchHandler(watchFunc func(controller controllers.CappController) (<-chan types.CappWatchEvent, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !websocket.IsWebSocketUpgrade(c.Request) {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		kubeClient, err := middleware.GetDynClient(c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		logger, err := middleware.GetLogger(c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		ctx, cancel := context.WithCancel(routes.GetContext(c))
+		defer cancel()
+
+		cappController := controllers.NewCappController(kubeClient, ctx, logger)
+		events, err := watchFunc(cappController)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		websocketClient := websocketpkg.NewWebSocket(nil)
+		conn, err := websocketClient.Register(c)
+		if err != nil {
+			logger.Error(fmt.Sprintf("error watching capps: %v", err.Error()))
+			return
+		}
+		defer conn.Close()
+
+		// The client is not expected to send messages, reading is only needed to handle pongs and detect a closed connection.
+		websocketpkg.KeepAlive(ctx, conn, cancel, nil)
+		go func() {
+			defer cancel()
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		writeCappWatchEvents(ctx, conn, events, logger)
+	}
+}
+
+// writeCappWatchEvents writes every event as a JSON message to the WebSocket until the events channel is closed.
+// A close frame is sent once the watch ends, with the error as its reason if the watch failed.
+func writeCappWatchEvents(ctx context.Context, conn *websocket.Conn, events <-chan types.CappWatchEvent, logger *zap.Logger) {
+	for event := range events {
+		_ = conn.SetWriteDeadline(time.Now().Add(watchWriteWait))
+		if err := conn.WriteJSON(event); err != nil {
+			logger.Debug(fmt.Sprintf("error writing capp event to WebSocket: %v", err.Error()))
+			return
+		}
+
+		if event.Type == string(watch.Error) {
+			websocketpkg.CloseWithError(conn, errors.New(event.Error), nil)
+			return
+		}
+	}
+
+	if ctx.Err() != nil {
+		websocketpkg.CloseWithReason(conn, websocket.CloseGoingAway, websocketpkg.CloseReasonStreamCancelled)
+		return
+	}
+
+	websocketpkg.CloseWithReason(conn, websocket.CloseNormalClosure, websocketpkg.CloseReasonStreamEnded)
+}
@@ -0,0 +1,229 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	websocketpkg "github.com/dana-team/platform-backend/src/websocket"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testNamespaceWatchCapps = testutils.CappNamespace + "-watch"
+	testNamespaceWatchCapp  = testutils.CappNamespace + "-watch-one"
+
+	watchTimeout = 5 * time.Second
+)
+
+func TestWatchCapps(t *testing.T) {
+	type args struct {
+		wsUrl  string
+		action func()
+	}
+	type want struct {
+		statusCode int
+		event      map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldStreamAddedEvent": {
+			args: args{
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/watch/capps", testNamespaceWatchCapps),
+				action: func() {
+					mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespaceWatchCapps, testutils.Domain, nil, nil)
+				},
+			},
+			want: want{
+				statusCode: http.StatusSwitchingProtocols,
+				event: map[string]interface{}{
+					"type":   watch.Added,
+					"object": mocks.PrepareCappSummary(testutils.CappName, testNamespaceWatchCapps),
+				},
+			},
+		},
+		"ShouldStreamModifiedEventOfCapp": {
+			args: args{
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/watch/capps/%s", testNamespaceWatchCapp, testutils.CappName),
+				action: func() {
+					capp := mocks.PrepareCapp(testutils.CappName, testNamespaceWatchCapp, testutils.Domain, map[string]string{testutils.LabelKey: testutils.LabelValue}, nil)
+					capp.ResourceVersion = "1"
+					if err := dynClient.Update(context.TODO(), &capp); err != nil {
+						panic(err)
+					}
+				},
+			},
+			want: want{
+				statusCode: http.StatusSwitchingProtocols,
+				event: map[string]interface{}{
+					"type": watch.Modified,
+					"object": types.Capp{
+						Metadata: types.Metadata{Name: testutils.CappName, Namespace: testNamespaceWatchCapp},
+						Labels:   []types.KeyValue{{Key: testutils.LabelKey, Value: testutils.LabelValue}},
+						Spec:     mocks.PrepareCappSpec(),
+						Status:   mocks.PrepareCappStatus(testutils.CappName, testNamespaceWatchCapp, testutils.Domain),
+					},
+				},
+			},
+		},
+		"ShouldFailWatchingNonExistingCapp": {
+			args: args{
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/watch/capps/%s", testNamespaceWatchCapp, testutils.CappName+testutils.NonExistentSuffix),
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		"ShouldFailWatchingCappsWithInvalidSelector": {
+			args: args{
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/watch/capps?labelSelector=%s", testNamespaceWatchCapps, testutils.InvalidLabelSelector),
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				event: map[string]interface{}{
+					testutils.ErrorKey:  "Could not parse labelSelector",
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespaceWatchCapp, testutils.Domain, nil, nil)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			token = "valid_token"
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + tc.args.wsUrl
+
+			headers := http.Header{}
+			headers.Add(authorizationHeader, token)
+			headers.Add(middleware.WebsocketTokenHeader, token)
+
+			conn, resp, err := websocket.DefaultDialer.Dial(wsURL, headers)
+			assert.Equal(t, tc.want.statusCode, resp.StatusCode)
+			if tc.want.statusCode != http.StatusSwitchingProtocols {
+				if tc.want.event != nil {
+					var response map[string]interface{}
+					assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+					assert.Equal(t, normalizeJSON(t, tc.want.event), response)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to dial WebSocket: %v", err)
+			}
+			defer conn.Close()
+
+			tc.args.action()
+
+			assert.NoError(t, conn.SetReadDeadline(time.Now().Add(watchTimeout)))
+			var event map[string]interface{}
+			if err := conn.ReadJSON(&event); err != nil {
+				t.Fatalf("Error reading event from WebSocket: %v", err)
+			}
+			assert.Equal(t, normalizeJSON(t, tc.want.event), event)
+		})
+	}
+}
+
+func TestWriteCappWatchEvents(t *testing.T) {
+	type want struct {
+		events []map[string]interface{}
+		code   int
+		reason string
+	}
+
+	cases := map[string]struct {
+		events []types.CappWatchEvent
+		want   want
+	}{
+		"ShouldCloseNormallyWhenWatchEnds": {
+			events: []types.CappWatchEvent{{Type: string(watch.Deleted)}},
+			want: want{
+				events: []map[string]interface{}{{"type": string(watch.Deleted)}},
+				code:   websocket.CloseNormalClosure,
+				reason: websocketpkg.CloseReasonStreamEnded,
+			},
+		},
+		"ShouldCloseWithErrorWhenWatchFails": {
+			events: []types.CappWatchEvent{
+				{Type: string(watch.Error), Error: "too old resource version"},
+				{Type: string(watch.Deleted)},
+			},
+			want: want{
+				events: []map[string]interface{}{{"type": string(watch.Error), "error": "too old resource version"}},
+				code:   websocket.CloseInternalServerErr,
+				reason: "too old resource version",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := websocketpkg.DefaultUpgrader().Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				events := make(chan types.CappWatchEvent, len(tc.events))
+				for _, event := range tc.events {
+					events <- event
+				}
+				close(events)
+
+				writeCappWatchEvents(context.Background(), conn, events, zap.NewNop())
+			}))
+			defer server.Close()
+
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+			if err != nil {
+				t.Fatalf("Failed to dial WebSocket: %v", err)
+			}
+			defer conn.Close()
+
+			for _, want := range tc.want.events {
+				assert.NoError(t, conn.SetReadDeadline(time.Now().Add(watchTimeout)))
+				var event map[string]interface{}
+				if err := conn.ReadJSON(&event); err != nil {
+					t.Fatalf("Error reading event from WebSocket: %v", err)
+				}
+				assert.Equal(t, want, event)
+			}
+			assertStreamClosed(t, conn, tc.want.code, tc.want.reason)
+		})
+	}
+}
+
+// normalizeJSON marshals and unmarshals the given value so that it can be compared to a decoded JSON response.
+func normalizeJSON(t *testing.T, value interface{}) map[string]interface{} {
+	valueJSON, err := json.Marshal(value)
+	assert.NoError(t, err)
+
+	var normalized map[string]interface{}
+	assert.NoError(t, json.Unmarshal(valueJSON, &normalized))
+
+	return normalized
+}
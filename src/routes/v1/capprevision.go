@@ -51,7 +51,7 @@ func GetCappRevisions() gin.HandlerFunc {
 		}
 
 		cappRevisionHandler(func(controller controllers.CappRevisionController, c *gin.Context) (interface{}, error) {
-			return controller.GetCappRevisions(cappRevisionUri.NamespaceName, limit, page, cappRevisionUri.CappName)
+			return controller.GetCappRevisions(cappRevisionUri.NamespaceName, limit, page, cappRevisionUri.CappName, pagination.ExtractListQueryFromCtx(c))
 		})(c)
 	}
 }
@@ -69,3 +69,23 @@ func GetCappRevision() gin.HandlerFunc {
 		})(c)
 	}
 }
+
+func GetCappRevisionDiff() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappRevisionUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		var diffQuery types.CappRevisionDiffQuery
+		if err := c.BindQuery(&diffQuery); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		cappRevisionHandler(func(controller controllers.CappRevisionController, c *gin.Context) (interface{}, error) {
+			return controller.GetCappRevisionDiff(cappUri.NamespaceName, cappUri.CappName, cappUri.CappRevisionName, diffQuery.CompareTo)
+		})(c)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/dana-team/platform-backend/src/controllers"
 	"github.com/dana-team/platform-backend/src/middleware"
 	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils"
 	"github.com/dana-team/platform-backend/src/utils/testutils"
 	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
 	"github.com/stretchr/testify/assert"
@@ -245,3 +246,125 @@ func TestGetCappRevision(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCappRevisionDiff(t *testing.T) {
+	testNamespaceName := cappRevisionNamespace + "-diff"
+
+	type requestURI struct {
+		name      string
+		namespace string
+		cappName  string
+		compareTo string
+	}
+
+	type want struct {
+		statusCode int
+		response   map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		requestURI requestURI
+		want       want
+	}{
+		"ShouldSucceedDiffingCappRevisions": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				name:      cappRevisionName + "-1",
+				cappName:  testutils.CappName + "-1",
+				compareTo: cappRevisionName + "-2",
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					"from": cappRevisionName + "-1",
+					"to":   cappRevisionName + "-2",
+					"changes": []types.FieldDiff{
+						{Path: "labels." + testutils.LabelKey, Type: utils.DiffAdded, NewValue: testutils.LabelValue},
+					},
+					testutils.CountKey: 1,
+				},
+			},
+		},
+		"ShouldSucceedDiffingCappRevisionWithLiveCapp": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				name:      cappRevisionName + "-1",
+				cappName:  testutils.CappName + "-1",
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					"from":             cappRevisionName + "-1",
+					"to":               fmt.Sprintf("live capp %q", testutils.CappName+"-1"),
+					"changes":          []types.FieldDiff{},
+					testutils.CountKey: 0,
+				},
+			},
+		},
+		"ShouldHandleCappRevisionOfAnotherCapp": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				name:      cappRevisionName + "-3",
+				cappName:  testutils.CappName + "-1",
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(controllers.ErrCappRevisionNotOfCapp, cappRevisionName+"-3", testutils.CappName+"-1", testNamespaceName),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+		},
+		"ShouldHandleNotFoundCappRevision": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				name:      cappRevisionName + testutils.NonExistentSuffix,
+				cappName:  testutils.CappName + "-1",
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey: fmt.Sprintf("%v, %v",
+						fmt.Sprintf(controllers.ErrCouldNotGetCappRevision, cappRevisionName+testutils.NonExistentSuffix, testNamespaceName),
+						fmt.Sprintf("%s.%s %q not found", capprevisionsKey, cappv1alpha1.GroupVersion.Group, cappRevisionName+testutils.NonExistentSuffix)),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestNamespace(fakeClient, testNamespaceName)
+	mocks.CreateTestCapp(dynClient, testutils.CappName+"-1", testNamespaceName, testutils.Domain, map[string]string{testutils.LabelCappName: testutils.CappName + "-1"}, nil)
+	mocks.CreateTestCappRevision(dynClient, cappRevisionName+"-1", testNamespaceName, map[string]string{testutils.LabelCappName: testutils.CappName + "-1"}, nil)
+	mocks.CreateTestCappRevision(dynClient, cappRevisionName+"-2", testNamespaceName, map[string]string{testutils.LabelCappName: testutils.CappName + "-1", testutils.LabelKey: testutils.LabelValue}, nil)
+	mocks.CreateTestCappRevision(dynClient, cappRevisionName+"-3", testNamespaceName, map[string]string{testutils.LabelCappName: testutils.CappName + "-2"}, nil)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			params := url.Values{}
+			if test.requestURI.compareTo != "" {
+				params.Add("compareTo", test.requestURI.compareTo)
+			}
+
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/capprevisions/%s/diff", test.requestURI.namespace, test.requestURI.cappName, test.requestURI.name)
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s?%s", baseURI, params.Encode()), nil)
+			assert.NoError(t, err)
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+
+			var response map[string]interface{}
+			err = json.Unmarshal(writer.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			wantResponseJSON, err := json.Marshal(test.want.response)
+			assert.NoError(t, err)
+			var wantResponseNormalized map[string]interface{}
+			err = json.Unmarshal(wantResponseJSON, &wantResponseNormalized)
+			assert.NoError(t, err)
+			assert.Equal(t, wantResponseNormalized, response)
+		})
+	}
+}
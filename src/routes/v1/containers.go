@@ -50,3 +50,18 @@ func GetPodsContainers() gin.HandlerFunc {
 		})(c)
 	}
 }
+
+// GetPodContainer returns a Gin handler function for retrieving the status and resources of a container.
+func GetPodContainer() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request types.ContainerUri
+		if err := c.BindUri(&request); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		containerHandler(func(controller controllers.ContainerController, c *gin.Context) (interface{}, error) {
+			return controller.GetContainer(request.NamespaceName, request.PodName, request.ContainerName)
+		})(c)
+	}
+}
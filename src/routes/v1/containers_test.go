@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/dana-team/platform-backend/src/types"
 	"github.com/dana-team/platform-backend/src/utils/testutils"
 	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,8 +45,45 @@ func TestGetContainer(t *testing.T) {
 				statusCode: http.StatusOK,
 				response: map[string]interface{}{
 					testutils.ContainersKey: []interface{}{
-						map[string]interface{}{testutils.ContainerNameKey: testutils.TestContainerName},
-						map[string]interface{}{testutils.ContainerNameKey: testutils.CappName},
+						map[string]interface{}{
+							testutils.ContainerNameKey: testutils.TestContainerName,
+							testutils.ReadyKey:         false,
+							testutils.RestartCountKey:  0,
+							testutils.StateKey:         types.ContainerStateWaiting,
+						},
+						map[string]interface{}{
+							testutils.ContainerNameKey: testutils.CappName,
+							testutils.ReadyKey:         false,
+							testutils.RestartCountKey:  0,
+							testutils.StateKey:         types.ContainerStateWaiting,
+						},
+					},
+					testutils.CountKey: 2,
+				},
+			},
+		},
+		"ShouldSucceedGettingInitContainers": {
+			args: args{
+				namespace: testNamespaceName,
+				podName:   pod3,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.ContainersKey: []interface{}{
+						map[string]interface{}{
+							testutils.ContainerNameKey: testutils.InitContainerName,
+							testutils.InitKey:          true,
+							testutils.ReadyKey:         true,
+							testutils.RestartCountKey:  0,
+							testutils.StateKey:         types.ContainerStateTerminated,
+						},
+						map[string]interface{}{
+							testutils.ContainerNameKey: testutils.CappName,
+							testutils.ReadyKey:         false,
+							testutils.RestartCountKey:  3,
+							testutils.StateKey:         types.ContainerStateWaiting,
+						},
 					},
 					testutils.CountKey: 2,
 				},
@@ -83,6 +121,7 @@ func TestGetContainer(t *testing.T) {
 	mocks.CreateTestNamespace(fakeClient, testNamespaceName)
 	mocks.CreateTestPod(fakeClient, testNamespaceName, pod1, "", false)
 	mocks.CreateTestPod(fakeClient, testNamespaceName, pod2, testutils.CappName, true)
+	mocks.CreateTestPodWithStatus(fakeClient, testNamespaceName, pod3, testutils.CappName)
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -107,3 +146,100 @@ func TestGetContainer(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPodContainer(t *testing.T) {
+	testNamespaceName := containerNamespace + "-getcontainer"
+
+	type args struct {
+		podName       string
+		containerName string
+	}
+
+	type want struct {
+		statusCode int
+		response   map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldSucceedGettingInitContainer": {
+			args: args{
+				podName:       pod1,
+				containerName: testutils.InitContainerName,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.ContainerNameKey: testutils.InitContainerName,
+					"image":                    testutils.Image,
+					"init":                     true,
+					"ready":                    true,
+					"restartCount":             0,
+					"state": map[string]interface{}{
+						"state":      types.ContainerStateTerminated,
+						"reason":     testutils.ReasonCompleted,
+						"exitCode":   0,
+						"startedAt":  testutils.PodStartTime,
+						"finishedAt": testutils.PodStartTime,
+					},
+					"resources": map[string]interface{}{},
+				},
+			},
+		},
+		"ShouldHandleNotFoundContainer": {
+			args: args{
+				podName:       pod1,
+				containerName: testutils.TestContainerName,
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf("container %q not found in the pod %q", testutils.TestContainerName, pod1),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+		},
+		"ShouldHandleNotFoundPod": {
+			args: args{
+				podName:       pod1 + testutils.NonExistentSuffix,
+				containerName: testutils.CappName,
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(failedToGetPodErr, pod1+testutils.NonExistentSuffix, testNamespaceName, pod1+testutils.NonExistentSuffix),
+					testutils.ReasonKey: metav1.StatusReasonNotFound,
+				},
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestNamespace(fakeClient, testNamespaceName)
+	mocks.CreateTestPodWithStatus(fakeClient, testNamespaceName, pod1, testutils.CappName)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/pods/%s/containers/%s", testNamespaceName, test.args.podName, test.args.containerName)
+			request, err := http.NewRequest(http.MethodGet, baseURI, nil)
+			assert.NoError(t, err)
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+
+			var response map[string]interface{}
+			err = json.Unmarshal(writer.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			wantResponseJSON, err := json.Marshal(test.want.response)
+			assert.NoError(t, err)
+			var wantResponseNormalized map[string]interface{}
+			err = json.Unmarshal(wantResponseJSON, &wantResponseNormalized)
+			assert.NoError(t, err)
+			assert.Equal(t, wantResponseNormalized, response)
+		})
+	}
+}
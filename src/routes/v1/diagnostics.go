@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"github.com/dana-team/platform-backend/src/controllers"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/routes"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GetCappDiagnostics returns a Gin handler function for diagnosing the health of a specific capp.
+func GetCappDiagnostics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		dynClient, err := middleware.GetDynClient(c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		kubeClient, err := middleware.GetKubeClient(c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		logger, err := middleware.GetLogger(c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		diagnosticsController := controllers.NewDiagnosticsController(dynClient, kubeClient, routes.GetContext(c), logger)
+		diagnostics, err := diagnosticsController.GetCappDiagnostics(cappUri.NamespaceName, cappUri.CappName)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, diagnostics)
+	}
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetCappDiagnostics(t *testing.T) {
+	testNamespaceName := testutils.CappNamespace + "-diagnostics"
+
+	type want struct {
+		statusCode int
+		reason     metav1.StatusReason
+	}
+
+	cases := map[string]struct {
+		cappName string
+		want     want
+	}{
+		"ShouldFailDiagnosingNonExistingCapp": {
+			cappName: testutils.CappName + testutils.NonExistentSuffix,
+			want: want{
+				statusCode: http.StatusNotFound,
+				reason:     metav1.StatusReasonNotFound,
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespaceName, testutils.Domain, nil, nil)
+	mocks.CreateTestPodWithStatus(fakeClient, testNamespaceName, pod1, testutils.CappName)
+
+	t.Run("ShouldSucceedDiagnosingCapp", func(t *testing.T) {
+		baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/diagnostics", testNamespaceName, testutils.CappName)
+		request, err := http.NewRequest(http.MethodGet, baseURI, nil)
+		assert.NoError(t, err)
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, request)
+
+		assert.Equal(t, http.StatusOK, writer.Code)
+
+		var response types.CappDiagnostics
+		assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+		assert.Equal(t, testutils.CappName, response.CappName)
+		assert.False(t, response.Healthy)
+		assert.NotEmpty(t, response.Findings)
+		assert.Equal(t, types.SeverityError, response.Findings[0].Severity)
+		assert.Equal(t, testutils.ReasonCrashLoopBackOff, response.Findings[0].Reason)
+	})
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/diagnostics", testNamespaceName, test.cappName)
+			request, err := http.NewRequest(http.MethodGet, baseURI, nil)
+			assert.NoError(t, err)
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+			assert.Equal(t, string(test.want.reason), response[testutils.ReasonKey])
+		})
+	}
+}
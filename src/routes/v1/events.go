@@ -0,0 +1,192 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/controllers"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/routes"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils/pagination"
+	websocketpkg "github.com/dana-team/platform-backend/src/websocket"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"net/http"
+)
+
+// eventHandler wraps a handler function with context setup for EventController.
+func eventHandler(handler func(controller controllers.EventController, c *gin.Context) (interface{}, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		kubeClient, err := middleware.GetKubeClient(c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		logger, err := middleware.GetLogger(c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		context := routes.GetContext(c)
+		eventController := controllers.NewEventController(kubeClient, context, logger)
+
+		result, err := handler(eventController, c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetNamespaceEvents returns a Gin handler function for retrieving all events in a namespace.
+func GetNamespaceEvents() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request types.CappNamespaceUri
+		if err := c.BindUri(&request); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		var query types.EventQuery
+		if err := c.BindQuery(&query); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		limit, page, err := pagination.ExtractPaginationParamsFromCtx(c)
+		if err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		eventHandler(func(controller controllers.EventController, c *gin.Context) (interface{}, error) {
+			return controller.GetNamespaceEvents(request.NamespaceName, limit, page, query, pagination.ExtractListQueryFromCtx(c))
+		})(c)
+	}
+}
+
+// GetCappEvents returns a Gin handler function for retrieving the events of a specific capp.
+func GetCappEvents() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request types.CappUri
+		if err := c.BindUri(&request); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		var query types.EventQuery
+		if err := c.BindQuery(&query); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		limit, page, err := pagination.ExtractPaginationParamsFromCtx(c)
+		if err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		eventHandler(func(controller controllers.EventController, c *gin.Context) (interface{}, error) {
+			return controller.GetCappEvents(request.NamespaceName, request.CappName, limit, page, query, pagination.ExtractListQueryFromCtx(c))
+		})(c)
+	}
+}
+
+// WatchNamespaceEvents returns a handler function that streams all events in a namespace over a WebSocket.
+func WatchNamespaceEvents() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request types.CappNamespaceUri
+		if err := c.BindUri(&request); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		var query types.EventQuery
+		if err := c.BindQuery(&query); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		eventWatchHandler(func(controller controllers.EventController) (<-chan types.EventWatchEvent, error) {
+			return controller.WatchNamespaceEvents(request.NamespaceName, query)
+		})(c)
+	}
+}
+
+// WatchCappEvents returns a handler function that streams the events of a specific capp over a WebSocket.
+func WatchCappEvents() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request types.CappUri
+		if err := c.BindUri(&request); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		var query types.EventQuery
+		if err := c.BindQuery(&query); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		eventWatchHandler(func(controller controllers.EventController) (<-chan types.EventWatchEvent, error) {
+			return controller.WatchCappEvents(request.NamespaceName, request.CappName, query)
+		})(c)
+	}
+}
+
+// eventWatchHandler opens a watch using the provided watch function and writes every event as
+// a JSON message to the WebSocket. The watch is stopped once the client closes the connection.
+func eventWatchHandler(watchFunc func(controller controllers.EventController) (<-chan types.EventWatchEvent, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !websocket.IsWebSocketUpgrade(c.Request) {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		kubeClient, err := middleware.GetKubeClient(c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		logger, err := middleware.GetLogger(c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		ctx, cancel := context.WithCancel(routes.GetContext(c))
+		defer cancel()
+
+		eventController := controllers.NewEventController(kubeClient, ctx, logger)
+		events, err := watchFunc(eventController)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		websocketClient := websocketpkg.NewWebSocket(nil)
+		conn, err := websocketClient.Register(c)
+		if err != nil {
+			logger.Error(fmt.Sprintf("error watching events: %v", err.Error()))
+			return
+		}
+		defer conn.Close()
+
+		// The client is not expected to send messages, reading is only needed to detect a closed connection.
+		go func() {
+			defer cancel()
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		for event := range events {
+			if err := conn.WriteJSON(event); err != nil {
+				logger.Debug(fmt.Sprintf("error writing event to WebSocket: %v", err.Error()))
+				return
+			}
+		}
+	}
+}
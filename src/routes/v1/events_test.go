@@ -0,0 +1,248 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils/pagination"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	"github.com/gorilla/websocket"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testNamespaceEvents      = testutils.TestNamespace + "-events"
+	testNamespaceWatchEvents = testutils.TestNamespace + "-watch-events"
+
+	cappEventName     = testutils.EventName + "-capp"
+	podEventName      = testutils.EventName + "-pod"
+	otherPodEventName = testutils.EventName + "-other-pod"
+
+	eventTypeWarningQuery = "?type=" + corev1.EventTypeWarning
+)
+
+func TestGetEvents(t *testing.T) {
+	type want struct {
+		statusCode int
+		response   map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		url  string
+		want want
+	}{
+		"ShouldGetNamespaceEvents": {
+			url: fmt.Sprintf("/v1/namespaces/%s/events", testNamespaceEvents),
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.EventsKey: []string{otherPodEventName, podEventName, cappEventName},
+					testutils.CountKey:  3,
+				},
+			},
+		},
+		"ShouldGetWarningNamespaceEvents": {
+			url: fmt.Sprintf("/v1/namespaces/%s/events%s", testNamespaceEvents, eventTypeWarningQuery),
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.EventsKey: []string{otherPodEventName, podEventName},
+					testutils.CountKey:  2,
+				},
+			},
+		},
+		"ShouldGetCappEvents": {
+			url: fmt.Sprintf("/v1/namespaces/%s/capps/%s/events", testNamespaceEvents, testutils.CappName),
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.EventsKey: []string{podEventName, cappEventName},
+					testutils.CountKey:  2,
+				},
+			},
+		},
+		"ShouldGetCappEventsWithLimit": {
+			url: fmt.Sprintf("/v1/namespaces/%s/capps/%s/events?limit=1&page=2", testNamespaceEvents, testutils.CappName),
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.EventsKey: []string{cappEventName},
+					testutils.CountKey:  1,
+				},
+			},
+		},
+		"ShouldGetCappEventsInCluster": {
+			url: fmt.Sprintf("/v1/clusters/%s/namespaces/%s/capps/%s/events%s", cluster, testNamespaceEvents, testutils.CappName, eventTypeWarningQuery),
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.EventsKey: []string{podEventName},
+					testutils.CountKey:  1,
+				},
+			},
+		},
+		"ShouldGetNamespaceEventsSortedByName": {
+			url: fmt.Sprintf("/v1/namespaces/%s/events?sortBy=name", testNamespaceEvents),
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.EventsKey: []string{cappEventName, otherPodEventName, podEventName},
+					testutils.CountKey:  3,
+				},
+			},
+		},
+		"ShouldSearchCappEvents": {
+			url: fmt.Sprintf("/v1/namespaces/%s/capps/%s/events?search=POD", testNamespaceEvents, testutils.CappName),
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.EventsKey: []string{podEventName},
+					testutils.CountKey:  1,
+				},
+			},
+		},
+		"ShouldFilterNamespaceEventsByFieldSelector": {
+			url: fmt.Sprintf("/v1/namespaces/%s/events?fieldSelector=involvedObject.kind=Pod", testNamespaceEvents),
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.EventsKey: []string{otherPodEventName, podEventName},
+					testutils.CountKey:  2,
+				},
+			},
+		},
+		"ShouldFailWithContinue": {
+			url: fmt.Sprintf("/v1/namespaces/%s/events?continue=token", testNamespaceEvents),
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  pagination.ErrContinueWithSortedList,
+					testutils.ReasonKey: testutils.ReasonBadRequest,
+				},
+			},
+		},
+		"ShouldFailWithInvalidType": {
+			url: fmt.Sprintf("/v1/namespaces/%s/capps/%s/events?type=Error", testNamespaceEvents, testutils.CappName),
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  "Key: 'EventQuery.Type' Error:Field validation for 'Type' failed on the 'oneof' tag",
+					testutils.ReasonKey: testutils.ReasonBadRequest,
+				},
+			},
+		},
+	}
+
+	setup()
+	eventTime, _ := time.Parse(time.RFC3339, testutils.EventTimestamp)
+	mocks.CreateTestNamespace(fakeClient, testNamespaceEvents)
+	mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespaceEvents, testutils.Domain, nil, nil)
+	mocks.CreateTestPod(fakeClient, testNamespaceEvents, pod1, testutils.CappName, false)
+	mocks.CreateTestPod(fakeClient, testNamespaceEvents, pod2, "", false)
+	mocks.CreateTestEvent(fakeClient, testNamespaceEvents, cappEventName, "Capp", testutils.CappName, corev1.EventTypeNormal, testutils.EventReasonCreated, eventTime)
+	mocks.CreateTestEvent(fakeClient, testNamespaceEvents, podEventName, "Pod", pod1, corev1.EventTypeWarning, testutils.EventReasonBackOff, eventTime.Add(time.Minute))
+	mocks.CreateTestEvent(fakeClient, testNamespaceEvents, otherPodEventName, "Pod", pod2, corev1.EventTypeWarning, testutils.EventReasonBackOff, eventTime.Add(2*time.Minute))
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodGet, test.url, nil)
+			assert.NoError(t, err)
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+			if test.want.statusCode != http.StatusOK {
+				var response map[string]interface{}
+				assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+				assert.Equal(t, normalizeJSON(t, test.want.response), response)
+				return
+			}
+
+			var response types.EventList
+			assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+			var names []string
+			for _, event := range response.Events {
+				names = append(names, event.Name)
+			}
+			assert.Equal(t, test.want.response[testutils.EventsKey], names)
+			assert.Equal(t, test.want.response[testutils.CountKey], response.Count)
+		})
+	}
+}
+
+func TestWatchEvents(t *testing.T) {
+	eventTime, _ := time.Parse(time.RFC3339, testutils.EventTimestamp)
+	expectedEvent := types.Event{
+		Name:           podEventName,
+		Type:           corev1.EventTypeWarning,
+		Reason:         testutils.EventReasonBackOff,
+		Message:        testutils.EventReasonBackOff,
+		InvolvedObject: types.InvolvedObject{Kind: "Pod", Name: pod1},
+		Source:         "kubelet",
+		Count:          1,
+		FirstTimestamp: testutils.EventTimestamp,
+		LastTimestamp:  testutils.EventTimestamp,
+	}
+
+	cases := map[string]struct {
+		url string
+	}{
+		"ShouldStreamNamespaceEvents": {
+			url: fmt.Sprintf("/v1/namespaces/%s/watch/events%s", testNamespaceWatchEvents, eventTypeWarningQuery),
+		},
+		"ShouldStreamCappEvents": {
+			url: fmt.Sprintf("/v1/namespaces/%s/watch/capps/%s/events", testNamespaceWatchEvents, testutils.CappName),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			setup()
+			mocks.CreateTestPod(fakeClient, testNamespaceWatchEvents, pod1, testutils.CappName, false)
+
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + tc.url
+			headers := http.Header{}
+			headers.Add(middleware.WebsocketTokenHeader, "valid_token")
+
+			conn, resp, err := websocket.DefaultDialer.Dial(wsURL, headers)
+			if err != nil {
+				t.Fatalf("Failed to dial WebSocket: %v", err)
+			}
+			defer conn.Close()
+			assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+			mocks.CreateTestEvent(fakeClient, testNamespaceWatchEvents, cappEventName, "Capp", testutils.CappName+testutils.NonExistentSuffix, corev1.EventTypeNormal, testutils.EventReasonCreated, eventTime)
+			mocks.CreateTestEvent(fakeClient, testNamespaceWatchEvents, podEventName, "Pod", pod1, corev1.EventTypeWarning, testutils.EventReasonBackOff, eventTime)
+
+			assert.NoError(t, conn.SetReadDeadline(time.Now().Add(watchTimeout)))
+			var event map[string]interface{}
+			if err := conn.ReadJSON(&event); err != nil {
+				t.Fatalf("Error reading event from WebSocket: %v", err)
+			}
+			assert.Equal(t, normalizeJSON(t, types.EventWatchEvent{Type: string(watch.Added), Object: &expectedEvent}), event)
+		})
+	}
+}
+
+func TestWatchEventsNotWebSocket(t *testing.T) {
+	setup()
+
+	writer := httptest.NewRecorder()
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/namespaces/%s/watch/events", testNamespaceWatchEvents), nil)
+	router.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
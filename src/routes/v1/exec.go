@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/controllers"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/routes"
+	"github.com/dana-team/platform-backend/src/types"
+	websocketpkg "github.com/dana-team/platform-backend/src/websocket"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"k8s.io/client-go/tools/remotecommand"
+	"net/http"
+)
+
+const (
+	errExecNotWebSocket = "exec sessions can only be opened over a WebSocket"
+	closeReasonExited   = "command exited"
+)
+
+// ExecInContainer returns a handler function that opens an interactive exec session in a container of a pod.
+// The connection is upgraded to a WebSocket which carries the stdin, stdout, stderr and terminal resize messages
+// of the session, and is closed once the command exits or the client goes away.
+func ExecInContainer() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !websocket.IsWebSocketUpgrade(c.Request) {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(errExecNotWebSocket))
+			return
+		}
+
+		logger, err := middleware.GetLogger(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("error getting logger %s", err.Error())})
+			return
+		}
+
+		websocketClient := websocketpkg.NewWebSocket(nil)
+		conn, err := websocketClient.Register(c)
+		if err != nil {
+			logger.Error(fmt.Sprintf("error opening exec session: %v", err.Error()))
+			return
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithCancel(routes.GetContext(c))
+		defer cancel()
+
+		var uri types.ContainerUri
+		if err := c.ShouldBindUri(&uri); err != nil {
+			websocketpkg.CloseWithError(conn, customerrors.NewValidationError(err.Error()), nil)
+			return
+		}
+
+		var query types.ExecQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			websocketpkg.CloseWithError(conn, customerrors.NewValidationError(err.Error()), nil)
+			return
+		}
+
+		kubeClient, err := middleware.GetKubeClient(c)
+		if err != nil {
+			websocketpkg.CloseWithError(conn, err, nil)
+			return
+		}
+
+		config, err := middleware.GetRestConfig(c)
+		if err != nil {
+			websocketpkg.CloseWithError(conn, err, nil)
+			return
+		}
+
+		tty := query.TTY == nil || *query.TTY
+		session := websocketpkg.NewTerminalSession(ctx, conn, cancel, nil)
+		streams := remotecommand.StreamOptions{
+			Stdin:  session,
+			Stdout: session.Stdout(),
+			Stderr: session.Stderr(),
+		}
+		if tty {
+			streams.TerminalSizeQueue = session
+		}
+
+		exitCode, err := controllers.ExecInContainer(ctx, kubeClient, config, uri.NamespaceName, uri.PodName, uri.ContainerName, query.Command, tty, streams, logger)
+		if err != nil {
+			websocketpkg.CloseWithError(conn, err, nil)
+			return
+		}
+
+		if ctx.Err() != nil {
+			websocketpkg.CloseWithReason(conn, websocket.CloseGoingAway, websocketpkg.CloseReasonStreamCancelled)
+			return
+		}
+
+		_ = session.SendExit(exitCode)
+		websocketpkg.CloseWithReason(conn, websocket.CloseNormalClosure, closeReasonExited)
+	}
+}
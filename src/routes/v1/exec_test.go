@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	testNamespaceExec = testutils.TestNamespace + "-Test_ExecInContainer"
+)
+
+func Test_ExecInContainer(t *testing.T) {
+	type args struct {
+		url string
+	}
+	type want struct {
+		closeCode   int
+		closeReason string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldFailWhenAPIServerIsUnreachable": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/pods/%s/containers/%s/exec?command=ls&tty=false", testNamespaceExec, pod1, testutils.TestContainerName),
+			},
+			want: want{
+				closeCode:   websocket.CloseInternalServerErr,
+				closeReason: "error executing command in container",
+			},
+		},
+		"ShouldFailWithNonExistingPod": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/pods/%s/containers/%s/exec", testNamespaceExec, "test-invalid-pod", testutils.TestContainerName),
+			},
+			want: want{
+				closeCode:   websocket.ClosePolicyViolation,
+				closeReason: fmt.Sprintf("failed to get pod %q", "test-invalid-pod"),
+			},
+		},
+		"ShouldFailWithNonExistingContainer": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/pods/%s/containers/%s/exec", testNamespaceExec, pod1, testutils.TestContainerName+testutils.NonExistentSuffix),
+			},
+			want: want{
+				closeCode:   websocket.ClosePolicyViolation,
+				closeReason: fmt.Sprintf("container %q not found in the pod %q", testutils.TestContainerName+testutils.NonExistentSuffix, pod1),
+			},
+		},
+		"ShouldFailInClusterWithNonExistingContainer": {
+			args: args{
+				url: fmt.Sprintf("/v1/clusters/%s/namespaces/%s/pods/%s/containers/%s/exec", cluster, testNamespaceExec, pod1, testutils.TestContainerName+testutils.NonExistentSuffix),
+			},
+			want: want{
+				closeCode:   websocket.ClosePolicyViolation,
+				closeReason: fmt.Sprintf("container %q not found in the pod %q", testutils.TestContainerName+testutils.NonExistentSuffix, pod1),
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestNamespace(fakeClient, testNamespaceExec)
+	mocks.CreateTestPod(fakeClient, testNamespaceExec, pod1, "", false)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + tc.args.url
+			headers := http.Header{}
+			headers.Add(middleware.WebsocketTokenHeader, "valid_token")
+
+			conn, resp, err := websocket.DefaultDialer.Dial(wsURL, headers)
+			if err != nil {
+				t.Fatalf("Failed to dial WebSocket: %v", err)
+			}
+			defer conn.Close()
+			assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+			assertStreamClosed(t, conn, tc.want.closeCode, tc.want.closeReason)
+		})
+	}
+}
+
+func Test_ExecInContainerNotWebSocket(t *testing.T) {
+	setup()
+	mocks.CreateTestNamespace(fakeClient, testNamespaceExec)
+	mocks.CreateTestPod(fakeClient, testNamespaceExec, pod1, "", false)
+
+	writer := httptest.NewRecorder()
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/namespaces/%s/pods/%s/containers/%s/exec", testNamespaceExec, pod1, testutils.TestContainerName), nil)
+	router.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+
+	response := map[string]string{}
+	assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+	assert.Equal(t, errExecNotWebSocket, response[testutils.ErrorKey])
+}
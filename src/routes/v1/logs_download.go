@@ -0,0 +1,220 @@
+package v1
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/routes"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	acceptHeader             = "Accept"
+	contentTypeHeader        = "Content-Type"
+	acceptEncodingHeader     = "Accept-Encoding"
+	contentEncodingHeader    = "Content-Encoding"
+	contentDispositionHeader = "Content-Disposition"
+	cacheControlHeader       = "Cache-Control"
+	varyHeader               = "Vary"
+
+	eventStreamContentType = "text/event-stream"
+	plainTextContentType   = "text/plain; charset=utf-8"
+	gzipEncoding           = "gzip"
+	noCache                = "no-cache"
+	attachmentDisposition  = "attachment; filename=%q"
+	logFileExtension       = ".log"
+
+	logEvent            = "log"
+	endEvent            = "end"
+	errorEvent          = "error"
+	streamEndedMessage  = "stream ended"
+	keepAliveComment    = ": keep-alive\n\n"
+	eventsKeepAlive     = 30 * time.Second
+	logsDownloadBufSize = 32 * 1024
+	maxEventLineSize    = 1024 * 1024
+)
+
+// serveHTTPLogs serves the logs over plain HTTP. Clients accepting "text/event-stream" receive the logs as
+// Server-Sent Events, and any other client downloads them as a chunked text/plain ".log" attachment.
+// When the json format is requested, every line is sent as a JSON log frame.
+func serveHTTPLogs(c *gin.Context, streamFunc logStreamFunc, paramKey, logPrefix string) {
+	logger, err := middleware.GetLogger(c)
+	if middleware.AddErrorToContext(c, err) {
+		return
+	}
+
+	logOptions, err := bindLogOptions(c)
+	if middleware.AddErrorToContext(c, err) {
+		return
+	}
+
+	ctx := routes.GetContext(c)
+	logStream, err := streamFunc(ctx, c, logOptions, logger)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Error streaming %q logs: %v", logPrefix, err.Error()))
+		middleware.AddErrorToContext(c, err)
+		return
+	}
+	defer logStream.Close()
+
+	var formatFunc func(string) string
+	if isJSONFormat(logOptions) {
+		formatFunc = func(line string) string {
+			return formatLogFrame(logStream.Pod, logStream.Container, line)
+		}
+	}
+
+	if strings.Contains(c.GetHeader(acceptHeader), eventStreamContentType) {
+		streamLogEvents(ctx, c, logStream, formatFunc, logger)
+		return
+	}
+
+	downloadLogs(c, logStream, c.Param(paramKey)+logFileExtension, formatFunc, logger)
+}
+
+// downloadLogs copies the log stream to the response as a chunked attachment, flushing every chunk
+// as soon as it is read. If formatFunc is set, every line is formatted and written on its own line instead.
+// The response is gzip-compressed if the client accepts it.
+func downloadLogs(c *gin.Context, logStream io.Reader, fileName string, formatFunc func(string) string, logger *zap.Logger) {
+	c.Header(contentDispositionHeader, fmt.Sprintf(attachmentDisposition, fileName))
+	c.Header(varyHeader, acceptEncodingHeader)
+	c.Header(contentTypeHeader, plainTextContentType)
+
+	var writer io.Writer = c.Writer
+	flush := c.Writer.Flush
+	if strings.Contains(c.GetHeader(acceptEncodingHeader), gzipEncoding) {
+		c.Header(contentEncodingHeader, gzipEncoding)
+
+		gzipWriter := gzip.NewWriter(c.Writer)
+		defer gzipWriter.Close()
+
+		writer = gzipWriter
+		flush = func() {
+			_ = gzipWriter.Flush()
+			c.Writer.Flush()
+		}
+	}
+
+	c.Status(http.StatusOK)
+
+	if formatFunc != nil {
+		scanner := bufio.NewScanner(logStream)
+		scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxEventLineSize)
+		for scanner.Scan() {
+			if _, err := io.WriteString(writer, formatFunc(scanner.Text())+"\n"); err != nil {
+				logger.Debug(fmt.Sprintf("error writing logs download: %v", err.Error()))
+				return
+			}
+			flush()
+		}
+
+		if err := scanner.Err(); err != nil {
+			logger.Debug(fmt.Sprintf("error reading logs download: %v", err.Error()))
+		}
+		return
+	}
+
+	buf := make([]byte, logsDownloadBufSize)
+	for {
+		n, err := logStream.Read(buf)
+		if n > 0 {
+			if _, writeErr := writer.Write(buf[:n]); writeErr != nil {
+				logger.Debug(fmt.Sprintf("error writing logs download: %v", writeErr.Error()))
+				return
+			}
+			flush()
+		}
+
+		if err != nil {
+			if err != io.EOF {
+				logger.Debug(fmt.Sprintf("error reading logs download: %v", err.Error()))
+			}
+			return
+		}
+	}
+}
+
+// streamLogEvents sends every line of the log stream as a "log" event. Once the stream ends an "end" event is
+// sent, or an "error" event if reading the stream failed. Comments are sent periodically to keep the connection alive.
+// If formatFunc is set, the data of every event is a JSON frame.
+func streamLogEvents(ctx context.Context, c *gin.Context, logStream io.Reader, formatFunc func(string) string, logger *zap.Logger) {
+	c.Header(cacheControlHeader, noCache)
+	c.Header(contentTypeHeader, eventStreamContentType)
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lines := make(chan string)
+	var readErr error
+	go func() {
+		defer close(lines)
+
+		scanner := bufio.NewScanner(logStream)
+		scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxEventLineSize)
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				return
+			case lines <- scanner.Text():
+			}
+		}
+
+		readErr = scanner.Err()
+	}()
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			if _, err := io.WriteString(c.Writer, keepAliveComment); err != nil {
+				return
+			}
+		case line, ok := <-lines:
+			if !ok {
+				if readErr != nil {
+					logger.Debug(fmt.Sprintf("error reading logs events: %v", readErr.Error()))
+					sendLogEvent(c, errorEvent, readErr.Error(), formatFunc != nil)
+				} else {
+					sendLogEvent(c, endEvent, streamEndedMessage, formatFunc != nil)
+				}
+				c.Writer.Flush()
+				return
+			}
+
+			if formatFunc != nil {
+				line = formatFunc(line)
+			}
+			c.SSEvent(logEvent, line)
+		}
+		c.Writer.Flush()
+	}
+}
+
+// sendLogEvent sends an "end" or "error" event with the given message, as a JSON frame if asJSON is true.
+func sendLogEvent(c *gin.Context, event, message string, asJSON bool) {
+	if !asJSON {
+		c.SSEvent(event, message)
+		return
+	}
+
+	if event == errorEvent {
+		c.SSEvent(event, formatErrorFrame(message))
+		return
+	}
+
+	c.SSEvent(event, formatEventFrame(types.LogControlEventEnd, message))
+}
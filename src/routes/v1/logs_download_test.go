@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	testNamespaceDownloadLogs = testutils.TestNamespace + "-Test_DownloadLogs"
+)
+
+func Test_DownloadLogs(t *testing.T) {
+	type args struct {
+		url     string
+		headers map[string]string
+	}
+	type want struct {
+		statusCode  int
+		headers     map[string]string
+		body        string
+		errorString string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldDownloadPodLogs": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/pod/%s/logs", testNamespaceDownloadLogs, pod1),
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				headers: map[string]string{
+					contentTypeHeader:        plainTextContentType,
+					contentDispositionHeader: fmt.Sprintf(attachmentDisposition, pod1+logFileExtension),
+				},
+				body: "fake logs",
+			},
+		},
+		"ShouldDownloadCappLogs": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?follow=false&tailLines=10", testNamespaceDownloadLogs, testutils.CappName),
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				headers: map[string]string{
+					contentTypeHeader:        plainTextContentType,
+					contentDispositionHeader: fmt.Sprintf(attachmentDisposition, testutils.CappName+logFileExtension),
+				},
+				body: "fake logs",
+			},
+		},
+		"ShouldDownloadCompressedLogs": {
+			args: args{
+				url:     fmt.Sprintf("/v1/namespaces/%s/pod/%s/logs", testNamespaceDownloadLogs, pod1),
+				headers: map[string]string{acceptEncodingHeader: gzipEncoding},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				headers: map[string]string{
+					contentEncodingHeader:    gzipEncoding,
+					contentDispositionHeader: fmt.Sprintf(attachmentDisposition, pod1+logFileExtension),
+				},
+				body: "fake logs",
+			},
+		},
+		"ShouldStreamLogEvents": {
+			args: args{
+				url:     fmt.Sprintf("/v1/namespaces/%s/pod/%s/logs", testNamespaceDownloadLogs, pod1),
+				headers: map[string]string{acceptHeader: eventStreamContentType},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				headers: map[string]string{
+					contentTypeHeader:  eventStreamContentType,
+					cacheControlHeader: noCache,
+				},
+				body: fmt.Sprintf("event:%s\ndata:fake logs\n\nevent:%s\ndata:%s\n\n", logEvent, endEvent, streamEndedMessage),
+			},
+		},
+		"ShouldDownloadLogsAsJSONLines": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/pod/%s/logs?format=json", testNamespaceDownloadLogs, pod1),
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				headers: map[string]string{
+					contentDispositionHeader: fmt.Sprintf(attachmentDisposition, pod1+logFileExtension),
+				},
+				body: fmt.Sprintf(`{"type":"log","pod":%q,"container":%q,"stream":"combined","line":"fake logs"}`+"\n", pod1, testutils.CappName),
+			},
+		},
+		"ShouldStreamLogEventsAsJSONFrames": {
+			args: args{
+				url:     fmt.Sprintf("/v1/namespaces/%s/pod/%s/logs?format=json", testNamespaceDownloadLogs, pod1),
+				headers: map[string]string{acceptHeader: eventStreamContentType},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: fmt.Sprintf("event:%s\ndata:%s\n\nevent:%s\ndata:%s\n\n",
+					logEvent, fmt.Sprintf(`{"type":"log","pod":%q,"container":%q,"stream":"combined","line":"fake logs"}`, pod1, testutils.CappName),
+					endEvent, fmt.Sprintf(`{"type":"control","event":"end","message":%q}`, streamEndedMessage)),
+			},
+		},
+		"ShouldFailToDownloadLogsOfNonExistingPod": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/pod/%s/logs", testNamespaceDownloadLogs, "test-invalid-pod"),
+			},
+			want: want{
+				statusCode:  http.StatusInternalServerError,
+				errorString: fmt.Sprintf("error opening log stream, failed to get pod: pods %q not found", "test-invalid-pod"),
+			},
+		},
+		"ShouldFailToDownloadLogsWithInvalidLogOptions": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?tailLines=-1", testNamespaceDownloadLogs, testutils.CappName),
+			},
+			want: want{
+				statusCode:  http.StatusBadRequest,
+				errorString: "Key: 'LogOptions.TailLines' Error:Field validation for 'TailLines' failed on the 'min' tag",
+			},
+		},
+		"ShouldFailToDownloadAggregatedLogs": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?aggregate=true", testNamespaceDownloadLogs, testutils.CappName),
+			},
+			want: want{
+				statusCode:  http.StatusBadRequest,
+				errorString: errAggregatedLogsNotWebSocket,
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestNamespace(fakeClient, testNamespaceDownloadLogs)
+	mocks.CreateTestPod(fakeClient, testNamespaceDownloadLogs, pod1, testutils.CappName, false)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodGet, tc.args.url, nil)
+			assert.NoError(t, err)
+			for key, value := range tc.args.headers {
+				request.Header.Set(key, value)
+			}
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, tc.want.statusCode, writer.Code)
+			if tc.want.errorString != "" {
+				var response map[string]interface{}
+				assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+				assert.Equal(t, tc.want.errorString, response[testutils.ErrorKey])
+				return
+			}
+
+			for key, value := range tc.want.headers {
+				assert.Equal(t, value, writer.Header().Get(key))
+			}
+
+			var body io.Reader = writer.Body
+			if writer.Header().Get(contentEncodingHeader) == gzipEncoding {
+				body, err = gzip.NewReader(writer.Body)
+				assert.NoError(t, err)
+			}
+
+			content, err := io.ReadAll(body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want.body, string(content))
+		})
+	}
+}
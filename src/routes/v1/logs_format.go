@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/types"
+	websocketpkg "github.com/dana-team/platform-backend/src/websocket"
+	"github.com/gorilla/websocket"
+	"strings"
+	"time"
+)
+
+const timestampSeparator = " "
+
+// isJSONFormat returns true if the log lines should be sent as structured JSON frames.
+func isJSONFormat(logOptions types.LogOptions) bool {
+	return logOptions.Format == types.LogFormatJSON
+}
+
+// logStreamOptions returns the options used to stream logs over a WebSocket in the requested format.
+// Every JSON frame holds a single object, so JSON frames are not batched.
+func logStreamOptions(logOptions types.LogOptions) *websocketpkg.StreamOptions {
+	if !isJSONFormat(logOptions) {
+		return nil
+	}
+
+	return &websocketpkg.StreamOptions{BatchSize: 1, ControlFrame: formatControlFrame}
+}
+
+// formatLogFrame formats a log line as a JSON log frame. The timestamp that prefixes the line is moved to its own field.
+func formatLogFrame(pod, container, line string) string {
+	frame := types.LogFrame{
+		Type:      types.LogFrameTypeLog,
+		Pod:       pod,
+		Container: container,
+		Stream:    types.LogStreamCombined,
+		Line:      line,
+	}
+
+	timestamp, content, _ := strings.Cut(line, timestampSeparator)
+	if _, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+		frame.Timestamp = timestamp
+		frame.Line = content
+	}
+
+	return marshalFrame(frame)
+}
+
+// formatControlFrame formats the frame sent before the WebSocket is closed with the given code and reason.
+// Streams which ended or were cancelled are reported with a control frame and any other closure with an error frame.
+func formatControlFrame(code int, reason string) string {
+	switch code {
+	case websocket.CloseNormalClosure:
+		return formatEventFrame(types.LogControlEventEnd, reason)
+	case websocket.CloseGoingAway:
+		return formatEventFrame(types.LogControlEventCancelled, reason)
+	default:
+		return formatErrorFrame(reason)
+	}
+}
+
+// formatEventFrame formats a JSON control frame for the given event.
+func formatEventFrame(event, message string) string {
+	return marshalFrame(types.LogControlFrame{Type: types.LogFrameTypeControl, Event: event, Message: message})
+}
+
+// formatErrorFrame formats a JSON error frame with the given message.
+func formatErrorFrame(message string) string {
+	return marshalFrame(types.LogErrorFrame{Type: types.LogFrameTypeError, Message: message})
+}
+
+// marshalFrame returns the JSON encoding of the frame. Frames only hold strings, so encoding them can not fail.
+func marshalFrame(frame interface{}) string {
+	data, err := json.Marshal(frame)
+	if err != nil {
+		return fmt.Sprintf(`{"type":%q,"message":%q}`, types.LogFrameTypeError, err.Error())
+	}
+
+	return string(data)
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_FormatLogFrame(t *testing.T) {
+	cases := map[string]struct {
+		line string
+		want string
+	}{
+		"ShouldMoveTimestampToItsOwnField": {
+			line: "2024-01-01T10:00:00.123456789Z server started",
+			want: `{"type":"log","pod":"pod","container":"container","timestamp":"2024-01-01T10:00:00.123456789Z","stream":"combined","line":"server started"}`,
+		},
+		"ShouldKeepLineWithoutTimestamp": {
+			line: "server started",
+			want: `{"type":"log","pod":"pod","container":"container","stream":"combined","line":"server started"}`,
+		},
+		"ShouldKeepEmptyLineWithTimestamp": {
+			line: "2024-01-01T10:00:00Z",
+			want: `{"type":"log","pod":"pod","container":"container","timestamp":"2024-01-01T10:00:00Z","stream":"combined","line":""}`,
+		},
+		"ShouldEscapeLine": {
+			line: `{"level":"info"}`,
+			want: `{"type":"log","pod":"pod","container":"container","stream":"combined","line":"{\"level\":\"info\"}"}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, formatLogFrame("pod", "container", tc.line))
+		})
+	}
+}
+
+func Test_FormatControlFrame(t *testing.T) {
+	cases := map[string]struct {
+		code   int
+		reason string
+		want   string
+	}{
+		"ShouldFormatEndEvent": {
+			code:   websocket.CloseNormalClosure,
+			reason: "stream ended",
+			want:   `{"type":"control","event":"end","message":"stream ended"}`,
+		},
+		"ShouldFormatCancelledEvent": {
+			code:   websocket.CloseGoingAway,
+			reason: "stream cancelled",
+			want:   `{"type":"control","event":"cancelled","message":"stream cancelled"}`,
+		},
+		"ShouldFormatError": {
+			code:   websocket.CloseInternalServerErr,
+			reason: "error opening log stream",
+			want:   `{"type":"error","message":"error opening log stream"}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, formatControlFrame(tc.code, tc.reason))
+		})
+	}
+}
+
+func Test_LogStreamOptions(t *testing.T) {
+	assert.Nil(t, logStreamOptions(types.LogOptions{}))
+	assert.Nil(t, logStreamOptions(types.LogOptions{Format: types.LogFormatText}))
+
+	streamOptions := logStreamOptions(types.LogOptions{Format: types.LogFormatJSON})
+	assert.Equal(t, 1, streamOptions.BatchSize)
+	assert.NotNil(t, streamOptions.ControlFrame)
+}
@@ -1,15 +1,17 @@
 package v1
 
 import (
+	"context"
 	"fmt"
 	"github.com/dana-team/platform-backend/src/controllers"
+	"github.com/dana-team/platform-backend/src/customerrors"
 	"github.com/dana-team/platform-backend/src/middleware"
 	"github.com/dana-team/platform-backend/src/routes"
+	"github.com/dana-team/platform-backend/src/types"
 	websocketpkg "github.com/dana-team/platform-backend/src/websocket"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
-	"io"
 	"net/http"
 )
 
@@ -17,27 +19,45 @@ const (
 	namespaceParam      = "namespaceName"
 	cappNameParam       = "cappName"
 	containerQueryParam = "container"
-	previousQueryParam  = "previous"
 	trueValue           = "true"
 	trueValueCapital    = "True"
 	podNameQueryParam   = "podName"
+	aggregateQueryParam = "aggregate"
 )
 
+const errAggregatedLogsNotWebSocket = "aggregated logs can only be streamed over a WebSocket"
+
+// logStreamFunc opens the log stream requested by the gin.Context using the given log options.
+type logStreamFunc func(context.Context, *gin.Context, types.LogOptions, *zap.Logger) (*types.LogStream, error)
+
 // GetPodLogs returns a handler function that fetches logs for a specified pod and container.
 func GetPodLogs() gin.HandlerFunc {
 	return createLogHandler(streamPodLogs, podNameQueryParam, "Pod")
 }
 
 // GetCappLogs returns a handler function that fetches logs for a specified Capp.
+// When aggregated logs are requested, the logs of all the Capp's pods and containers are streamed together.
 func GetCappLogs() gin.HandlerFunc {
-	return createLogHandler(streamCappLogs, cappNameParam, "Capp")
+	singlePodHandler := createLogHandler(streamCappLogs, cappNameParam, "Capp")
+
+	return func(c *gin.Context) {
+		if isAggregatedLogsRequested(c) {
+			streamAggregatedCappLogs(c)
+			return
+		}
+
+		singlePodHandler(c)
+	}
 }
 
 // createLogHandler creates a gin.HandlerFunc for streaming logs using the provided stream function.
-func createLogHandler(streamFunc func(*gin.Context, *zap.Logger) (io.ReadCloser, error), paramKey, logPrefix string) gin.HandlerFunc {
+// WebSocket streams are closed with a close frame once they end or the client goes away,
+// and any other request is served over plain HTTP.
+// Lines are sent as JSON frames when the json format is requested.
+func createLogHandler(streamFunc logStreamFunc, paramKey, logPrefix string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if !websocket.IsWebSocketUpgrade(c.Request) {
-			c.AbortWithStatus(http.StatusBadRequest)
+			serveHTTPLogs(c, streamFunc, paramKey, logPrefix)
 			return
 		}
 
@@ -55,24 +75,104 @@ func createLogHandler(streamFunc func(*gin.Context, *zap.Logger) (io.ReadCloser,
 		}
 		defer conn.Close()
 
-		logStream, err := streamFunc(c, logger)
+		ctx, cancel := context.WithCancel(routes.GetContext(c))
+		defer cancel()
+
+		logOptions, err := bindLogOptions(c)
+		if err != nil {
+			websocketpkg.CloseWithError(conn, err, nil)
+			return
+		}
+
+		streamOptions := logStreamOptions(logOptions)
+		logStream, err := streamFunc(ctx, c, logOptions, logger)
 		if err != nil {
 			logger.Debug(fmt.Sprintf("Error streaming %q logs: %v", logPrefix, err.Error()))
-			websocketpkg.SendErrorMessage(conn, fmt.Sprintf("Error streaming %q logs: %v", logPrefix, err.Error()))
+			websocketpkg.CloseWithError(conn, err, streamOptions)
 			return
 		}
-		defer logStream.Close()
 
 		formatFunc := func(line string) string {
 			return fmt.Sprintf("%v: %q line: %v", logPrefix, c.Param(paramKey), line)
 		}
+		if isJSONFormat(logOptions) {
+			formatFunc = func(line string) string {
+				return formatLogFrame(logStream.Pod, logStream.Container, line)
+			}
+		}
 
-		websocketpkg.Stream(c, conn, logStream, formatFunc)
+		websocketpkg.Stream(ctx, conn, logStream, formatFunc, streamOptions)
 	}
 }
 
+// streamAggregatedCappLogs streams the logs of all the pods and containers of a Capp over a single WebSocket.
+// Every message is tagged with the pod and container the line was read from, or sent as a JSON frame when the json format is requested.
+func streamAggregatedCappLogs(c *gin.Context) {
+	if !websocket.IsWebSocketUpgrade(c.Request) {
+		middleware.AddErrorToContext(c, customerrors.NewValidationError(errAggregatedLogsNotWebSocket))
+		return
+	}
+
+	logger, err := middleware.GetLogger(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("error getting logger %s", err.Error())})
+		return
+	}
+
+	websocketClient := websocketpkg.NewWebSocket(nil)
+	conn, err := websocketClient.Register(c)
+	if err != nil {
+		logger.Error(fmt.Sprintf("error streaming %q logs: %v", "Capp", err.Error()))
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(routes.GetContext(c))
+	defer cancel()
+
+	logOptions, err := bindLogOptions(c)
+	if err != nil {
+		websocketpkg.CloseWithError(conn, err, nil)
+		return
+	}
+
+	streamOptions := logStreamOptions(logOptions)
+	lines, err := fetchAggregatedCappLogs(ctx, c, logOptions, logger)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Error streaming %q logs: %v", "Capp", err.Error()))
+		websocketpkg.CloseWithError(conn, err, streamOptions)
+		return
+	}
+
+	cappName := c.Param(cappNameParam)
+	formatFunc := func(line types.LogLine) string {
+		return fmt.Sprintf("Capp: %q pod: %q container: %q line: %v", cappName, line.Pod, line.Container, line.Line)
+	}
+	if isJSONFormat(logOptions) {
+		formatFunc = func(line types.LogLine) string {
+			return formatLogFrame(line.Pod, line.Container, line.Line)
+		}
+	}
+
+	websocketpkg.StreamLines(ctx, conn, lines, formatFunc, streamOptions)
+}
+
+// fetchAggregatedCappLogs streams logs for all the pods of a specific Capp.
+func fetchAggregatedCappLogs(ctx context.Context, c *gin.Context, logOptions types.LogOptions, logger *zap.Logger) (<-chan types.LogLine, error) {
+	client, err := middleware.GetKubeClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := c.Param(namespaceParam)
+	cappName := c.Param(cappNameParam)
+	containerName := c.Query(containerQueryParam)
+
+	return controllers.FetchAggregatedCappLogs(ctx, client, namespace, cappName, containerName, logOptions, logger)
+}
+
 // streamPodLogs streams logs for a specific pod and container.
-func streamPodLogs(c *gin.Context, logger *zap.Logger) (io.ReadCloser, error) {
+func streamPodLogs(ctx context.Context, c *gin.Context, logOptions types.LogOptions, logger *zap.Logger) (*types.LogStream, error) {
 	client, err := middleware.GetKubeClient(c)
 	if err != nil {
 		return nil, err
@@ -82,12 +182,11 @@ func streamPodLogs(c *gin.Context, logger *zap.Logger) (io.ReadCloser, error) {
 	podName := c.Param(podNameQueryParam)
 	containerName := c.Query(containerQueryParam)
 
-	context := routes.GetContext(c)
-	return controllers.FetchPodLogs(context, client, namespace, podName, containerName, isPreviousLogsRequested(c), logger)
+	return controllers.FetchPodLogs(ctx, client, namespace, podName, containerName, logOptions, logger)
 }
 
 // streamCappLogs streams logs for a specific Capp.
-func streamCappLogs(c *gin.Context, logger *zap.Logger) (io.ReadCloser, error) {
+func streamCappLogs(ctx context.Context, c *gin.Context, logOptions types.LogOptions, logger *zap.Logger) (*types.LogStream, error) {
 	client, err := middleware.GetKubeClient(c)
 	if err != nil {
 		return nil, err
@@ -98,12 +197,21 @@ func streamCappLogs(c *gin.Context, logger *zap.Logger) (io.ReadCloser, error) {
 	containerName := c.DefaultQuery(containerQueryParam, cappName)
 	podName := c.Query(podNameQueryParam)
 
-	context := routes.GetContext(c)
-	return controllers.FetchCappLogs(context, client, namespace, cappName, containerName, podName, isPreviousLogsRequested(c), logger)
+	return controllers.FetchCappLogs(ctx, client, namespace, cappName, containerName, podName, logOptions, logger)
+}
+
+// bindLogOptions binds the log options from the query params.
+func bindLogOptions(c *gin.Context) (types.LogOptions, error) {
+	var logOptions types.LogOptions
+	if err := c.ShouldBindQuery(&logOptions); err != nil {
+		return logOptions, customerrors.NewValidationError(err.Error())
+	}
+
+	return logOptions, nil
 }
 
-// isPreviousLogsRequested returns true if the query parameter for previous logs is set to "true" or "True".
-func isPreviousLogsRequested(c *gin.Context) bool {
-	previous := c.Query(previousQueryParam)
-	return previous == trueValue || previous == trueValueCapital
+// isAggregatedLogsRequested returns true if the query parameter for aggregated logs is set to "true" or "True".
+func isAggregatedLogsRequested(c *gin.Context) bool {
+	aggregate := c.Query(aggregateQueryParam)
+	return aggregate == trueValue || aggregate == trueValueCapital
 }
@@ -1,15 +1,19 @@
 package v1
 
 import (
+	"errors"
 	"fmt"
 	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/utils/logfilter"
 	"github.com/dana-team/platform-backend/src/utils/testutils"
 	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	websocketpkg "github.com/dana-team/platform-backend/src/websocket"
 	"github.com/gorilla/websocket"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -18,6 +22,8 @@ const (
 	testNamespaceGetCappLogs = testutils.TestNamespace + "-Test_GetCappLogs"
 	testNamespaceGetPodLogs  = testutils.TestNamespace + "-Test_GetPodLogs"
 
+	testNamespaceGetAggregatedCappLogs = testutils.TestNamespace + "-Test_GetAggregatedCappLogs"
+
 	authorizationHeader = "Authorization"
 	pod1                = testutils.PodName + "-1"
 	pod2                = testutils.PodName + "-2"
@@ -32,6 +38,8 @@ func Test_GetCappLogs(t *testing.T) {
 	type want struct {
 		statusCode    int
 		expectedLines []string
+		closeCode     int
+		closeReason   string
 	}
 
 	cases := map[string]struct {
@@ -54,8 +62,9 @@ func Test_GetCappLogs(t *testing.T) {
 				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/invalid-capp/logs", testNamespaceGetCappLogs),
 			},
 			want: want{
-				statusCode:    http.StatusSwitchingProtocols,
-				expectedLines: []string{fmt.Sprintf("error: Error streaming %q logs: no pods found for Capp %q in namespace %q", "Capp", "invalid-capp", testNamespaceGetCappLogs)},
+				statusCode:  http.StatusSwitchingProtocols,
+				closeCode:   websocket.CloseInternalServerErr,
+				closeReason: fmt.Sprintf("no pods found for Capp %q in namespace %q", "invalid-capp", testNamespaceGetCappLogs),
 			},
 		},
 		"ShouldStreamLogsWithQueryParams": {
@@ -78,14 +87,132 @@ func Test_GetCappLogs(t *testing.T) {
 				expectedLines: []string{fmt.Sprintf("Capp: %q line: fake logs", testutils.CappName)},
 			},
 		},
+		"ShouldStreamLogsWithLogOptions": {
+			args: args{
+				token: "valid_token",
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?tailLines=10&sinceSeconds=60&timestamps=true&limitBytes=1024&follow=false", testNamespaceGetCappLogs, testutils.CappName),
+			},
+			want: want{
+				statusCode:    http.StatusSwitchingProtocols,
+				expectedLines: []string{fmt.Sprintf("Capp: %q line: fake logs", testutils.CappName)},
+				closeCode:     websocket.CloseNormalClosure,
+				closeReason:   websocketpkg.CloseReasonStreamEnded,
+			},
+		},
+		"ShouldStreamLogsAsJSONFrames": {
+			args: args{
+				token: "valid_token",
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?format=json&container=%s", testNamespaceGetCappLogs, testutils.CappName, testutils.TestContainerName),
+			},
+			want: want{
+				statusCode: http.StatusSwitchingProtocols,
+				expectedLines: []string{
+					fmt.Sprintf(`{"type":"log","pod":%q,"container":%q,"stream":"combined","line":"fake logs"}`, pod2, testutils.TestContainerName),
+					fmt.Sprintf(`{"type":"control","event":"end","message":%q}`, websocketpkg.CloseReasonStreamEnded),
+				},
+				closeCode:   websocket.CloseNormalClosure,
+				closeReason: websocketpkg.CloseReasonStreamEnded,
+			},
+		},
+		"ShouldSendJSONErrorFrame": {
+			args: args{
+				token: "valid_token",
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/invalid-capp/logs?format=json", testNamespaceGetCappLogs),
+			},
+			want: want{
+				statusCode:    http.StatusSwitchingProtocols,
+				expectedLines: []string{`{"type":"error","message":"no pods found for Capp \"invalid-capp\"`},
+				closeCode:     websocket.CloseInternalServerErr,
+				closeReason:   fmt.Sprintf("no pods found for Capp %q", "invalid-capp"),
+			},
+		},
+		"ShouldNotStreamLogsWithInvalidFormat": {
+			args: args{
+				token: "valid_token",
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?format=xml", testNamespaceGetCappLogs, testutils.CappName),
+			},
+			want: want{
+				statusCode:  http.StatusSwitchingProtocols,
+				closeCode:   websocket.ClosePolicyViolation,
+				closeReason: "Key: 'LogOptions.Format' Error:Field validation for 'Format' failed on the 'oneof' tag",
+			},
+		},
+		"ShouldStreamLogsMatchingFilters": {
+			args: args{
+				token: "valid_token",
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?include=fake&exclude=debug&level=warn", testNamespaceGetCappLogs, testutils.CappName),
+			},
+			want: want{
+				statusCode:    http.StatusSwitchingProtocols,
+				expectedLines: []string{fmt.Sprintf("Capp: %q line: fake logs", testutils.CappName)},
+				closeCode:     websocket.CloseNormalClosure,
+				closeReason:   websocketpkg.CloseReasonStreamEnded,
+			},
+		},
+		"ShouldNotStreamLogsExcludedByFilter": {
+			args: args{
+				token: "valid_token",
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?exclude=fake", testNamespaceGetCappLogs, testutils.CappName),
+			},
+			want: want{
+				statusCode:  http.StatusSwitchingProtocols,
+				closeCode:   websocket.CloseNormalClosure,
+				closeReason: websocketpkg.CloseReasonStreamEnded,
+			},
+		},
+		"ShouldNotStreamLogsWithInvalidIncludePattern": {
+			args: args{
+				token: "valid_token",
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?include=(", testNamespaceGetCappLogs, testutils.CappName),
+			},
+			want: want{
+				statusCode:  http.StatusSwitchingProtocols,
+				closeCode:   websocket.ClosePolicyViolation,
+				closeReason: logfilter.ErrInvalidIncludePattern,
+			},
+		},
+		"ShouldNotStreamLogsWithInvalidLevel": {
+			args: args{
+				token: "valid_token",
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?level=verbose", testNamespaceGetCappLogs, testutils.CappName),
+			},
+			want: want{
+				statusCode:  http.StatusSwitchingProtocols,
+				closeCode:   websocket.ClosePolicyViolation,
+				closeReason: "Key: 'LogOptions.Level' Error:Field validation for 'Level' failed on the 'oneof' tag",
+			},
+		},
+		"ShouldNotStreamLogsWithNegativeTailLines": {
+			args: args{
+				token: "valid_token",
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?tailLines=-1", testNamespaceGetCappLogs, testutils.CappName),
+			},
+			want: want{
+				statusCode:  http.StatusSwitchingProtocols,
+				closeCode:   websocket.ClosePolicyViolation,
+				closeReason: "Key: 'LogOptions.TailLines' Error:Field validation for 'TailLines' failed on the 'min' tag",
+			},
+		},
+		"ShouldNotStreamLogsWithBothSinceSecondsAndSinceTime": {
+			args: args{
+				token: "valid_token",
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?sinceSeconds=60&sinceTime=2024-01-01T00:00:00Z", testNamespaceGetCappLogs, testutils.CappName),
+			},
+			want: want{
+				statusCode:  http.StatusSwitchingProtocols,
+				closeCode:   websocket.ClosePolicyViolation,
+				closeReason: "Key: 'LogOptions.SinceSeconds' Error:Field validation for 'SinceSeconds' failed on the 'excluded_with' tag",
+			},
+		},
 		"ShouldNotStreamLogsWithNonExistingPodName": {
 			args: args{
 				token: "valid_token",
 				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?podName=pod%s", testNamespaceGetCappLogs, testutils.CappName, testutils.NonExistentSuffix),
 			},
 			want: want{
-				statusCode:    http.StatusSwitchingProtocols,
-				expectedLines: []string{fmt.Sprintf("error: Error streaming %q logs: pod %q not found for Capp %q in namespace %q", "Capp", "pod"+testutils.NonExistentSuffix, testutils.CappName, testNamespaceGetCappLogs)},
+				statusCode:  http.StatusSwitchingProtocols,
+				closeCode:   websocket.CloseInternalServerErr,
+				closeReason: fmt.Sprintf("pod %q not found for Capp %q in namespace %q", "pod"+testutils.NonExistentSuffix, testutils.CappName, testNamespaceGetCappLogs),
 			},
 		},
 		"ShouldNotStreamLogsWithInvalidContainerName": {
@@ -94,8 +221,9 @@ func Test_GetCappLogs(t *testing.T) {
 				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?container=container%s", testNamespaceGetCappLogs, testutils.CappName, testutils.NonExistentSuffix),
 			},
 			want: want{
-				statusCode:    http.StatusSwitchingProtocols,
-				expectedLines: []string{fmt.Sprintf("error: Error streaming %q logs: error opening log stream, container %q not found in the pod %q", "Capp", "container"+testutils.NonExistentSuffix, pod2)},
+				statusCode:  http.StatusSwitchingProtocols,
+				closeCode:   websocket.CloseInternalServerErr,
+				closeReason: fmt.Sprintf("error opening log stream, container %q not found in the pod %q", "container"+testutils.NonExistentSuffix, pod2),
 			},
 		},
 		"ShouldStreamLogsWithValidContainerName": {
@@ -139,12 +267,13 @@ func Test_GetCappLogs(t *testing.T) {
 			}
 
 			defer conn.Close()
-			for _, expectedLine := range tc.want.expectedLines {
-				_, message, err := conn.ReadMessage()
-				if err != nil {
-					t.Fatalf("Error reading message from WebSocket: %v", err)
-				}
-				assert.Contains(t, string(message), expectedLine)
+			messages := readStreamedLines(t, conn, len(tc.want.expectedLines))
+			for i, expectedLine := range tc.want.expectedLines {
+				assert.Contains(t, messages[i], expectedLine)
+			}
+
+			if tc.want.closeCode != 0 {
+				assertStreamClosed(t, conn, tc.want.closeCode, tc.want.closeReason)
 			}
 		})
 	}
@@ -158,6 +287,8 @@ func Test_GetPodLogs(t *testing.T) {
 	type want struct {
 		statusCode    int
 		expectedLines []string
+		closeCode     int
+		closeReason   string
 	}
 
 	cases := map[string]struct {
@@ -200,8 +331,9 @@ func Test_GetPodLogs(t *testing.T) {
 				wsUrl: fmt.Sprintf("/v1/namespaces/%s/pod/%s/logs", testNamespaceGetPodLogs, pod3),
 			},
 			want: want{
-				statusCode:    http.StatusSwitchingProtocols,
-				expectedLines: []string{fmt.Sprintf(`error: Error streaming "Pod" logs: error opening log stream, pod %q has multiple containers, please specify the container name`, pod3)},
+				statusCode:  http.StatusSwitchingProtocols,
+				closeCode:   websocket.CloseInternalServerErr,
+				closeReason: fmt.Sprintf(`error opening log stream, pod %q has multiple containers, please specify the container name`, pod3),
 			},
 		},
 		"ShouldNotStreamLogsWithNonExistingPodName": {
@@ -210,8 +342,9 @@ func Test_GetPodLogs(t *testing.T) {
 				wsUrl: fmt.Sprintf("/v1/namespaces/%s/pod/test-invalid-pod/logs", testNamespaceGetPodLogs),
 			},
 			want: want{
-				statusCode:    http.StatusSwitchingProtocols,
-				expectedLines: []string{fmt.Sprintf(`error: Error streaming "Pod" logs: error opening log stream, failed to get pod: pods %q not found`, "test-invalid-pod")},
+				statusCode:  http.StatusSwitchingProtocols,
+				closeCode:   websocket.CloseInternalServerErr,
+				closeReason: fmt.Sprintf(`error opening log stream, failed to get pod: pods %q not found`, "test-invalid-pod"),
 			},
 		},
 		"ShouldNotStreamLogsWithInvalidContainerName": {
@@ -220,8 +353,9 @@ func Test_GetPodLogs(t *testing.T) {
 				wsUrl: fmt.Sprintf("/v1/namespaces/%s/pod/%s/logs?container=container%s", testNamespaceGetPodLogs, pod1, testutils.NonExistentSuffix),
 			},
 			want: want{
-				statusCode:    http.StatusSwitchingProtocols,
-				expectedLines: []string{fmt.Sprintf(`error: Error streaming "Pod" logs: error opening log stream, container %q not found in the pod %q`, "container"+testutils.NonExistentSuffix, pod1)},
+				statusCode:  http.StatusSwitchingProtocols,
+				closeCode:   websocket.CloseInternalServerErr,
+				closeReason: fmt.Sprintf(`error opening log stream, container %q not found in the pod %q`, "container"+testutils.NonExistentSuffix, pod1),
 			},
 		},
 	}
@@ -257,13 +391,120 @@ func Test_GetPodLogs(t *testing.T) {
 			}
 
 			defer conn.Close()
-			for _, expectedLine := range tc.want.expectedLines {
-				_, message, err := conn.ReadMessage()
-				if err != nil {
-					t.Fatalf("Error reading message from WebSocket: %v", err)
-				}
-				assert.Contains(t, string(message), expectedLine)
+			messages := readStreamedLines(t, conn, len(tc.want.expectedLines))
+			for i, expectedLine := range tc.want.expectedLines {
+				assert.Contains(t, messages[i], expectedLine)
+			}
+
+			if tc.want.closeCode != 0 {
+				assertStreamClosed(t, conn, tc.want.closeCode, tc.want.closeReason)
 			}
 		})
 	}
 }
+
+func Test_GetAggregatedCappLogs(t *testing.T) {
+	type args struct {
+		wsUrl string
+	}
+	type want struct {
+		expectedMessages []string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldStreamLogsOfAllPodsAndContainers": {
+			args: args{
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?aggregate=true", testNamespaceGetAggregatedCappLogs, testutils.CappName),
+			},
+			want: want{
+				expectedMessages: []string{
+					fmt.Sprintf("Capp: %q pod: %q container: %q line: fake logs", testutils.CappName, pod1, testutils.CappName),
+					fmt.Sprintf("Capp: %q pod: %q container: %q line: fake logs", testutils.CappName, pod2, testutils.TestContainerName),
+					fmt.Sprintf("Capp: %q pod: %q container: %q line: fake logs", testutils.CappName, pod2, testutils.CappName),
+				},
+			},
+		},
+		"ShouldStreamLogsAsJSONFrames": {
+			args: args{
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?aggregate=true&format=json&container=%s", testNamespaceGetAggregatedCappLogs, testutils.CappName, testutils.TestContainerName),
+			},
+			want: want{
+				expectedMessages: []string{
+					fmt.Sprintf(`{"type":"log","pod":%q,"container":%q,"stream":"combined","line":"fake logs"}`, pod2, testutils.TestContainerName),
+				},
+			},
+		},
+		"ShouldStreamLogsOfSpecificContainer": {
+			args: args{
+				wsUrl: fmt.Sprintf("/v1/namespaces/%s/capp/%s/logs?aggregate=true&container=%s", testNamespaceGetAggregatedCappLogs, testutils.CappName, testutils.TestContainerName),
+			},
+			want: want{
+				expectedMessages: []string{
+					fmt.Sprintf("Capp: %q pod: %q container: %q line: fake logs", testutils.CappName, pod2, testutils.TestContainerName),
+				},
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestNamespace(fakeClient, testNamespaceGetAggregatedCappLogs)
+	mocks.CreateTestPod(fakeClient, testNamespaceGetAggregatedCappLogs, pod1, testutils.CappName, false)
+	mocks.CreateTestPod(fakeClient, testNamespaceGetAggregatedCappLogs, pod2, testutils.CappName, true)
+	mocks.CreateTestPod(fakeClient, testNamespaceGetAggregatedCappLogs, pod3, "", false)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			token = "valid_token"
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + tc.args.wsUrl
+
+			headers := http.Header{}
+			headers.Add(authorizationHeader, token)
+			headers.Add(middleware.WebsocketTokenHeader, token)
+
+			conn, resp, err := websocket.DefaultDialer.Dial(wsURL, headers)
+			if err != nil {
+				t.Fatalf("Failed to dial WebSocket: %v", err)
+			}
+			defer conn.Close()
+			assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+			messages := readStreamedLines(t, conn, len(tc.want.expectedMessages))
+			assert.ElementsMatch(t, tc.want.expectedMessages, messages)
+		})
+	}
+}
+
+// readStreamedLines reads frames from the WebSocket until the given number of lines is received.
+// A single frame may hold several lines separated by newlines.
+func readStreamedLines(t *testing.T, conn *websocket.Conn, count int) []string {
+	var lines []string
+	for len(lines) < count {
+		assert.NoError(t, conn.SetReadDeadline(time.Now().Add(watchTimeout)))
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("Error reading message from WebSocket: %v", err)
+		}
+		lines = append(lines, strings.Split(string(message), "\n")...)
+	}
+
+	return lines
+}
+
+// assertStreamClosed asserts that the next frame read from the WebSocket is a close frame with the given code and reason.
+func assertStreamClosed(t *testing.T, conn *websocket.Conn, code int, reason string) {
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(watchTimeout)))
+	_, _, err := conn.ReadMessage()
+
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) {
+		t.Fatalf("Expected a close frame, got %v", err)
+	}
+	assert.Equal(t, code, closeErr.Code)
+	assert.Contains(t, closeErr.Text, reason)
+}
@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"github.com/dana-team/platform-backend/src/controllers"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/routes"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils/prometheus"
+	"github.com/gin-gonic/gin"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"net/http"
+)
+
+// GetCappMetrics returns a Gin handler function for retrieving the resource usage of a specific capp.
+func GetCappMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var cappUri types.CappUri
+		if err := c.BindUri(&cappUri); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		var query types.MetricsQuery
+		if err := c.BindQuery(&query); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		dynClient, err := middleware.GetDynClient(c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		logger, err := middleware.GetLogger(c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		historyClient, err := newMetricsHistoryClient(c)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		metricsController := controllers.NewMetricsController(dynClient, historyClient, routes.GetContext(c), logger)
+		metrics, err := metricsController.GetCappMetrics(cappUri.NamespaceName, cappUri.CappName, query)
+		if middleware.AddErrorToContext(c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, metrics)
+	}
+}
+
+// newMetricsHistoryClient creates a client of the Prometheus-compatible query backend which authenticates as the user,
+// or returns nil if no query backend is configured.
+func newMetricsHistoryClient(c *gin.Context) (promv1.API, error) {
+	address := prometheus.GetAddress()
+	if address == "" {
+		return nil, nil
+	}
+
+	config, err := middleware.GetRestConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	historyClient, err := prometheus.NewClient(address, config.BearerToken, config.Insecure)
+	if err != nil {
+		return nil, customerrors.NewInternalServerError("failed to create metrics history client")
+	}
+
+	return historyClient, nil
+}
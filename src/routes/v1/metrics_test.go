@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/controllers"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetCappMetrics(t *testing.T) {
+	testNamespaceName := testutils.CappNamespace + "-metrics"
+
+	type want struct {
+		statusCode int
+		reason     metav1.StatusReason
+		error      string
+	}
+
+	cases := map[string]struct {
+		cappName string
+		query    string
+		want     want
+	}{
+		"ShouldFailWithInvalidRange": {
+			cappName: testutils.CappName,
+			query:    "?range=30s",
+			want: want{
+				statusCode: http.StatusBadRequest,
+				reason:     metav1.StatusReasonBadRequest,
+				error:      "Key: 'MetricsQuery.Range' Error:Field validation for 'Range' failed on the 'min' tag",
+			},
+		},
+		"ShouldFailGettingHistoryWithoutQueryBackend": {
+			cappName: testutils.CappName,
+			query:    "?range=1h",
+			want: want{
+				statusCode: http.StatusBadRequest,
+				reason:     metav1.StatusReasonBadRequest,
+				error:      controllers.ErrMetricsHistoryNotConfigured,
+			},
+		},
+		"ShouldHandleNotFoundCapp": {
+			cappName: testutils.CappName + testutils.NonExistentSuffix,
+			want: want{
+				statusCode: http.StatusNotFound,
+				reason:     metav1.StatusReasonNotFound,
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespaceName, testutils.Domain, nil, nil)
+	mocks.CreateTestPodMetrics(dynClient, testNamespaceName, pod1, testutils.CappName, "50m", "100Mi")
+
+	t.Run("ShouldSucceedGettingCappMetrics", func(t *testing.T) {
+		baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/metrics", testNamespaceName, testutils.CappName)
+		request, err := http.NewRequest(http.MethodGet, baseURI, nil)
+		assert.NoError(t, err)
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, request)
+
+		assert.Equal(t, http.StatusOK, writer.Code)
+
+		var response types.CappMetrics
+		assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+		assert.Equal(t, testutils.CappName, response.CappName)
+		assert.Len(t, response.Pods, 1)
+		assert.Equal(t, pod1, response.Pods[0].Name)
+		assert.Equal(t, int64(51), response.Usage.CPU)
+		assert.Nil(t, response.History)
+	})
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/metrics%s", testNamespaceName, test.cappName, test.query)
+			request, err := http.NewRequest(http.MethodGet, baseURI, nil)
+			assert.NoError(t, err)
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+			assert.Equal(t, string(test.want.reason), response[testutils.ReasonKey])
+			if test.want.error != "" {
+				assert.Equal(t, test.want.error, response[testutils.ErrorKey])
+			}
+		})
+	}
+}
@@ -45,7 +45,7 @@ func GetNamespaces() gin.HandlerFunc {
 		}
 
 		namespaceHandler(func(controller controllers.NamespaceController, c *gin.Context) (interface{}, error) {
-			return controller.GetNamespaces(limit, page)
+			return controller.GetNamespaces(limit, page, pagination.ExtractListQueryFromCtx(c))
 		})(c)
 	}
 }
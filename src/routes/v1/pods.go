@@ -53,7 +53,43 @@ func GetPods() gin.HandlerFunc {
 		}
 
 		podHandler(func(controller controllers.PodController, c *gin.Context) (interface{}, error) {
-			return controller.GetPods(request.NamespaceName, request.CappName, limit, page)
+			return controller.GetPods(request.NamespaceName, request.CappName, limit, page, pagination.ExtractListQueryFromCtx(c))
+		})(c)
+	}
+}
+
+// GetPod returns a Gin handler function for retrieving the status of a pod of a specific capp.
+func GetPod() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request types.PodUri
+		if err := c.BindUri(&request); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		podHandler(func(controller controllers.PodController, c *gin.Context) (interface{}, error) {
+			return controller.GetPod(request.NamespaceName, request.CappName, request.PodName)
+		})(c)
+	}
+}
+
+// DeletePod returns a Gin handler function for deleting a pod of a specific capp.
+func DeletePod() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request types.PodUri
+		if err := c.BindUri(&request); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		var query types.DeletePodQuery
+		if err := c.BindQuery(&query); err != nil {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(err.Error()))
+			return
+		}
+
+		podHandler(func(controller controllers.PodController, c *gin.Context) (interface{}, error) {
+			return controller.DeletePod(request.NamespaceName, request.CappName, request.PodName, query.GracePeriodSeconds)
 		})(c)
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
 	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/types"
 	"github.com/dana-team/platform-backend/src/utils/testutils"
 	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const (
@@ -51,8 +53,18 @@ func TestGetPods(t *testing.T) {
 				statusCode: http.StatusOK,
 				response: map[string]interface{}{
 					testutils.PodsKey: []interface{}{
-						map[string]interface{}{testutils.PodNameKey: pod1},
-						map[string]interface{}{testutils.PodNameKey: pod2},
+						map[string]interface{}{
+							testutils.PodNameKey:  pod1,
+							testutils.PhaseKey:    string(corev1.PodRunning),
+							testutils.ReadyKey:    false,
+							testutils.RestartsKey: 3,
+						},
+						map[string]interface{}{
+							testutils.PodNameKey:  pod2,
+							testutils.PhaseKey:    "",
+							testutils.ReadyKey:    false,
+							testutils.RestartsKey: 0,
+						},
 					},
 					testutils.CountKey: 2,
 				},
@@ -68,8 +80,18 @@ func TestGetPods(t *testing.T) {
 				statusCode: http.StatusOK,
 				response: map[string]interface{}{
 					testutils.PodsKey: []interface{}{
-						map[string]interface{}{testutils.PodNameKey: pod1},
-						map[string]interface{}{testutils.PodNameKey: pod2},
+						map[string]interface{}{
+							testutils.PodNameKey:  pod1,
+							testutils.PhaseKey:    string(corev1.PodRunning),
+							testutils.ReadyKey:    false,
+							testutils.RestartsKey: 3,
+						},
+						map[string]interface{}{
+							testutils.PodNameKey:  pod2,
+							testutils.PhaseKey:    "",
+							testutils.ReadyKey:    false,
+							testutils.RestartsKey: 0,
+						},
 					},
 					testutils.CountKey: 2,
 				},
@@ -106,7 +128,7 @@ func TestGetPods(t *testing.T) {
 	setup()
 	mocks.CreateTestNamespace(fakeClient, testNamespaceName)
 	mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespaceName, testutils.Domain, map[string]string{}, map[string]string{})
-	mocks.CreateTestPod(fakeClient, testNamespaceName, pod1, testutils.CappName, false)
+	mocks.CreateTestPodWithStatus(fakeClient, testNamespaceName, pod1, testutils.CappName)
 	mocks.CreateTestPod(fakeClient, testNamespaceName, pod2, testutils.CappName, true)
 	mocks.CreateTestPod(fakeClient, testNamespaceName, pod3, "", false)
 
@@ -142,3 +164,228 @@ func TestGetPods(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPod(t *testing.T) {
+	testNamespaceName := podNamespace + "-getpod"
+
+	type args struct {
+		namespace string
+		cappName  string
+		podName   string
+	}
+
+	type want struct {
+		statusCode int
+		response   map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldHandleNotFoundPod": {
+			args: args{
+				namespace: testNamespaceName,
+				cappName:  testutils.CappName,
+				podName:   pod1 + testutils.NonExistentSuffix,
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(failedToGetPodErr, pod1+testutils.NonExistentSuffix, testNamespaceName, pod1+testutils.NonExistentSuffix),
+					testutils.ReasonKey: testutils.ReasonNotFound,
+				},
+			},
+		},
+		"ShouldHandlePodOfAnotherCapp": {
+			args: args{
+				namespace: testNamespaceName,
+				cappName:  testutils.CappName,
+				podName:   pod2,
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf("pod %q does not belong to Capp %q", pod2, testutils.CappName),
+					testutils.ReasonKey: testutils.ReasonNotFound,
+				},
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestNamespace(fakeClient, testNamespaceName)
+	mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespaceName, testutils.Domain, map[string]string{}, map[string]string{})
+	mocks.CreateTestPodWithStatus(fakeClient, testNamespaceName, pod1, testutils.CappName)
+	mocks.CreateTestPod(fakeClient, testNamespaceName, pod2, "", false)
+
+	t.Run("ShouldSucceedGettingPod", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/namespaces/%s/capps/%s/pods/%s", testNamespaceName, testutils.CappName, pod1), nil)
+		assert.NoError(t, err)
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, request)
+
+		assert.Equal(t, http.StatusOK, writer.Code)
+
+		var response types.PodDetails
+		assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+		assert.Equal(t, pod1, response.PodName)
+		assert.Equal(t, testutils.RevisionName, response.Revision)
+		assert.Equal(t, testutils.NodeName, response.NodeName)
+		assert.False(t, response.Ready)
+		assert.Len(t, response.InitContainers, 1)
+		assert.Len(t, response.Containers, 1)
+		assert.Equal(t, testutils.ReasonCrashLoopBackOff, response.Containers[0].State.Reason)
+		assert.Equal(t, int32(1), *response.Containers[0].LastState.ExitCode)
+	})
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/pods/%s", test.args.namespace, test.args.cappName, test.args.podName)
+			request, err := http.NewRequest(http.MethodGet, baseURI, nil)
+			assert.NoError(t, err)
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+
+			var response map[string]interface{}
+			err = json.Unmarshal(writer.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			wantResponseJSON, err := json.Marshal(test.want.response)
+			assert.NoError(t, err)
+			var wantResponseNormalized map[string]interface{}
+			err = json.Unmarshal(wantResponseJSON, &wantResponseNormalized)
+			assert.NoError(t, err)
+			assert.Equal(t, wantResponseNormalized, response)
+		})
+	}
+}
+
+func TestDeletePod(t *testing.T) {
+	testNamespaceName := podNamespace + "-deletepod"
+
+	type args struct {
+		namespace   string
+		cappName    string
+		podName     string
+		queryParams map[string]string
+	}
+
+	type want struct {
+		statusCode int
+		response   map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldSucceedDeletingPod": {
+			args: args{
+				namespace: testNamespaceName,
+				cappName:  testutils.CappName,
+				podName:   pod1,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.MessageKey: fmt.Sprintf("Deleted pod %q in namespace %q successfully", pod1, testNamespaceName),
+				},
+			},
+		},
+		"ShouldSucceedDeletingPodWithGracePeriod": {
+			args: args{
+				namespace:   testNamespaceName,
+				cappName:    testutils.CappName,
+				podName:     pod3,
+				queryParams: map[string]string{"gracePeriodSeconds": "0"},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.MessageKey: fmt.Sprintf("Deleted pod %q in namespace %q successfully", pod3, testNamespaceName),
+				},
+			},
+		},
+		"ShouldFailWithNegativeGracePeriod": {
+			args: args{
+				namespace:   testNamespaceName,
+				cappName:    testutils.CappName,
+				podName:     pod3,
+				queryParams: map[string]string{"gracePeriodSeconds": "-1"},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  "Key: 'DeletePodQuery.GracePeriodSeconds' Error:Field validation for 'GracePeriodSeconds' failed on the 'min' tag",
+					testutils.ReasonKey: testutils.ReasonBadRequest,
+				},
+			},
+		},
+		"ShouldHandleNotFoundPod": {
+			args: args{
+				namespace: testNamespaceName,
+				cappName:  testutils.CappName,
+				podName:   pod1 + testutils.NonExistentSuffix,
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf(failedToGetPodErr, pod1+testutils.NonExistentSuffix, testNamespaceName, pod1+testutils.NonExistentSuffix),
+					testutils.ReasonKey: testutils.ReasonNotFound,
+				},
+			},
+		},
+		"ShouldHandlePodOfAnotherCapp": {
+			args: args{
+				namespace: testNamespaceName,
+				cappName:  testutils.CappName,
+				podName:   pod2,
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  fmt.Sprintf("pod %q does not belong to Capp %q", pod2, testutils.CappName),
+					testutils.ReasonKey: testutils.ReasonNotFound,
+				},
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestNamespace(fakeClient, testNamespaceName)
+	mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespaceName, testutils.Domain, map[string]string{}, map[string]string{})
+	mocks.CreateTestPod(fakeClient, testNamespaceName, pod1, testutils.CappName, false)
+	mocks.CreateTestPod(fakeClient, testNamespaceName, pod2, "", false)
+	mocks.CreateTestPod(fakeClient, testNamespaceName, pod3, testutils.CappName, false)
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			params := url.Values{}
+			for key, value := range test.args.queryParams {
+				params.Add(key, value)
+			}
+
+			baseURI := fmt.Sprintf("/v1/namespaces/%s/capps/%s/pods/%s", test.args.namespace, test.args.cappName, test.args.podName)
+			request, err := http.NewRequest(http.MethodDelete, baseURI+"?"+params.Encode(), nil)
+			assert.NoError(t, err)
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.want.statusCode, writer.Code)
+
+			var response map[string]interface{}
+			err = json.Unmarshal(writer.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			wantResponseJSON, err := json.Marshal(test.want.response)
+			assert.NoError(t, err)
+			var wantResponseNormalized map[string]interface{}
+			err = json.Unmarshal(wantResponseJSON, &wantResponseNormalized)
+			assert.NoError(t, err)
+			assert.Equal(t, wantResponseNormalized, response)
+		})
+	}
+}
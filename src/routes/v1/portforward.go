@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/controllers"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/routes"
+	"github.com/dana-team/platform-backend/src/types"
+	websocketpkg "github.com/dana-team/platform-backend/src/websocket"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"k8s.io/client-go/tools/portforward"
+	"net/http"
+)
+
+const (
+	errPortForwardNotWebSocket = "port-forward sessions can only be opened over a WebSocket"
+	portForwardTunnelName      = "portforward"
+)
+
+// PortForwardCapp returns a handler function that forwards a connection to a port of a Capp's pod.
+// The connection is upgraded to a WebSocket which tunnels the raw bytes of the forwarded connection
+// in binary messages, and is closed once either side closes the connection.
+func PortForwardCapp() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !websocket.IsWebSocketUpgrade(c.Request) {
+			middleware.AddErrorToContext(c, customerrors.NewValidationError(errPortForwardNotWebSocket))
+			return
+		}
+
+		logger, err := middleware.GetLogger(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("error getting logger %s", err.Error())})
+			return
+		}
+
+		websocketClient := websocketpkg.NewWebSocket(nil)
+		conn, err := websocketClient.Register(c)
+		if err != nil {
+			logger.Error(fmt.Sprintf("error opening port-forward session: %v", err.Error()))
+			return
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithCancel(routes.GetContext(c))
+		defer cancel()
+
+		var uri types.CappUri
+		if err := c.ShouldBindUri(&uri); err != nil {
+			websocketpkg.CloseWithError(conn, customerrors.NewValidationError(err.Error()), nil)
+			return
+		}
+
+		var query types.PortForwardQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			websocketpkg.CloseWithError(conn, customerrors.NewValidationError(err.Error()), nil)
+			return
+		}
+
+		kubeClient, err := middleware.GetKubeClient(c)
+		if err != nil {
+			websocketpkg.CloseWithError(conn, err, nil)
+			return
+		}
+
+		config, err := middleware.GetRestConfig(c)
+		if err != nil {
+			websocketpkg.CloseWithError(conn, err, nil)
+			return
+		}
+
+		websocketpkg.KeepAlive(ctx, conn, cancel, nil)
+		tunnel := portforward.NewTunnelingConnection(portForwardTunnelName, conn)
+		err = controllers.PortForwardCapp(ctx, kubeClient, config, uri.NamespaceName, uri.CappName, query.PodName, query.Port, tunnel, logger)
+		if err != nil {
+			websocketpkg.CloseWithError(conn, err, nil)
+			return
+		}
+
+		if ctx.Err() != nil {
+			websocketpkg.CloseWithReason(conn, websocket.CloseGoingAway, websocketpkg.CloseReasonStreamCancelled)
+			return
+		}
+
+		websocketpkg.CloseWithReason(conn, websocket.CloseNormalClosure, websocketpkg.CloseReasonStreamEnded)
+	}
+}
@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	testNamespacePortForward = testutils.TestNamespace + "-Test_PortForwardCapp"
+)
+
+func Test_PortForwardCapp(t *testing.T) {
+	type args struct {
+		url string
+	}
+	type want struct {
+		closeCode   int
+		closeReason string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldFailWhenAPIServerIsUnreachable": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/capps/%s/portforward?port=8080", testNamespacePortForward, testutils.CappName),
+			},
+			want: want{
+				closeCode:   websocket.CloseInternalServerErr,
+				closeReason: "error opening port-forward session",
+			},
+		},
+		"ShouldFailInClusterWhenAPIServerIsUnreachable": {
+			args: args{
+				url: fmt.Sprintf("/v1/clusters/%s/namespaces/%s/capps/%s/portforward?port=8080&podName=%s", cluster, testNamespacePortForward, testutils.CappName, pod1),
+			},
+			want: want{
+				closeCode:   websocket.CloseInternalServerErr,
+				closeReason: "error opening port-forward session",
+			},
+		},
+		"ShouldFailWithoutPort": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/capps/%s/portforward", testNamespacePortForward, testutils.CappName),
+			},
+			want: want{
+				closeCode:   websocket.ClosePolicyViolation,
+				closeReason: "Port",
+			},
+		},
+		"ShouldFailWithOutOfRangePort": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/capps/%s/portforward?port=70000", testNamespacePortForward, testutils.CappName),
+			},
+			want: want{
+				closeCode:   websocket.ClosePolicyViolation,
+				closeReason: "Port",
+			},
+		},
+		"ShouldFailWithPodOfAnotherCapp": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/capps/%s/portforward?port=8080&podName=%s", testNamespacePortForward, testutils.CappName, pod2),
+			},
+			want: want{
+				closeCode:   websocket.CloseInternalServerErr,
+				closeReason: fmt.Sprintf("pod %q not found for Capp %q", pod2, testutils.CappName),
+			},
+		},
+		"ShouldFailWithCappWithoutPods": {
+			args: args{
+				url: fmt.Sprintf("/v1/namespaces/%s/capps/%s/portforward?port=8080", testNamespacePortForward, testutils.CappName+testutils.NonExistentSuffix),
+			},
+			want: want{
+				closeCode:   websocket.CloseInternalServerErr,
+				closeReason: fmt.Sprintf("no pods found for Capp %q", testutils.CappName+testutils.NonExistentSuffix),
+			},
+		},
+	}
+
+	setup()
+	mocks.CreateTestNamespace(fakeClient, testNamespacePortForward)
+	mocks.CreateTestCapp(dynClient, testutils.CappName, testNamespacePortForward, testutils.Domain, map[string]string{}, map[string]string{})
+	mocks.CreateTestPod(fakeClient, testNamespacePortForward, pod1, testutils.CappName, false)
+	mocks.CreateTestPod(fakeClient, testNamespacePortForward, pod2, "", false)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + tc.args.url
+			headers := http.Header{}
+			headers.Add(middleware.WebsocketTokenHeader, "valid_token")
+
+			conn, resp, err := websocket.DefaultDialer.Dial(wsURL, headers)
+			if err != nil {
+				t.Fatalf("Failed to dial WebSocket: %v", err)
+			}
+			defer conn.Close()
+			assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+			assertStreamClosed(t, conn, tc.want.closeCode, tc.want.closeReason)
+		})
+	}
+}
+
+func Test_PortForwardCappNotWebSocket(t *testing.T) {
+	setup()
+
+	writer := httptest.NewRecorder()
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/namespaces/%s/capps/%s/portforward?port=8080", testNamespacePortForward, testutils.CappName), nil)
+	router.ServeHTTP(writer, request)
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+
+	response := map[string]string{}
+	assert.NoError(t, json.Unmarshal(writer.Body.Bytes(), &response))
+	assert.Equal(t, errPortForwardNotWebSocket, response[testutils.ErrorKey])
+}
@@ -72,7 +72,7 @@ func GetSecrets() gin.HandlerFunc {
 		}
 
 		secretHandler(func(controller controllers.SecretController, c *gin.Context) (interface{}, error) {
-			return controller.GetSecrets(request.NamespaceName, limit, page)
+			return controller.GetSecrets(request.NamespaceName, limit, page, pagination.ExtractListQueryFromCtx(c))
 		})(c)
 	}
 }
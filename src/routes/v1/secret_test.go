@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/dana-team/platform-backend/src/controllers"
 	"github.com/dana-team/platform-backend/src/middleware"
+	paginationpkg "github.com/dana-team/platform-backend/src/utils/pagination"
 	"github.com/dana-team/platform-backend/src/utils/testutils"
 	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +32,7 @@ func TestGetSecrets(t *testing.T) {
 	type requestURI struct {
 		namespace        string
 		paginationParams pagination
+		listQuery        types.ListQuery
 	}
 
 	type want struct {
@@ -71,6 +73,48 @@ func TestGetSecrets(t *testing.T) {
 				},
 			},
 		},
+		"ShouldSucceedGettingSecretsSortedByNameDescending": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				listQuery: types.ListQuery{SortBy: paginationpkg.SortByName, Order: paginationpkg.OrderDesc},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.CountKey: 2,
+					testutils.SecretsKey: []types.Secret{
+						{SecretName: testutils.SecretName + "-2", NamespaceName: testNamespaceName, Type: string(corev1.SecretTypeOpaque)},
+						{SecretName: testutils.SecretName + "-1", NamespaceName: testNamespaceName, Type: string(corev1.SecretTypeOpaque)}},
+				},
+			},
+		},
+		"ShouldSucceedGettingSecretsBySearch": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				listQuery: types.ListQuery{Search: "-2"},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				response: map[string]interface{}{
+					testutils.CountKey: 1,
+					testutils.SecretsKey: []types.Secret{
+						{SecretName: testutils.SecretName + "-2", NamespaceName: testNamespaceName, Type: string(corev1.SecretTypeOpaque)}},
+				},
+			},
+		},
+		"ShouldFailGettingSecretsWithInvalidOrder": {
+			requestURI: requestURI{
+				namespace: testNamespaceName,
+				listQuery: types.ListQuery{SortBy: paginationpkg.SortByName, Order: testutils.Unknown},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				response: map[string]interface{}{
+					testutils.ErrorKey:  "invalid request, Key: 'ListQuery.Order' Error:Field validation for 'Order' failed on the 'oneof' tag",
+					testutils.ReasonKey: metav1.StatusReasonBadRequest,
+				},
+			},
+		},
 	}
 
 	setup()
@@ -89,6 +133,8 @@ func TestGetSecrets(t *testing.T) {
 				params.Add(middleware.PageCtxKey, test.requestURI.paginationParams.page)
 			}
 
+			addListQueryParams(params, test.requestURI.listQuery)
+
 			baseURI := fmt.Sprintf("/v1/namespaces/%s/secrets", test.requestURI.namespace)
 			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s?%s", baseURI, params.Encode()), nil)
 			assert.NoError(t, err)
@@ -70,13 +70,27 @@ func setupNamespaceRoutes(v1 *gin.RouterGroup, tokenProvider auth.TokenProvider,
 		cappGroup.POST("", CreateCapp())
 		cappGroup.GET("/:cappName", GetCapp())
 		cappGroup.PUT("/:cappName", UpdateCapp())
+		cappGroup.PATCH("/:cappName", PatchCapp())
 		cappGroup.PUT("/:cappName/state", EditCappState())
 		cappGroup.GET("/:cappName/state", GetCappState())
+		cappGroup.GET("/:cappName/traffic", GetCappTraffic())
+		cappGroup.PUT("/:cappName/traffic", UpdateCappTraffic())
+		cappGroup.GET("/:cappName/history", GetCappHistory())
+		cappGroup.POST("/:cappName/rollback", RollbackCapp())
+		cappGroup.POST("/:cappName/restart", RestartCapp())
+		cappGroup.POST("/:cappName/deploy", DeployCapp())
+		cappGroup.POST("/:cappName/rollout", StartCappRollout())
+		cappGroup.GET("/:cappName/rollout", GetCappRollout())
+		cappGroup.POST("/:cappName/rollout/abort", AbortCappRollout())
+		cappGroup.POST("/:cappName/rollout/resume", ResumeCappRollout())
+		cappGroup.GET("/:cappName/portforward", PortForwardCapp())
 		cappGroup.DELETE("/:cappName", DeleteCapp())
 
 		getDns := cappGroup.Group("")
 		getDns.Use(middleware.ClusterMiddleware())
 		getDns.GET("/:cappName/dns", GetCappDNS())
+		getDns.GET("/:cappName/diagnostics", GetCappDiagnostics())
+		getDns.GET("/:cappName/metrics", GetCappMetrics())
 	}
 
 	cappRevisionGroup := namespacesGroup.Group("/:namespaceName/capps/:cappName/capprevisions")
@@ -87,6 +101,7 @@ func setupNamespaceRoutes(v1 *gin.RouterGroup, tokenProvider auth.TokenProvider,
 		getCappRevisions.GET("", GetCappRevisions())
 
 		cappRevisionGroup.GET("/:cappRevisionName", GetCappRevision())
+		cappRevisionGroup.GET("/:cappRevisionName/diff", GetCappRevisionDiff())
 	}
 
 	usersGroup := namespacesGroup.Group("/:namespaceName/users")
@@ -109,11 +124,15 @@ func setupNamespaceRoutes(v1 *gin.RouterGroup, tokenProvider auth.TokenProvider,
 	containersGroup := namespacesGroup.Group("/:namespaceName/pods/:podName/containers")
 	{
 		containersGroup.GET("", GetPodsContainers())
+		containersGroup.GET("/:containerName", GetPodContainer())
+		containersGroup.GET("/:containerName/exec", ExecInContainer())
 	}
 
 	podsGroup := namespacesGroup.Group("/:namespaceName/capps/:cappName/pods")
 	podsGroup.Use(middleware.ClusterMiddleware())
 	{
+		podsGroup.GET("/:podName", GetPod())
+		podsGroup.DELETE("/:podName", DeletePod())
 		podsGroup.Use(middleware.PaginationMiddleware()).GET("", GetPods())
 	}
 
@@ -123,6 +142,23 @@ func setupNamespaceRoutes(v1 *gin.RouterGroup, tokenProvider auth.TokenProvider,
 		logsGroup.GET("/capp/:cappName/logs", GetCappLogs()).Use(middleware.ClusterMiddleware())
 	}
 
+	watchGroup := namespacesGroup.Group("/:namespaceName/watch/capps")
+	{
+		watchGroup.GET("", WatchCapps())
+		watchGroup.GET("/:cappName", WatchCapp())
+	}
+
+	eventsGroup := namespacesGroup.Group("/:namespaceName")
+	{
+		getEvents := eventsGroup.Group("")
+		getEvents.Use(middleware.PaginationMiddleware())
+		getEvents.GET("/events", GetNamespaceEvents())
+		getEvents.GET("/capps/:cappName/events", GetCappEvents())
+
+		eventsGroup.GET("/watch/events", WatchNamespaceEvents())
+		eventsGroup.GET("/watch/capps/:cappName/events", WatchCappEvents())
+	}
+
 	serviceAccountsGroup := namespacesGroup.Group("/:namespaceName/serviceaccounts")
 	{
 		serviceAccountsGroup.GET("/:serviceAccountName/token", GetToken())
@@ -146,6 +182,23 @@ func setupClustersRoutes(v1 *gin.RouterGroup, tokenProvider auth.TokenProvider,
 			logsGroup.GET("/capp/:cappName/logs", GetCappLogs())
 		}
 
+		watchGroup := namespacesGroup.Group("/:namespaceName/watch/capps")
+		{
+			watchGroup.GET("", WatchCapps())
+			watchGroup.GET("/:cappName", WatchCapp())
+		}
+
+		eventsGroup := namespacesGroup.Group("/:namespaceName")
+		{
+			getEvents := eventsGroup.Group("")
+			getEvents.Use(middleware.PaginationMiddleware())
+			getEvents.GET("/events", GetNamespaceEvents())
+			getEvents.GET("/capps/:cappName/events", GetCappEvents())
+
+			eventsGroup.GET("/watch/events", WatchNamespaceEvents())
+			eventsGroup.GET("/watch/capps/:cappName/events", WatchCappEvents())
+		}
+
 		cappRevisionGroup := namespacesGroup.Group("/:namespaceName/capprevisions")
 		{
 			getCappRevisions := cappRevisionGroup.Group("")
@@ -153,16 +206,26 @@ func setupClustersRoutes(v1 *gin.RouterGroup, tokenProvider auth.TokenProvider,
 			getCappRevisions.GET("", GetCappRevisions())
 
 			cappRevisionGroup.GET("/:cappRevisionName", GetCappRevision())
+			cappRevisionGroup.GET("/:cappRevisionName/diff", GetCappRevisionDiff())
 		}
 
 		containersGroup := namespacesGroup.Group("/:namespaceName/pods/:podName/containers")
 		{
 			containersGroup.GET("", GetPodsContainers())
+			containersGroup.GET("/:containerName", GetPodContainer())
+			containersGroup.GET("/:containerName/exec", ExecInContainer())
 		}
 
 		podsGroup := namespacesGroup.Group("/:namespaceName/capps/:cappName/pods")
 		{
-			podsGroup.GET("", GetPods()).Use(middleware.PaginationMiddleware())
+			podsGroup.GET("/:podName", GetPod())
+			podsGroup.DELETE("/:podName", DeletePod())
+			podsGroup.Use(middleware.PaginationMiddleware()).GET("", GetPods())
+		}
+
+		portForwardGroup := namespacesGroup.Group("/:namespaceName/capps/:cappName")
+		{
+			portForwardGroup.GET("/portforward", PortForwardCapp())
 		}
 	}
 }
@@ -3,12 +3,17 @@ package v1
 import (
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
 	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
 	dnsrecordv1alpha1 "github.com/dana-team/provider-dns/apis/record/v1alpha1"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"net/url"
 	"os"
 	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
 	runtimeFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -17,6 +22,8 @@ import (
 
 const (
 	cluster = "test-cluster"
+	// unreachableHost is the API server of the rest config in the context, so that requests sent with it fail fast.
+	unreachableHost = "http://127.0.0.1:1"
 )
 
 var (
@@ -24,6 +31,7 @@ var (
 	fakeClient *fake.Clientset
 	dynClient  runtimeClient.WithWatch
 	token      string
+	restConfig = &rest.Config{Host: unreachableHost}
 )
 
 func TestMain(m *testing.M) {
@@ -46,7 +54,9 @@ func setupRouter(logger *zap.Logger) *gin.Engine {
 		c.Set(middleware.KubeClientCtxKey, fakeClient)
 		c.Set(middleware.DynamicClientCtxKey, dynClient)
 		c.Set(middleware.TokenCtxKey, token)
+		c.Set(middleware.RestConfigCtxKey, restConfig)
 		c.Set(middleware.ClusterCtxKey, cluster)
+		c.Set(middleware.UsernameCtxKey, testutils.Username)
 		c.Next()
 	})
 
@@ -62,5 +72,29 @@ func setupScheme() *runtime.Scheme {
 	schema := scheme.Scheme
 	_ = cappv1alpha1.AddToScheme(schema)
 	_ = dnsrecordv1alpha1.AddToScheme(schema)
+	_ = metricsv1beta1.AddToScheme(schema)
 	return schema
 }
+
+// addListQueryParams adds the non-empty cursor, sorting and filtering parameters to the query params.
+func addListQueryParams(params url.Values, listQuery types.ListQuery) {
+	if listQuery.Continue != "" {
+		params.Add(testutils.ContinueKey, listQuery.Continue)
+	}
+
+	if listQuery.SortBy != "" {
+		params.Add(testutils.SortByKey, listQuery.SortBy)
+	}
+
+	if listQuery.Order != "" {
+		params.Add(testutils.OrderKey, listQuery.Order)
+	}
+
+	if listQuery.Search != "" {
+		params.Add(testutils.SearchKey, listQuery.Search)
+	}
+
+	if listQuery.FieldSelector != "" {
+		params.Add(testutils.FieldSelectorKey, listQuery.FieldSelector)
+	}
+}
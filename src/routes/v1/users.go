@@ -94,7 +94,7 @@ func GetUsers() gin.HandlerFunc {
 		}
 
 		usersHandler(func(controller controllers.UserController, c *gin.Context) (interface{}, error) {
-			return controller.GetUsers(namespace.NamespaceName, limit, page)
+			return controller.GetUsers(namespace.NamespaceName, limit, page, pagination.ExtractListQueryFromCtx(c))
 		})(c)
 	}
 }
@@ -30,8 +30,69 @@ type UpdateCapp struct {
 	Spec        cappv1alpha1.CappSpec `json:"spec"`
 }
 
+type PatchCappRequest struct {
+	PatchType        string
+	Patch            []byte
+	ResourceVersions []string
+}
+
+type RollbackCappRequest struct {
+	RevisionName   string `json:"revisionName"`
+	RevisionNumber int    `json:"revisionNumber" binding:"min=0"`
+}
+
+type RollbackCappResponse struct {
+	RevisionName   string `json:"revisionName"`
+	RevisionNumber int    `json:"revisionNumber"`
+	Capp           Capp   `json:"capp"`
+}
+
+type RestartCappResponse struct {
+	RestartedAt string `json:"restartedAt"`
+	Capp        Capp   `json:"capp"`
+}
+
+// DeployCappRequest sets the image of a container of a Capp, which is the first container if no name is given,
+// and overrides the given environment variables of the container.
+type DeployCappRequest struct {
+	Image         string     `json:"image" binding:"required"`
+	ContainerName string     `json:"containerName"`
+	Env           []KeyValue `json:"env" binding:"omitempty,dive"`
+	ChangeCause   string     `json:"changeCause"`
+}
+
+type DeployCappResponse struct {
+	ChangeCause string `json:"changeCause"`
+	DeployedBy  string `json:"deployedBy"`
+	DeployedAt  string `json:"deployedAt"`
+	Capp        Capp   `json:"capp"`
+}
+
+// CappHistory is the deployment timeline of a Capp, built from its CappRevisions with the newest first.
+type CappHistory struct {
+	CappName  string             `json:"cappName"`
+	Revisions []CappHistoryEntry `json:"revisions"`
+}
+
+type CappHistoryEntry struct {
+	RevisionName   string   `json:"revisionName"`
+	RevisionNumber int      `json:"revisionNumber"`
+	CreatedAt      string   `json:"createdAt"`
+	Images         []string `json:"images"`
+	ChangeCause    string   `json:"changeCause,omitempty"`
+	DeployedBy     string   `json:"deployedBy,omitempty"`
+	DeployedAt     string   `json:"deployedAt,omitempty"`
+	Serving        bool     `json:"serving"`
+}
+
 type CappQuery struct {
 	LabelSelector string `form:"labelSelector"`
+	State         string `form:"state" binding:"omitempty,oneof=enabled disabled"`
+	Site          string `form:"site"`
+}
+
+type CappDryRunQuery struct {
+	DryRun bool `form:"dryRun"`
 }
 
 type CappList struct {
@@ -40,9 +101,22 @@ type CappList struct {
 }
 
 type CappSummary struct {
-	Name   string   `json:"name"`
-	URL    string   `json:"url"`
-	Images []string `json:"images"`
+	Name                string   `json:"name"`
+	URL                 string   `json:"url"`
+	Images              []string `json:"images"`
+	State               string   `json:"state"`
+	ReadyStatus         string   `json:"readyStatus"`
+	ReadyReason         string   `json:"readyReason"`
+	Site                string   `json:"site"`
+	LatestReadyRevision string   `json:"latestReadyRevision"`
+	CreationTimestamp   string   `json:"creationTimestamp"`
+	ScaleMetric         string   `json:"scaleMetric"`
+}
+
+type CappWatchEvent struct {
+	Type   string      `json:"type"`
+	Object interface{} `json:"object,omitempty"`
+	Error  string      `json:"error,omitempty"`
 }
 
 type CappStateReponse struct {
@@ -76,6 +150,57 @@ type GetDNSResponse struct {
 	Records []DNS `json:"records"`
 }
 
+// CappTraffic is the routing table of a Capp as applied by Knative. Targets with a tag have a URL of their own.
+type CappTraffic struct {
+	CappName string          `json:"cappName"`
+	URL      string          `json:"url,omitempty"`
+	Targets  []TrafficTarget `json:"targets"`
+}
+
+type TrafficTarget struct {
+	RevisionName   string `json:"revisionName"`
+	Tag            string `json:"tag,omitempty"`
+	Percent        int64  `json:"percent"`
+	LatestRevision bool   `json:"latestRevision"`
+	URL            string `json:"url,omitempty"`
+}
+
+// UpdateCappTrafficRequest routes the traffic of a Capp to CappRevisions of the Capp. The percentages of the targets must sum to 100.
+type UpdateCappTrafficRequest struct {
+	Targets []CappTrafficTargetRequest `json:"targets" binding:"required,min=1,dive"`
+}
+
+type CappTrafficTargetRequest struct {
+	RevisionName string `json:"revisionName" binding:"required"`
+	Percent      int64  `json:"percent" binding:"min=0,max=100"`
+	Tag          string `json:"tag"`
+}
+
+const (
+	RolloutStateProgressing = "Progressing"
+	RolloutStateCompleted   = "Completed"
+	RolloutStateFailed      = "Failed"
+	RolloutStateAborted     = "Aborted"
+)
+
+// StartCappRolloutRequest starts routing the traffic of a Capp to one of its CappRevisions once the revision is ready.
+type StartCappRolloutRequest struct {
+	RevisionName string `json:"revisionName" binding:"required"`
+}
+
+// CappRollout is the progress of a rollout of a Capp to one of its CappRevisions. The traffic target the Capp had
+// before the rollout is kept so that it can be restored when the rollout fails or is aborted.
+type CappRollout struct {
+	RevisionName        string        `json:"revisionName"`
+	KnativeRevisionName string        `json:"knativeRevisionName"`
+	State               string        `json:"state"`
+	Message             string        `json:"message,omitempty"`
+	PreviousTarget      TrafficTarget `json:"previousTarget"`
+	RestartBaseline     int32         `json:"restartBaseline"`
+	StartedAt           string        `json:"startedAt"`
+	UpdatedAt           string        `json:"updatedAt"`
+}
+
 type DNS struct {
 	Status corev1.ConditionStatus `json:"status"`
 	Name   string                 `json:"name"`
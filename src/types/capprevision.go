@@ -25,9 +25,21 @@ type CappRevisionNamespaceUri struct {
 
 type CappRevisionUri struct {
 	NamespaceName    string `uri:"namespaceName" binding:"required"`
+	CappName         string `uri:"cappName"`
 	CappRevisionName string `uri:"cappRevisionName" binding:"required"`
 }
 
 type CappRevisionQuery struct {
 	LabelSelector string `form:"labelSelector"`
 }
+
+type CappRevisionDiffQuery struct {
+	CompareTo string `form:"compareTo"`
+}
+
+type CappRevisionDiff struct {
+	From    string      `json:"from"`
+	To      string      `json:"to"`
+	Changes []FieldDiff `json:"changes"`
+	Count   int         `json:"count"`
+}
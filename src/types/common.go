@@ -9,8 +9,21 @@ type Metadata struct {
 	Name              string `json:"name" binding:"required"`
 	Namespace         string `json:"namespace"`
 	CreationTimestamp string `json:"creationTimestamp"`
+	// ResourceVersion is not part of the response body, it is returned in the ETag header.
+	ResourceVersion string `json:"-"`
 }
 
 type ListMetadata struct {
 	Count int `json:"count"`
+	// Continue is the cursor of the next page, it can be passed back using the continue query param.
+	Continue           string `json:"continue,omitempty"`
+	HasMore            bool   `json:"hasMore,omitempty"`
+	RemainingItemCount *int64 `json:"remainingItemCount,omitempty"`
+}
+
+type FieldDiff struct {
+	Path     string      `json:"path"`
+	Type     string      `json:"type"`
+	OldValue interface{} `json:"oldValue,omitempty"`
+	NewValue interface{} `json:"newValue,omitempty"`
 }
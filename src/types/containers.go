@@ -1,15 +1,60 @@
 package types
 
+const (
+	ContainerStateWaiting    = "waiting"
+	ContainerStateRunning    = "running"
+	ContainerStateTerminated = "terminated"
+	ContainerStateUnknown    = "unknown"
+)
+
 type GetContainersResponse struct {
 	Containers []Container `json:"containers"`
 	ListMetadata
 }
 
+// Container summarizes the status of a container or an init container of a pod.
 type Container struct {
 	ContainerName string `json:"containerName"`
+	Init          bool   `json:"init,omitempty"`
+	Ready         bool   `json:"ready"`
+	RestartCount  int32  `json:"restartCount"`
+	State         string `json:"state"`
 }
 
 type ContainerRequestUri struct {
 	NamespaceName string `uri:"namespaceName" binding:"required"`
 	PodName       string `uri:"podName" binding:"required"`
 }
+
+type ContainerUri struct {
+	NamespaceName string `uri:"namespaceName" binding:"required"`
+	PodName       string `uri:"podName" binding:"required"`
+	ContainerName string `uri:"containerName" binding:"required"`
+}
+
+// ContainerDetails describes the status and resources of a container or an init container of a pod.
+type ContainerDetails struct {
+	ContainerName string             `json:"containerName"`
+	Image         string             `json:"image"`
+	Init          bool               `json:"init,omitempty"`
+	Ready         bool               `json:"ready"`
+	RestartCount  int32              `json:"restartCount"`
+	State         ContainerState     `json:"state"`
+	LastState     *ContainerState    `json:"lastState,omitempty"`
+	Resources     ContainerResources `json:"resources"`
+}
+
+// ContainerState is the state of a container. The exit code is only set for terminated containers.
+type ContainerState struct {
+	State      string `json:"state"`
+	Reason     string `json:"reason,omitempty"`
+	Message    string `json:"message,omitempty"`
+	ExitCode   *int32 `json:"exitCode,omitempty"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+}
+
+type ContainerResources struct {
+	Requests map[string]string `json:"requests,omitempty"`
+	Limits   map[string]string `json:"limits,omitempty"`
+}
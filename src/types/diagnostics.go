@@ -0,0 +1,33 @@
+package types
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+const (
+	FindingSourceCapp    = "capp"
+	FindingSourceKnative = "knative"
+	FindingSourcePod     = "pod"
+	FindingSourceEvent   = "event"
+	FindingSourceDNS     = "dns"
+	FindingSourceSecret  = "secret"
+)
+
+// CappDiagnostics is the result of the health checks of a Capp. A Capp is healthy if none of its findings is an error.
+type CappDiagnostics struct {
+	CappName string    `json:"cappName"`
+	Healthy  bool      `json:"healthy"`
+	Findings []Finding `json:"findings"`
+}
+
+// Finding is a single issue found while diagnosing a Capp, along with a hint on how to remediate it.
+type Finding struct {
+	Severity    string `json:"severity"`
+	Source      string `json:"source"`
+	Object      string `json:"object,omitempty"`
+	Reason      string `json:"reason"`
+	Message     string `json:"message"`
+	Remediation string `json:"remediation,omitempty"`
+}
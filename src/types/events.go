@@ -0,0 +1,34 @@
+package types
+
+type EventQuery struct {
+	Type string `form:"type" binding:"omitempty,oneof=Normal Warning"`
+}
+
+type EventList struct {
+	Events []Event `json:"events"`
+	ListMetadata
+}
+
+// Event is a Kubernetes event, with the timestamps and count of both core and events.k8s.io events unified.
+type Event struct {
+	Name           string         `json:"name"`
+	Type           string         `json:"type"`
+	Reason         string         `json:"reason"`
+	Message        string         `json:"message"`
+	InvolvedObject InvolvedObject `json:"involvedObject"`
+	Source         string         `json:"source,omitempty"`
+	Count          int32          `json:"count"`
+	FirstTimestamp string         `json:"firstTimestamp,omitempty"`
+	LastTimestamp  string         `json:"lastTimestamp,omitempty"`
+}
+
+type InvolvedObject struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type EventWatchEvent struct {
+	Type   string `json:"type"`
+	Object *Event `json:"object,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
@@ -0,0 +1,25 @@
+package types
+
+const (
+	TerminalMessageStdin  = "stdin"
+	TerminalMessageResize = "resize"
+	TerminalMessageStdout = "stdout"
+	TerminalMessageStderr = "stderr"
+	TerminalMessageExit   = "exit"
+)
+
+// ExecQuery are the query params of an exec session. The command may be repeated to pass arguments.
+type ExecQuery struct {
+	Command []string `form:"command"`
+	TTY     *bool    `form:"tty"`
+}
+
+// TerminalMessage is a message of an exec session. Clients send stdin and resize messages,
+// and the server sends stdout, stderr and a final exit message.
+type TerminalMessage struct {
+	Type string `json:"type"`
+	Data string `json:"data,omitempty"`
+	Cols uint16 `json:"cols,omitempty"`
+	Rows uint16 `json:"rows,omitempty"`
+	Code *int   `json:"code,omitempty"`
+}
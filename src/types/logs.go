@@ -0,0 +1,73 @@
+package types
+
+import (
+	"io"
+	"time"
+)
+
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+
+	LogFrameTypeLog     = "log"
+	LogFrameTypeControl = "control"
+	LogFrameTypeError   = "error"
+
+	LogControlEventEnd       = "end"
+	LogControlEventCancelled = "cancelled"
+
+	// LogStreamCombined is the stream of every log line, since the Kubernetes logs API merges stdout and stderr.
+	LogStreamCombined = "combined"
+)
+
+// LogLine is a single line of a container log, tagged with the pod and container it was read from.
+type LogLine struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Line      string `json:"line"`
+}
+
+// LogOptions are the query params used to select which part of a container log is streamed.
+type LogOptions struct {
+	Previous     bool       `form:"previous"`
+	Follow       *bool      `form:"follow"`
+	TailLines    *int64     `form:"tailLines" binding:"omitempty,min=0"`
+	SinceSeconds *int64     `form:"sinceSeconds" binding:"omitempty,min=1,excluded_with=SinceTime"`
+	SinceTime    *time.Time `form:"sinceTime"`
+	Timestamps   bool       `form:"timestamps"`
+	LimitBytes   *int64     `form:"limitBytes" binding:"omitempty,min=1"`
+	Format       string     `form:"format" binding:"omitempty,oneof=text json"`
+	Include      string     `form:"include"`
+	Exclude      string     `form:"exclude"`
+	Level        string     `form:"level" binding:"omitempty,oneof=trace debug info warn error fatal"`
+}
+
+// LogStream is the log stream of a single container, along with the pod and container it is read from.
+type LogStream struct {
+	io.ReadCloser
+	Pod       string
+	Container string
+}
+
+// LogFrame is a log line sent as a structured frame when the json format is requested.
+type LogFrame struct {
+	Type      string `json:"type"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Stream    string `json:"stream"`
+	Line      string `json:"line"`
+}
+
+// LogControlFrame tells a client using the json format that the stream ended or was cancelled.
+type LogControlFrame struct {
+	Type    string `json:"type"`
+	Event   string `json:"event"`
+	Message string `json:"message,omitempty"`
+}
+
+// LogErrorFrame tells a client using the json format that the stream failed.
+type LogErrorFrame struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
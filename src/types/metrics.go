@@ -0,0 +1,54 @@
+package types
+
+import "time"
+
+type MetricsQuery struct {
+	Range time.Duration `form:"range" binding:"omitempty,min=1m"`
+	Step  time.Duration `form:"step" binding:"omitempty,min=1s"`
+}
+
+// CappMetrics is the resource usage of the pods of a Capp. The requests and limits are those of a single pod,
+// as set in the configuration of the Capp, while the usage is the total usage of all of its pods.
+type CappMetrics struct {
+	CappName string          `json:"cappName"`
+	Requests Resources       `json:"requests"`
+	Limits   Resources       `json:"limits"`
+	Usage    Resources       `json:"usage"`
+	Pods     []PodMetrics    `json:"pods"`
+	History  *MetricsHistory `json:"history,omitempty"`
+}
+
+// Resources is an amount of CPU in millicores and of memory in bytes. A zero amount of a request or limit means it is not set.
+type Resources struct {
+	CPU    int64 `json:"cpu"`
+	Memory int64 `json:"memory"`
+}
+
+type PodMetrics struct {
+	Name       string             `json:"name"`
+	Timestamp  string             `json:"timestamp"`
+	Window     string             `json:"window"`
+	Usage      Resources          `json:"usage"`
+	Containers []ContainerMetrics `json:"containers"`
+}
+
+type ContainerMetrics struct {
+	Name     string    `json:"name"`
+	Requests Resources `json:"requests"`
+	Limits   Resources `json:"limits"`
+	Usage    Resources `json:"usage"`
+}
+
+// MetricsHistory is the total usage of the pods of a Capp over time, as returned by the metrics history backend.
+type MetricsHistory struct {
+	Start  string          `json:"start"`
+	End    string          `json:"end"`
+	Step   string          `json:"step"`
+	CPU    []MetricsSample `json:"cpu"`
+	Memory []MetricsSample `json:"memory"`
+}
+
+type MetricsSample struct {
+	Timestamp string  `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
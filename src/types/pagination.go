@@ -18,3 +18,12 @@ type PaginationParams struct {
 	Limit int `form:"limit,omitempty" binding:"min=0"`
 	Page  int `form:"page,default=1,omitempty" binding:"min=1"`
 }
+
+// ListQuery holds the cursor, sorting and filtering parameters that are applied to every paginated list.
+type ListQuery struct {
+	Continue      string `form:"continue"`
+	SortBy        string `form:"sortBy" binding:"omitempty,oneof=name creationTimestamp"`
+	Order         string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Search        string `form:"search"`
+	FieldSelector string `form:"fieldSelector"`
+}
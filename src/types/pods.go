@@ -5,11 +5,56 @@ type GetPodsResponse struct {
 	ListMetadata
 }
 
+// Pod summarizes the status of a pod of a Capp. Restarts is the total restart count of its containers.
 type Pod struct {
-	PodName string `json:"podName"`
+	PodName  string `json:"podName"`
+	Phase    string `json:"phase"`
+	Ready    bool   `json:"ready"`
+	Restarts int32  `json:"restarts"`
 }
 
 type PodRequestUri struct {
 	NamespaceName string `uri:"namespaceName" binding:"required"`
 	CappName      string `uri:"cappName" binding:"required"`
 }
+
+type PodUri struct {
+	NamespaceName string `uri:"namespaceName" binding:"required"`
+	CappName      string `uri:"cappName" binding:"required"`
+	PodName       string `uri:"podName" binding:"required"`
+}
+
+// PodDetails describes the status of a pod of a Capp, along with the status of its init containers and containers.
+type PodDetails struct {
+	PodName        string             `json:"podName"`
+	Phase          string             `json:"phase"`
+	Ready          bool               `json:"ready"`
+	Reason         string             `json:"reason,omitempty"`
+	Message        string             `json:"message,omitempty"`
+	NodeName       string             `json:"nodeName,omitempty"`
+	HostIP         string             `json:"hostIP,omitempty"`
+	PodIPs         []string           `json:"podIPs,omitempty"`
+	StartTime      string             `json:"startTime,omitempty"`
+	Revision       string             `json:"revision,omitempty"`
+	QOSClass       string             `json:"qosClass,omitempty"`
+	Conditions     []PodCondition     `json:"conditions"`
+	InitContainers []ContainerDetails `json:"initContainers,omitempty"`
+	Containers     []ContainerDetails `json:"containers"`
+}
+
+type PodCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// DeletePodQuery are the query params of a pod deletion. A grace period of 0 deletes the pod immediately.
+type DeletePodQuery struct {
+	GracePeriodSeconds *int64 `form:"gracePeriodSeconds" binding:"omitempty,min=0"`
+}
+
+type DeletePodResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,7 @@
+package types
+
+// PortForwardQuery are the query params of a port-forward session. If the pod name is not set, the first pod of the Capp is used.
+type PortForwardQuery struct {
+	Port    int    `form:"port" binding:"required,min=1,max=65535"`
+	PodName string `form:"podName"`
+}
@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+
+	"github.com/dana-team/platform-backend/src/types"
+)
+
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+)
+
+var plainJSONKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// DiffJSON compares the JSON representations of two objects and returns
+// the fields that were added, removed or changed, sorted by their JSON path.
+func DiffJSON(from, to interface{}) ([]types.FieldDiff, error) {
+	fromValue, err := toJSONValue(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toValue, err := toJSONValue(to)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := []types.FieldDiff{}
+	diffValues("", fromValue, toValue, &diffs)
+
+	return diffs, nil
+}
+
+// toJSONValue converts an object to its generic JSON representation.
+func toJSONValue(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// diffValues recursively compares two JSON values and appends the differences to diffs.
+func diffValues(path string, from, to interface{}, diffs *[]types.FieldDiff) {
+	switch fromValue := from.(type) {
+	case map[string]interface{}:
+		if toValue, ok := to.(map[string]interface{}); ok {
+			diffMaps(path, fromValue, toValue, diffs)
+			return
+		}
+	case []interface{}:
+		if toValue, ok := to.([]interface{}); ok {
+			diffSlices(path, fromValue, toValue, diffs)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*diffs = append(*diffs, types.FieldDiff{Path: path, Type: DiffChanged, OldValue: from, NewValue: to})
+	}
+}
+
+// diffMaps compares two JSON objects key by key.
+func diffMaps(path string, from, to map[string]interface{}, diffs *[]types.FieldDiff) {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := joinJSONPath(path, key)
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+
+		switch {
+		case !inTo:
+			*diffs = append(*diffs, types.FieldDiff{Path: keyPath, Type: DiffRemoved, OldValue: fromValue})
+		case !inFrom:
+			*diffs = append(*diffs, types.FieldDiff{Path: keyPath, Type: DiffAdded, NewValue: toValue})
+		default:
+			diffValues(keyPath, fromValue, toValue, diffs)
+		}
+	}
+}
+
+// diffSlices compares two JSON arrays index by index.
+func diffSlices(path string, from, to []interface{}, diffs *[]types.FieldDiff) {
+	for i := 0; i < len(from) || i < len(to); i++ {
+		indexPath := fmt.Sprintf("%s[%d]", path, i)
+
+		switch {
+		case i >= len(to):
+			*diffs = append(*diffs, types.FieldDiff{Path: indexPath, Type: DiffRemoved, OldValue: from[i]})
+		case i >= len(from):
+			*diffs = append(*diffs, types.FieldDiff{Path: indexPath, Type: DiffAdded, NewValue: to[i]})
+		default:
+			diffValues(indexPath, from[i], to[i], diffs)
+		}
+	}
+}
+
+// joinJSONPath appends a key to a JSON path, using the bracket notation
+// for keys which contain characters such as dots or slashes.
+func joinJSONPath(path, key string) string {
+	if !plainJSONKey.MatchString(key) {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
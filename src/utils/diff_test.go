@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffJSON(t *testing.T) {
+	type args struct {
+		from interface{}
+		to   interface{}
+	}
+
+	cases := map[string]struct {
+		args args
+		want []types.FieldDiff
+	}{
+		"ShouldReturnNoChangesForEqualObjects": {
+			args: args{
+				from: map[string]interface{}{"name": "capp", "replicas": 1},
+				to:   map[string]interface{}{"name": "capp", "replicas": 1},
+			},
+			want: []types.FieldDiff{},
+		},
+		"ShouldReturnChangedField": {
+			args: args{
+				from: map[string]interface{}{"name": "capp"},
+				to:   map[string]interface{}{"name": "other"},
+			},
+			want: []types.FieldDiff{
+				{Path: "name", Type: DiffChanged, OldValue: "capp", NewValue: "other"},
+			},
+		},
+		"ShouldReturnAddedAndRemovedKeysSortedByPath": {
+			args: args{
+				from: map[string]interface{}{"b": "removed", "c": "same"},
+				to:   map[string]interface{}{"a": "added", "c": "same"},
+			},
+			want: []types.FieldDiff{
+				{Path: "a", Type: DiffAdded, NewValue: "added"},
+				{Path: "b", Type: DiffRemoved, OldValue: "removed"},
+			},
+		},
+		"ShouldReturnChangesOfNestedObjects": {
+			args: args{
+				from: map[string]interface{}{"spec": map[string]interface{}{"route": map[string]interface{}{"hostname": "a.com", "timeout": 30}}},
+				to:   map[string]interface{}{"spec": map[string]interface{}{"route": map[string]interface{}{"hostname": "b.com"}}},
+			},
+			want: []types.FieldDiff{
+				{Path: "spec.route.hostname", Type: DiffChanged, OldValue: "a.com", NewValue: "b.com"},
+				{Path: "spec.route.timeout", Type: DiffRemoved, OldValue: float64(30)},
+			},
+		},
+		"ShouldReturnChangesOfArraysByIndex": {
+			args: args{
+				from: map[string]interface{}{"containers": []interface{}{map[string]interface{}{"image": "nginx:1.26"}}},
+				to: map[string]interface{}{"containers": []interface{}{
+					map[string]interface{}{"image": "nginx:1.27"},
+					map[string]interface{}{"image": "busybox"},
+				}},
+			},
+			want: []types.FieldDiff{
+				{Path: "containers[0].image", Type: DiffChanged, OldValue: "nginx:1.26", NewValue: "nginx:1.27"},
+				{Path: "containers[1]", Type: DiffAdded, NewValue: map[string]interface{}{"image": "busybox"}},
+			},
+		},
+		"ShouldReturnRemovedArrayElements": {
+			args: args{
+				from: map[string]interface{}{"args": []interface{}{"--port", "8080"}},
+				to:   map[string]interface{}{"args": []interface{}{"--port"}},
+			},
+			want: []types.FieldDiff{
+				{Path: "args[1]", Type: DiffRemoved, OldValue: "8080"},
+			},
+		},
+		"ShouldReturnChangedFieldWhenTypeChanges": {
+			args: args{
+				from: map[string]interface{}{"value": []interface{}{"a"}},
+				to:   map[string]interface{}{"value": "a"},
+			},
+			want: []types.FieldDiff{
+				{Path: "value", Type: DiffChanged, OldValue: []interface{}{"a"}, NewValue: "a"},
+			},
+		},
+		"ShouldUseBracketNotationForKeysWithSpecialCharacters": {
+			args: args{
+				from: map[string]interface{}{"labels": map[string]interface{}{"rcs.dana.io/cappName": "a"}},
+				to:   map[string]interface{}{"labels": map[string]interface{}{"rcs.dana.io/cappName": "b"}},
+			},
+			want: []types.FieldDiff{
+				{Path: `labels["rcs.dana.io/cappName"]`, Type: DiffChanged, OldValue: "a", NewValue: "b"},
+			},
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			diffs, err := DiffJSON(test.args.from, test.args.to)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, diffs)
+		})
+	}
+}
+
+func TestDiffJSONWithUnmarshalableObject(t *testing.T) {
+	_, err := DiffJSON(make(chan int), nil)
+	assert.Error(t, err)
+}
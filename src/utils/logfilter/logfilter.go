@@ -0,0 +1,191 @@
+package logfilter
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	LevelTrace = "trace"
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+	LevelFatal = "fatal"
+
+	timestampSeparator = " "
+)
+
+const (
+	ErrInvalidIncludePattern = "invalid include pattern"
+	ErrInvalidExcludePattern = "invalid exclude pattern"
+	ErrInvalidLevel          = "invalid level %q"
+)
+
+// levelKeys are the keys holding the level of a line in common JSON log formats.
+var levelKeys = []string{"level", "lvl", "severity", "log.level", "levelname", "loglevel"}
+
+// levelRanks orders the level names used by common logging libraries from the least to the most severe.
+var levelRanks = map[string]int{
+	LevelTrace:    1,
+	LevelDebug:    2,
+	LevelInfo:     3,
+	"information": 3,
+	"notice":      3,
+	LevelWarn:     4,
+	"warning":     4,
+	LevelError:    5,
+	"err":         5,
+	"critical":    6,
+	"crit":        6,
+	"alert":       6,
+	"emergency":   6,
+	"dpanic":      6,
+	"panic":       6,
+	LevelFatal:    6,
+}
+
+// numericLevelRanks maps the numeric levels of pino and bunyan onto the level ranks.
+var numericLevelRanks = map[float64]int{10: 1, 20: 2, 30: 3, 40: 4, 50: 5, 60: 6}
+
+// LogFilter selects log lines by grep-style include and exclude patterns and by a minimum log level.
+type LogFilter struct {
+	include  *regexp.Regexp
+	exclude  *regexp.Regexp
+	minLevel int
+}
+
+// New returns a filter for the given patterns and minimum level. Empty values are ignored,
+// and nil is returned if there is nothing to filter by.
+func New(include, exclude, level string) (*LogFilter, error) {
+	if include == "" && exclude == "" && level == "" {
+		return nil, nil
+	}
+
+	filter := &LogFilter{}
+	var err error
+	if include != "" {
+		if filter.include, err = regexp.Compile(include); err != nil {
+			return nil, fmt.Errorf("%s: %v", ErrInvalidIncludePattern, err)
+		}
+	}
+
+	if exclude != "" {
+		if filter.exclude, err = regexp.Compile(exclude); err != nil {
+			return nil, fmt.Errorf("%s: %v", ErrInvalidExcludePattern, err)
+		}
+	}
+
+	if level != "" {
+		rank, ok := levelRanks[strings.ToLower(level)]
+		if !ok {
+			return nil, fmt.Errorf(ErrInvalidLevel, level)
+		}
+		filter.minLevel = rank
+	}
+
+	return filter, nil
+}
+
+// Matches returns true if the line should be streamed. A line matches if it matches the include pattern,
+// does not match the exclude pattern and its level is at least the minimum level.
+// The timestamp prefixing the line, if any, is ignored so that patterns match the same lines with or without it.
+// Lines whose level can not be detected, such as stack traces, are not filtered by level.
+func (f *LogFilter) Matches(line string) bool {
+	line = stripTimestamp(line)
+	if f.include != nil && !f.include.MatchString(line) {
+		return false
+	}
+
+	if f.exclude != nil && f.exclude.MatchString(line) {
+		return false
+	}
+
+	if f.minLevel == 0 {
+		return true
+	}
+
+	rank, ok := detectLevel(line)
+	return !ok || rank >= f.minLevel
+}
+
+// stripTimestamp returns the line without the timestamp added by the API server, if it is prefixed by one.
+func stripTimestamp(line string) string {
+	if timestamp, content, found := strings.Cut(line, timestampSeparator); found {
+		if _, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+			return content
+		}
+	}
+
+	return line
+}
+
+// detectLevel returns the rank of the level of a JSON log line.
+func detectLevel(line string) (int, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "{") {
+		return 0, false
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return 0, false
+	}
+
+	for _, key := range levelKeys {
+		switch level := fields[key].(type) {
+		case string:
+			if rank, ok := levelRanks[strings.ToLower(level)]; ok {
+				return rank, true
+			}
+		case float64:
+			if rank, ok := numericLevelRanks[level]; ok {
+				return rank, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// Stream returns a stream holding only the lines of the given stream that match the filter.
+// Closing the returned stream closes the given stream.
+func (f *LogFilter) Stream(stream io.ReadCloser) io.ReadCloser {
+	return &filteredStream{
+		ReadCloser: stream,
+		reader:     bufio.NewReader(stream),
+		filter:     f,
+	}
+}
+
+// filteredStream reads whole lines from the underlying stream and skips the ones that do not match the filter.
+type filteredStream struct {
+	io.ReadCloser
+	reader  *bufio.Reader
+	filter  *LogFilter
+	pending []byte
+	err     error
+}
+
+func (s *filteredStream) Read(p []byte) (int, error) {
+	for len(s.pending) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+
+		line, err := s.reader.ReadBytes('\n')
+		s.err = err
+		if len(line) > 0 && s.filter.Matches(strings.TrimRight(string(line), "\r\n")) {
+			s.pending = line
+		}
+	}
+
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
@@ -0,0 +1,148 @@
+package logfilter
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	type args struct {
+		include string
+		exclude string
+		level   string
+	}
+	type want struct {
+		isNil       bool
+		errContains string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldReturnNilWithoutFilters": {
+			args: args{},
+			want: want{isNil: true},
+		},
+		"ShouldCreateFilter": {
+			args: args{include: "request-id", exclude: "healthz", level: LevelWarn},
+			want: want{},
+		},
+		"ShouldFailWithInvalidIncludePattern": {
+			args: args{include: "("},
+			want: want{isNil: true, errContains: ErrInvalidIncludePattern},
+		},
+		"ShouldFailWithInvalidExcludePattern": {
+			args: args{exclude: "["},
+			want: want{isNil: true, errContains: ErrInvalidExcludePattern},
+		},
+		"ShouldFailWithInvalidLevel": {
+			args: args{level: "verbose"},
+			want: want{isNil: true, errContains: `invalid level "verbose"`},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			filter, err := New(tc.args.include, tc.args.exclude, tc.args.level)
+			if tc.want.errContains != "" {
+				assert.ErrorContains(t, err, tc.want.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.want.isNil, filter == nil)
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	type args struct {
+		include string
+		exclude string
+		level   string
+		line    string
+	}
+
+	cases := map[string]struct {
+		args args
+		want bool
+	}{
+		"ShouldMatchIncludedLine": {
+			args: args{include: "req-[0-9]+", line: "handled req-42 in 3ms"},
+			want: true,
+		},
+		"ShouldNotMatchLineWithoutIncludedPattern": {
+			args: args{include: "req-[0-9]+", line: "handled request in 3ms"},
+			want: false,
+		},
+		"ShouldNotMatchExcludedLine": {
+			args: args{exclude: "GET /healthz", line: "GET /healthz 200"},
+			want: false,
+		},
+		"ShouldNotMatchIncludedAndExcludedLine": {
+			args: args{include: "req-42", exclude: "healthz", line: "req-42 GET /healthz"},
+			want: false,
+		},
+		"ShouldMatchJSONLineAboveLevel": {
+			args: args{level: LevelWarn, line: `{"level":"error","msg":"failed"}`},
+			want: true,
+		},
+		"ShouldNotMatchJSONLineBelowLevel": {
+			args: args{level: LevelWarn, line: `{"level":"info","msg":"started"}`},
+			want: false,
+		},
+		"ShouldMatchLevelAlias": {
+			args: args{level: LevelWarn, line: `{"severity":"WARNING","message":"slow"}`},
+			want: true,
+		},
+		"ShouldMatchNumericLevel": {
+			args: args{level: LevelError, line: `{"level":50,"msg":"failed"}`},
+			want: true,
+		},
+		"ShouldNotMatchNumericLevelBelowLevel": {
+			args: args{level: LevelError, line: `{"level":30,"msg":"started"}`},
+			want: false,
+		},
+		"ShouldDetectLevelAfterTimestamp": {
+			args: args{level: LevelError, line: `2024-01-01T10:00:00.123Z {"lvl":"debug"}`},
+			want: false,
+		},
+		"ShouldMatchAnchoredIncludePatternAfterTimestamp": {
+			args: args{include: "^ERROR", line: "2024-01-01T10:00:00.123456789Z ERROR connection refused"},
+			want: true,
+		},
+		"ShouldNotMatchAnchoredExcludePatternAfterTimestamp": {
+			args: args{exclude: "^GET /healthz", line: "2024-01-01T10:00:00Z GET /healthz 200"},
+			want: false,
+		},
+		"ShouldNotMatchTimestampWithIncludePattern": {
+			args: args{include: "^2024", line: "2024-01-01T10:00:00Z started"},
+			want: false,
+		},
+		"ShouldMatchLineWithoutDetectableLevel": {
+			args: args{level: LevelError, line: "goroutine 1 [running]:"},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			filter, err := New(tc.args.include, tc.args.exclude, tc.args.level)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, filter.Matches(tc.args.line))
+		})
+	}
+}
+
+func TestStream(t *testing.T) {
+	filter, err := New("req-42", "", "")
+	assert.NoError(t, err)
+
+	stream := io.NopCloser(strings.NewReader("start\nreq-42 received\nreq-7 received\nreq-42 done"))
+	content, err := io.ReadAll(filter.Stream(stream))
+	assert.NoError(t, err)
+	assert.Equal(t, "req-42 received\nreq-42 done", string(content))
+}
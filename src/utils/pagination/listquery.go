@@ -0,0 +1,185 @@
+package pagination
+
+import (
+	"fmt"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/middleware"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sort"
+	"strings"
+)
+
+const (
+	SortByName              = "name"
+	SortByCreationTimestamp = "creationTimestamp"
+	OrderAsc                = "asc"
+	OrderDesc               = "desc"
+
+	fieldPathSeparator = "."
+)
+
+const (
+	ErrParsingFieldSelector   = "Could not parse fieldSelector"
+	ErrContinueWithListQuery  = "continue can not be combined with sortBy, search or fieldSelector"
+	ErrContinueWithFilter     = "continue can not be combined with filters which are applied after listing"
+	ErrContinueWithSortedList = "continue can not be used with lists which are sorted after listing"
+	ErrOrderWithoutSortBy     = "order can not be used without sortBy"
+	errUnsupportedListQuery   = "items of type %T can not be sorted or filtered"
+	errConvertingListQueryObj = "could not convert %q to evaluate fieldSelector: %v"
+)
+
+// ExtractListQueryFromCtx retrieves the cursor, sorting and filtering parameters from the context
+func ExtractListQueryFromCtx(c *gin.Context) types.ListQuery {
+	listQuery, exists := c.Get(middleware.ListQueryCtxKey)
+	if !exists {
+		return types.ListQuery{}
+	}
+
+	return listQuery.(types.ListQuery)
+}
+
+// validateListQuery returns a validation error if the list query can not be applied
+func validateListQuery(listQuery types.ListQuery) error {
+	if listQuery.Order != "" && listQuery.SortBy == "" {
+		return customerrors.NewValidationError(ErrOrderWithoutSortBy)
+	}
+
+	return nil
+}
+
+// hasListQuery returns true if the given list query requires the items to be sorted or filtered
+func hasListQuery(listQuery types.ListQuery) bool {
+	return listQuery.SortBy != "" || listQuery.Search != "" || listQuery.FieldSelector != ""
+}
+
+// applyListQuery filters the items by the search term and field selector and then sorts them
+func applyListQuery[T any](items []T, listQuery types.ListQuery) ([]T, error) {
+	fieldSelector, err := fields.ParseSelector(listQuery.FieldSelector)
+	if err != nil {
+		return nil, customerrors.NewValidationError(fmt.Sprintf("%s: %v", ErrParsingFieldSelector, err))
+	}
+
+	search := strings.ToLower(listQuery.Search)
+	var filtered []T
+	for i := range items {
+		object, ok := any(&items[i]).(metav1.Object)
+		if !ok {
+			return nil, fmt.Errorf(errUnsupportedListQuery, items[i])
+		}
+
+		if search != "" && !strings.Contains(strings.ToLower(object.GetName()), search) {
+			continue
+		}
+
+		matches, err := matchesFieldSelector(&items[i], fieldSelector)
+		if err != nil {
+			return nil, fmt.Errorf(errConvertingListQueryObj, object.GetName(), err)
+		}
+
+		if matches {
+			filtered = append(filtered, items[i])
+		}
+	}
+
+	sortItems(filtered, listQuery.SortBy, listQuery.Order)
+	return filtered, nil
+}
+
+// matchesFieldSelector checks whether the fields of the object referenced by the selector match it.
+// Fields are referenced by their JSON path, e.g. metadata.name or status.phase.
+func matchesFieldSelector(object interface{}, fieldSelector fields.Selector) (bool, error) {
+	if fieldSelector.Empty() {
+		return true, nil
+	}
+
+	unstructuredObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return false, err
+	}
+
+	fieldSet := fields.Set{}
+	for _, requirement := range fieldSelector.Requirements() {
+		fieldSet[requirement.Field] = lookupField(unstructuredObject, requirement.Field)
+	}
+
+	return fieldSelector.Matches(fieldSet), nil
+}
+
+// lookupField returns the string representation of the scalar value found at the given path,
+// or an empty string if the path does not exist or does not point to a scalar value
+func lookupField(object map[string]interface{}, path string) string {
+	var current interface{} = object
+	for _, key := range strings.Split(path, fieldPathSeparator) {
+		fieldsMap, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+
+		current, ok = fieldsMap[key]
+		if !ok {
+			return ""
+		}
+	}
+
+	switch value := current.(type) {
+	case string:
+		return value
+	case bool, int64, float64:
+		return fmt.Sprint(value)
+	default:
+		return ""
+	}
+}
+
+// sortItems sorts the items in place by the given field and order. Items are left untouched if sortBy is empty.
+func sortItems[T any](items []T, sortBy, order string) {
+	if sortBy == "" {
+		return
+	}
+
+	less := func(i, j int) bool {
+		first := any(&items[i]).(metav1.Object)
+		second := any(&items[j]).(metav1.Object)
+
+		if sortBy == SortByCreationTimestamp {
+			firstTimestamp, secondTimestamp := first.GetCreationTimestamp(), second.GetCreationTimestamp()
+			if !firstTimestamp.Equal(&secondTimestamp) {
+				return firstTimestamp.Before(&secondTimestamp)
+			}
+		}
+
+		return first.GetName() < second.GetName()
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if order == OrderDesc {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+}
+
+// pageItems returns the items of the specified page with given limit along with the metadata of the page
+func pageItems[T any](items []T, limit, page int) ([]T, types.ListMetadata, error) {
+	start := (page - 1) * limit
+	if start >= len(items) {
+		return nil, types.ListMetadata{}, nil
+	}
+
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	remainingItemCount := int64(len(items) - end)
+	listMetadata := types.ListMetadata{Count: end - start, HasMore: remainingItemCount > 0}
+	if listMetadata.HasMore {
+		listMetadata.RemainingItemCount = &remainingItemCount
+	}
+
+	return items[start:end], listMetadata, nil
+}
@@ -2,11 +2,13 @@ package pagination
 
 import (
 	"fmt"
+	"github.com/dana-team/platform-backend/src/customerrors"
 	"github.com/dana-team/platform-backend/src/middleware"
 	"github.com/dana-team/platform-backend/src/types"
 	"github.com/dana-team/platform-backend/src/utils"
 	"github.com/gin-gonic/gin"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
 )
 
 const (
@@ -16,6 +18,10 @@ const (
 	firstPage = 1
 )
 
+// fetchAllChunkSize is the number of items requested by each list call when all items are fetched, so that
+// the number of calls does not depend on the page limit.
+var fetchAllChunkSize int64 = 500
+
 // buildListOptions builds the ListOptions based on page and limit
 func buildListOptions(limit int64, continueToken string) v1.ListOptions {
 	return v1.ListOptions{
@@ -25,25 +31,56 @@ func buildListOptions(limit int64, continueToken string) v1.ListOptions {
 	}
 }
 
-// FetchPage fetches the specified page with given limit
-func FetchPage[T any](limit, page int, paginator Paginator[types.List[T]]) ([]T, error) {
-	var results []T
-	var continueToken string
-
+// FetchPage fetches the specified page with given limit, or the page following the continue token of the
+// list query when it is set. When the list query requires sorting or filtering, all items are fetched first
+// so that the page is taken from the processed items.
+func FetchPage[T any](limit, page int, paginator Paginator[types.List[T]], listQuery types.ListQuery) ([]T, types.ListMetadata, error) {
 	if limit <= 0 {
-		return nil, fmt.Errorf("limit must be greater than zero")
+		return nil, types.ListMetadata{}, fmt.Errorf("limit must be greater than zero")
+	}
+
+	if err := validateListQuery(listQuery); err != nil {
+		return nil, types.ListMetadata{}, err
+	}
+
+	if hasListQuery(listQuery) {
+		if listQuery.Continue != "" {
+			return nil, types.ListMetadata{}, customerrors.NewValidationError(ErrContinueWithListQuery)
+		}
+
+		items, err := fetchAll(paginator)
+		if err != nil {
+			return nil, types.ListMetadata{}, err
+		}
+
+		items, err = applyListQuery(items, listQuery)
+		if err != nil {
+			return nil, types.ListMetadata{}, err
+		}
+
+		return pageItems(items, limit, page)
+	}
+
+	if listQuery.Continue != "" {
+		list, err := paginator.FetchList(buildListOptions(int64(limit), listQuery.Continue))
+		if err != nil {
+			return nil, types.ListMetadata{}, err
+		}
+
+		return list.Items, buildListMetadata(list), nil
 	}
 
 	// Fetch items until the specified page is reached
+	var continueToken string
 	for currentPage := firstPage; currentPage <= page; currentPage++ {
 		listOptions := buildListOptions(int64(limit), continueToken)
 		list, err := paginator.FetchList(listOptions)
 		if err != nil {
-			return nil, err
+			return nil, types.ListMetadata{}, err
 		}
 
 		if currentPage == page {
-			return list.Items, nil
+			return list.Items, buildListMetadata(list), nil
 		}
 
 		continueToken = list.Continue
@@ -52,7 +89,36 @@ func FetchPage[T any](limit, page int, paginator Paginator[types.List[T]]) ([]T,
 		}
 	}
 
-	return results, nil
+	return nil, types.ListMetadata{}, nil
+}
+
+// buildListMetadata builds the ListMetadata of a single list returned by the API server
+func buildListMetadata[T any](list *types.List[T]) types.ListMetadata {
+	return types.ListMetadata{
+		Count:              len(list.Items),
+		Continue:           list.Continue,
+		HasMore:            list.Continue != "",
+		RemainingItemCount: list.RemainingItemCount,
+	}
+}
+
+// fetchAll fetches all items by following the continue tokens, in chunks of fetchAllChunkSize
+func fetchAll[T any](paginator Paginator[types.List[T]]) ([]T, error) {
+	var items []T
+	var continueToken string
+
+	for {
+		list, err := paginator.FetchList(buildListOptions(fetchAllChunkSize, continueToken))
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, list.Items...)
+		continueToken = list.Continue
+		if continueToken == "" {
+			return items, nil
+		}
+	}
 }
 
 // extractLimitFromCtx retrieves the pagination limit from the Gin context or defaults to an environment variable
@@ -89,3 +155,76 @@ func ExtractPaginationParamsFromCtx(c *gin.Context) (limit, page int, err error)
 
 	return ctxLimit, ctxPage, nil
 }
+
+// FetchSortedPage fetches all items, sorts them using the less function, applies the list query and returns
+// the specified page with given limit. It is used for lists which can only be sorted once all of their items
+// are known. The sortBy of the list query takes precedence over the less function.
+func FetchSortedPage[T any](limit, page int, paginator Paginator[types.List[T]], listQuery types.ListQuery, less func(first, second *T) bool) ([]T, types.ListMetadata, error) {
+	if limit <= 0 {
+		return nil, types.ListMetadata{}, fmt.Errorf("limit must be greater than zero")
+	}
+
+	if err := validateListQuery(listQuery); err != nil {
+		return nil, types.ListMetadata{}, err
+	}
+
+	if listQuery.Continue != "" {
+		return nil, types.ListMetadata{}, customerrors.NewValidationError(ErrContinueWithSortedList)
+	}
+
+	items, err := fetchAll(paginator)
+	if err != nil {
+		return nil, types.ListMetadata{}, err
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return less(&items[i], &items[j])
+	})
+
+	if hasListQuery(listQuery) {
+		items, err = applyListQuery(items, listQuery)
+		if err != nil {
+			return nil, types.ListMetadata{}, err
+		}
+	}
+
+	return pageItems(items, limit, page)
+}
+
+// FetchFilteredPage fetches all items, keeps the ones matching the filter function, applies the list query and
+// returns the specified page with given limit. It is used for lists which are filtered by fields the API server
+// can not select on, so that pages are full and the metadata describes the filtered items.
+func FetchFilteredPage[T any](limit, page int, paginator Paginator[types.List[T]], listQuery types.ListQuery, matches func(item *T) bool) ([]T, types.ListMetadata, error) {
+	if limit <= 0 {
+		return nil, types.ListMetadata{}, fmt.Errorf("limit must be greater than zero")
+	}
+
+	if err := validateListQuery(listQuery); err != nil {
+		return nil, types.ListMetadata{}, err
+	}
+
+	if listQuery.Continue != "" {
+		return nil, types.ListMetadata{}, customerrors.NewValidationError(ErrContinueWithFilter)
+	}
+
+	items, err := fetchAll(paginator)
+	if err != nil {
+		return nil, types.ListMetadata{}, err
+	}
+
+	var filtered []T
+	for i := range items {
+		if matches(&items[i]) {
+			filtered = append(filtered, items[i])
+		}
+	}
+
+	if hasListQuery(listQuery) {
+		filtered, err = applyListQuery(filtered, listQuery)
+		if err != nil {
+			return nil, types.ListMetadata{}, err
+		}
+	}
+
+	return pageItems(filtered, limit, page)
+}
@@ -1,12 +1,17 @@
 package pagination
 
 import (
+	"fmt"
+	"github.com/dana-team/platform-backend/src/customerrors"
 	"github.com/dana-team/platform-backend/src/types"
 	"github.com/dana-team/platform-backend/src/utils"
 	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"os"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -96,6 +101,7 @@ func Test_FetchPage(t *testing.T) {
 		page      int
 		limit     int
 		paginator Paginator[types.List[string]]
+		listQuery types.ListQuery
 	}
 
 	type want struct {
@@ -141,11 +147,24 @@ func Test_FetchPage(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ShouldFailSortingItemsWithoutMetadata": {
+			args: args{
+				limit: 5,
+				page:  1,
+				paginator: &TestPaginator{
+					str: strForPagination,
+				},
+				listQuery: types.ListQuery{SortBy: SortByName},
+			},
+			want: want{
+				err: fmt.Errorf(errUnsupportedListQuery, strForPagination+"-1"),
+			},
+		},
 	}
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			stringsList, err := FetchPage(test.args.limit, test.args.page, test.args.paginator)
+			stringsList, _, err := FetchPage(test.args.limit, test.args.page, test.args.paginator, test.args.listQuery)
 			if test.want.err != nil {
 				assert.Equal(t, test.want.err, err)
 			} else {
@@ -158,6 +177,439 @@ func Test_FetchPage(t *testing.T) {
 	}
 }
 
+// TestPodPaginator returns the pods in chunks of the requested limit, using the index as the continue token.
+// The limits of all list calls are recorded.
+type TestPodPaginator struct {
+	pods   []corev1.Pod
+	limits []int64
+}
+
+func (p *TestPodPaginator) FetchList(listOptions metav1.ListOptions) (*types.List[corev1.Pod], error) {
+	p.limits = append(p.limits, listOptions.Limit)
+	startIndex := 0
+	if listOptions.Continue != "" {
+		startIndex, _ = strconv.Atoi(listOptions.Continue)
+	}
+
+	endIndex := startIndex + int(listOptions.Limit)
+	continueToken := strconv.Itoa(endIndex)
+	if endIndex >= len(p.pods) {
+		endIndex = len(p.pods)
+		continueToken = ""
+	}
+
+	listMeta := metav1.ListMeta{Continue: continueToken}
+	if continueToken != "" {
+		remainingItemCount := int64(len(p.pods) - endIndex)
+		listMeta.RemainingItemCount = &remainingItemCount
+	}
+
+	return &types.List[corev1.Pod]{
+		ListMeta: listMeta,
+		Items:    p.pods[startIndex:endIndex],
+	}, nil
+}
+
+// setFetchAllChunkSize sets the chunk size used when all items are fetched for the duration of a test.
+func setFetchAllChunkSize(t *testing.T, chunkSize int64) {
+	previousChunkSize := fetchAllChunkSize
+	fetchAllChunkSize = chunkSize
+	t.Cleanup(func() { fetchAllChunkSize = previousChunkSize })
+}
+
+func preparePod(name string, creationTimestamp time.Time, phase corev1.PodPhase) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(creationTimestamp)},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func Test_FetchPageWithListQuery(t *testing.T) {
+	setFetchAllChunkSize(t, 2)
+	now := time.Now().Truncate(time.Second)
+	paginator := &TestPodPaginator{
+		pods: []corev1.Pod{
+			preparePod("b-pod", now.Add(-time.Hour), corev1.PodRunning),
+			preparePod("c-pod", now.Add(-2*time.Hour), corev1.PodPending),
+			preparePod("a-pod", now, corev1.PodRunning),
+			preparePod("other", now.Add(-3*time.Hour), corev1.PodFailed),
+		},
+	}
+
+	type args struct {
+		page      int
+		limit     int
+		listQuery types.ListQuery
+	}
+
+	type want struct {
+		names []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldSortByName": {
+			args: args{limit: 10, page: 1, listQuery: types.ListQuery{SortBy: SortByName}},
+			want: want{names: []string{"a-pod", "b-pod", "c-pod", "other"}},
+		},
+		"ShouldSortByNameDescending": {
+			args: args{limit: 10, page: 1, listQuery: types.ListQuery{SortBy: SortByName, Order: OrderDesc}},
+			want: want{names: []string{"other", "c-pod", "b-pod", "a-pod"}},
+		},
+		"ShouldSortByCreationTimestamp": {
+			args: args{limit: 10, page: 1, listQuery: types.ListQuery{SortBy: SortByCreationTimestamp}},
+			want: want{names: []string{"other", "c-pod", "b-pod", "a-pod"}},
+		},
+		"ShouldSortAcrossChunksAndReturnSecondPage": {
+			args: args{limit: 2, page: 2, listQuery: types.ListQuery{SortBy: SortByName}},
+			want: want{names: []string{"c-pod", "other"}},
+		},
+		"ShouldReturnNoItemsForPageOutOfRange": {
+			args: args{limit: 2, page: 3, listQuery: types.ListQuery{SortBy: SortByName}},
+			want: want{names: nil},
+		},
+		"ShouldSearchByName": {
+			args: args{limit: 10, page: 1, listQuery: types.ListQuery{Search: "POD", SortBy: SortByName}},
+			want: want{names: []string{"a-pod", "b-pod", "c-pod"}},
+		},
+		"ShouldFilterByFieldSelector": {
+			args: args{limit: 10, page: 1, listQuery: types.ListQuery{FieldSelector: "status.phase=Running", SortBy: SortByName}},
+			want: want{names: []string{"a-pod", "b-pod"}},
+		},
+		"ShouldFilterByNegatedFieldSelector": {
+			args: args{limit: 10, page: 1, listQuery: types.ListQuery{FieldSelector: "metadata.name!=other,status.phase!=Running"}},
+			want: want{names: []string{"c-pod"}},
+		},
+		"ShouldNotMatchNonExistingField": {
+			args: args{limit: 10, page: 1, listQuery: types.ListQuery{FieldSelector: "spec.nonExisting=value"}},
+			want: want{names: nil},
+		},
+		"ShouldFailWithInvalidFieldSelector": {
+			args: args{limit: 10, page: 1, listQuery: types.ListQuery{FieldSelector: "status.phase"}},
+			want: want{err: customerrors.NewValidationError(fmt.Sprintf("%s: %s", ErrParsingFieldSelector, `invalid selector: 'status.phase'; can't understand 'status.phase'`))},
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			pods, _, err := FetchPage(test.args.limit, test.args.page, paginator, test.args.listQuery)
+			if test.want.err != nil {
+				assert.Equal(t, test.want.err, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			var names []string
+			for _, pod := range pods {
+				names = append(names, pod.Name)
+			}
+			assert.Equal(t, test.want.names, names)
+		})
+	}
+}
+
+func Test_FetchPageListMetadata(t *testing.T) {
+	now := time.Now()
+	paginator := &TestPodPaginator{
+		pods: []corev1.Pod{
+			preparePod("a-pod", now, corev1.PodRunning),
+			preparePod("b-pod", now, corev1.PodRunning),
+			preparePod("c-pod", now, corev1.PodRunning),
+			preparePod("d-pod", now, corev1.PodRunning),
+			preparePod("e-pod", now, corev1.PodRunning),
+		},
+	}
+	remainingItemCount := func(count int64) *int64 { return &count }
+
+	type args struct {
+		page      int
+		limit     int
+		listQuery types.ListQuery
+	}
+
+	type want struct {
+		names        []string
+		listMetadata types.ListMetadata
+		err          error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldReturnContinueTokenOfFirstPage": {
+			args: args{limit: 2, page: 1},
+			want: want{
+				names:        []string{"a-pod", "b-pod"},
+				listMetadata: types.ListMetadata{Count: 2, Continue: "2", HasMore: true, RemainingItemCount: remainingItemCount(3)},
+			},
+		},
+		"ShouldReturnPageFollowingContinueToken": {
+			args: args{limit: 2, page: 1, listQuery: types.ListQuery{Continue: "2"}},
+			want: want{
+				names:        []string{"c-pod", "d-pod"},
+				listMetadata: types.ListMetadata{Count: 2, Continue: "4", HasMore: true, RemainingItemCount: remainingItemCount(1)},
+			},
+		},
+		"ShouldIgnorePageWhenContinueTokenIsSet": {
+			args: args{limit: 2, page: 3, listQuery: types.ListQuery{Continue: "2"}},
+			want: want{
+				names:        []string{"c-pod", "d-pod"},
+				listMetadata: types.ListMetadata{Count: 2, Continue: "4", HasMore: true, RemainingItemCount: remainingItemCount(1)},
+			},
+		},
+		"ShouldReturnLastPageWithoutMore": {
+			args: args{limit: 2, page: 1, listQuery: types.ListQuery{Continue: "4"}},
+			want: want{
+				names:        []string{"e-pod"},
+				listMetadata: types.ListMetadata{Count: 1},
+			},
+		},
+		"ShouldReturnRemainingItemCountOfSortedList": {
+			args: args{limit: 2, page: 1, listQuery: types.ListQuery{SortBy: SortByName, Order: OrderDesc}},
+			want: want{
+				names:        []string{"e-pod", "d-pod"},
+				listMetadata: types.ListMetadata{Count: 2, HasMore: true, RemainingItemCount: remainingItemCount(3)},
+			},
+		},
+		"ShouldFailCombiningContinueWithListQuery": {
+			args: args{limit: 2, page: 1, listQuery: types.ListQuery{Continue: "2", SortBy: SortByName}},
+			want: want{err: customerrors.NewValidationError(ErrContinueWithListQuery)},
+		},
+		"ShouldFailOrderingWithoutSortBy": {
+			args: args{limit: 2, page: 1, listQuery: types.ListQuery{Order: OrderDesc}},
+			want: want{err: customerrors.NewValidationError(ErrOrderWithoutSortBy)},
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			pods, listMetadata, err := FetchPage(test.args.limit, test.args.page, paginator, test.args.listQuery)
+			if test.want.err != nil {
+				assert.Equal(t, test.want.err, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			var names []string
+			for _, pod := range pods {
+				names = append(names, pod.Name)
+			}
+			assert.Equal(t, test.want.names, names)
+			assert.Equal(t, test.want.listMetadata, listMetadata)
+		})
+	}
+}
+
+func Test_FetchSortedPage(t *testing.T) {
+	setFetchAllChunkSize(t, 2)
+	now := time.Now().Truncate(time.Second)
+	paginator := &TestPodPaginator{
+		pods: []corev1.Pod{
+			preparePod("b-pod", now.Add(-time.Hour), corev1.PodRunning),
+			preparePod("c-pod", now.Add(-2*time.Hour), corev1.PodPending),
+			preparePod("a-pod", now, corev1.PodRunning),
+			preparePod("d-pod", now.Add(-3*time.Hour), corev1.PodFailed),
+		},
+	}
+	newestFirst := func(first, second *corev1.Pod) bool {
+		return second.CreationTimestamp.Before(&first.CreationTimestamp)
+	}
+	remainingItemCount := func(count int64) *int64 { return &count }
+
+	type args struct {
+		page      int
+		limit     int
+		listQuery types.ListQuery
+	}
+
+	type want struct {
+		names        []string
+		listMetadata types.ListMetadata
+		err          error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldSortAllItems": {
+			args: args{limit: 10, page: 1},
+			want: want{
+				names:        []string{"a-pod", "b-pod", "c-pod", "d-pod"},
+				listMetadata: types.ListMetadata{Count: 4},
+			},
+		},
+		"ShouldSortAcrossChunksAndReturnFirstPage": {
+			args: args{limit: 3, page: 1},
+			want: want{
+				names:        []string{"a-pod", "b-pod", "c-pod"},
+				listMetadata: types.ListMetadata{Count: 3, HasMore: true, RemainingItemCount: remainingItemCount(1)},
+			},
+		},
+		"ShouldReturnSecondPage": {
+			args: args{limit: 3, page: 2},
+			want: want{
+				names:        []string{"d-pod"},
+				listMetadata: types.ListMetadata{Count: 1},
+			},
+		},
+		"ShouldSortByListQueryInsteadOfLessFunction": {
+			args: args{limit: 10, page: 1, listQuery: types.ListQuery{SortBy: SortByName, Order: OrderDesc}},
+			want: want{
+				names:        []string{"d-pod", "c-pod", "b-pod", "a-pod"},
+				listMetadata: types.ListMetadata{Count: 4},
+			},
+		},
+		"ShouldFilterAndKeepLessFunctionOrder": {
+			args: args{limit: 10, page: 1, listQuery: types.ListQuery{Search: "pod", FieldSelector: "status.phase=Running"}},
+			want: want{
+				names:        []string{"a-pod", "b-pod"},
+				listMetadata: types.ListMetadata{Count: 2},
+			},
+		},
+		"ShouldFailCombiningContinueWithSortedList": {
+			args: args{limit: 2, page: 1, listQuery: types.ListQuery{Continue: "2"}},
+			want: want{err: customerrors.NewValidationError(ErrContinueWithSortedList)},
+		},
+		"ShouldFailWithOrderWithoutSortBy": {
+			args: args{limit: 2, page: 1, listQuery: types.ListQuery{Order: OrderDesc}},
+			want: want{err: customerrors.NewValidationError(ErrOrderWithoutSortBy)},
+		},
+		"ShouldFailWithZeroLimit": {
+			args: args{limit: 0, page: 1},
+			want: want{err: fmt.Errorf("limit must be greater than zero")},
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			pods, listMetadata, err := FetchSortedPage(test.args.limit, test.args.page, paginator, test.args.listQuery, newestFirst)
+			if test.want.err != nil {
+				assert.Equal(t, test.want.err, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			var names []string
+			for _, pod := range pods {
+				names = append(names, pod.Name)
+			}
+			assert.Equal(t, test.want.names, names)
+			assert.Equal(t, test.want.listMetadata, listMetadata)
+		})
+	}
+}
+
+func Test_FetchFilteredPage(t *testing.T) {
+	setFetchAllChunkSize(t, 2)
+	now := time.Now()
+	paginator := &TestPodPaginator{
+		pods: []corev1.Pod{
+			preparePod("a-pod", now, corev1.PodRunning),
+			preparePod("b-pod", now, corev1.PodPending),
+			preparePod("c-pod", now, corev1.PodRunning),
+			preparePod("d-pod", now, corev1.PodPending),
+			preparePod("e-pod", now, corev1.PodRunning),
+			preparePod("f-pod", now, corev1.PodPending),
+			preparePod("g-pod", now, corev1.PodRunning),
+		},
+	}
+	isRunning := func(pod *corev1.Pod) bool {
+		return pod.Status.Phase == corev1.PodRunning
+	}
+	remainingItemCount := func(count int64) *int64 { return &count }
+
+	type args struct {
+		page      int
+		limit     int
+		listQuery types.ListQuery
+	}
+
+	type want struct {
+		names        []string
+		listMetadata types.ListMetadata
+		err          error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldFillFirstPageWithMatchesFromSeveralChunks": {
+			args: args{limit: 2, page: 1},
+			want: want{
+				names:        []string{"a-pod", "c-pod"},
+				listMetadata: types.ListMetadata{Count: 2, HasMore: true, RemainingItemCount: remainingItemCount(2)},
+			},
+		},
+		"ShouldReturnLastPageOfMatches": {
+			args: args{limit: 2, page: 2},
+			want: want{
+				names:        []string{"e-pod", "g-pod"},
+				listMetadata: types.ListMetadata{Count: 2},
+			},
+		},
+		"ShouldReturnNoItemsForPageOutOfRange": {
+			args: args{limit: 2, page: 3},
+			want: want{},
+		},
+		"ShouldApplyListQueryToMatches": {
+			args: args{limit: 3, page: 1, listQuery: types.ListQuery{SortBy: SortByName, Order: OrderDesc}},
+			want: want{
+				names:        []string{"g-pod", "e-pod", "c-pod"},
+				listMetadata: types.ListMetadata{Count: 3, HasMore: true, RemainingItemCount: remainingItemCount(1)},
+			},
+		},
+		"ShouldFailCombiningContinueWithFilter": {
+			args: args{limit: 2, page: 1, listQuery: types.ListQuery{Continue: "2"}},
+			want: want{err: customerrors.NewValidationError(ErrContinueWithFilter)},
+		},
+		"ShouldFailWithZeroLimit": {
+			args: args{limit: 0, page: 1},
+			want: want{err: fmt.Errorf("limit must be greater than zero")},
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			pods, listMetadata, err := FetchFilteredPage(test.args.limit, test.args.page, paginator, test.args.listQuery, isRunning)
+			if test.want.err != nil {
+				assert.Equal(t, test.want.err, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			var names []string
+			for _, pod := range pods {
+				names = append(names, pod.Name)
+			}
+			assert.Equal(t, test.want.names, names)
+			assert.Equal(t, test.want.listMetadata, listMetadata)
+		})
+	}
+}
+
+func Test_FetchPageFetchesAllItemsInFixedChunks(t *testing.T) {
+	now := time.Now()
+	paginator := &TestPodPaginator{
+		pods: []corev1.Pod{
+			preparePod("c-pod", now, corev1.PodRunning),
+			preparePod("a-pod", now, corev1.PodRunning),
+			preparePod("b-pod", now, corev1.PodRunning),
+		},
+	}
+
+	pods, _, err := FetchPage(1, 2, paginator, types.ListQuery{SortBy: SortByName})
+	assert.NoError(t, err)
+	assert.Len(t, pods, 1)
+	assert.Equal(t, "b-pod", pods[0].Name)
+	assert.Equal(t, []int64{fetchAllChunkSize}, paginator.limits)
+}
+
 func Test_extractLimitFromCtx(t *testing.T) {
 	const defaultPaginationLimitStr = "100"
 	const defaultPaginationLimitInt = 100
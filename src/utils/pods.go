@@ -15,48 +15,41 @@ func GetPodsByLabel(ctx context.Context, client kubernetes.Interface, namespace,
 	return client.CoreV1().Pods(namespace).List(ctx, listOptions)
 }
 
-// GetPodLogStream returns the logs of a container in a pod.
-func GetPodLogStream(ctx context.Context, client kubernetes.Interface, namespace, podName, containerName string, previous bool) (io.ReadCloser, error) {
+// GetPodLogStream returns the logs of a container in a pod, using the given options to select which part of the log is returned.
+// If containerName is empty, the logs of the pod's default container are returned. The name of the streamed container is returned as well.
+func GetPodLogStream(ctx context.Context, client kubernetes.Interface, namespace, podName, containerName string, logOptions corev1.PodLogOptions) (io.ReadCloser, string, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get pod: %v", err)
+	}
+
 	if containerName == "" {
-		var err error
-		containerName, err = getDefaultContainerName(ctx, client, namespace, podName)
+		containerName, err = getDefaultContainerName(pod)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 	}
 
-	ok, err := isContainerInPod(ctx, client, namespace, podName, containerName)
-	if err != nil {
-		return nil, err
+	if !IsContainerInPod(pod, containerName) {
+		return nil, "", fmt.Errorf("container %q not found in the pod %q", containerName, podName)
 	}
 
-	if !ok {
-		return nil, fmt.Errorf("container %q not found in the pod %q", containerName, podName)
-	}
-
-	req := client.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
-		Container: containerName,
-		Follow:    true,
-		Previous:  previous,
-	})
+	logOptions.Container = containerName
+	req := client.CoreV1().Pods(namespace).GetLogs(podName, &logOptions)
 
-	return req.Stream(ctx)
+	logStream, err := req.Stream(ctx)
+	return logStream, containerName, err
 }
 
-// isContainerInPod checks if a container with the given name exists in the specified pod.
-func isContainerInPod(ctx context.Context, client kubernetes.Interface, namespace, podName, containerName string) (bool, error) {
-	pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
-	if err != nil {
-		return false, fmt.Errorf("failed to get pod: %v", err)
-	}
-
+// IsContainerInPod checks if a container with the given name is one of the containers of the pod.
+func IsContainerInPod(pod *corev1.Pod, containerName string) bool {
 	for _, container := range pod.Spec.Containers {
 		if container.Name == containerName {
-			return true, nil
+			return true
 		}
 	}
 
-	return false, nil
+	return false
 }
 
 // IsPodInPodList checks if a pod with the given name exists in the provided list of pods.
@@ -71,15 +64,10 @@ func IsPodInPodList(podName string, pods *corev1.PodList) bool {
 }
 
 // getDefaultContainerName returns the name of the only container in the pod if there is exactly one container, otherwise returns an error.
-func getDefaultContainerName(ctx context.Context, client kubernetes.Interface, namespace, podName string) (string, error) {
-	pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
-	if err != nil {
-		return "", fmt.Errorf("failed to get pod: %v", err)
-	}
-
+func getDefaultContainerName(pod *corev1.Pod) (string, error) {
 	if len(pod.Spec.Containers) == 1 {
 		return pod.Spec.Containers[0].Name, nil
 	}
 
-	return "", fmt.Errorf("pod %q has multiple containers, please specify the container name", podName)
+	return "", fmt.Errorf("pod %q has multiple containers, please specify the container name", pod.Name)
 }
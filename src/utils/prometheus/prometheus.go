@@ -0,0 +1,37 @@
+package prometheus
+
+import (
+	"crypto/tls"
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	promconfig "github.com/prometheus/common/config"
+	"net/http"
+	"os"
+)
+
+const (
+	envPrometheusURL  = "PROMETHEUS_URL"
+	bearerTokenPrefix = "Bearer"
+)
+
+// GetAddress returns the address of the Prometheus-compatible query backend, or an empty string if none is configured.
+func GetAddress() string {
+	return os.Getenv(envPrometheusURL)
+}
+
+// NewClient creates a client of the Prometheus-compatible query backend at the given address,
+// which authenticates with the bearer token of the user.
+func NewClient(address, token string, insecureSkipVerify bool) (promv1.API, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+
+	client, err := promapi.NewClient(promapi.Config{
+		Address:      address,
+		RoundTripper: promconfig.NewAuthorizationCredentialsRoundTripper(bearerTokenPrefix, promconfig.NewInlineSecret(token), transport),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return promv1.NewAPI(client), nil
+}
@@ -0,0 +1,35 @@
+package prometheus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetAddress(t *testing.T) {
+	t.Setenv(envPrometheusURL, "")
+	assert.Empty(t, GetAddress())
+
+	t.Setenv(envPrometheusURL, "https://thanos-querier:9091")
+	assert.Equal(t, "https://thanos-querier:9091", GetAddress())
+}
+
+func TestNewClient(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "token", false)
+	assert.NoError(t, err)
+
+	_, _, err = client.Query(context.TODO(), "up", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token", authorization)
+}
@@ -46,8 +46,8 @@ const (
 	ServiceAccountsKey = "serviceaccounts"
 	TokenKey           = "token"
 	Secret             = "Secret"
-	V1 = "v1"
-	Value = "value"
+	V1                 = "v1"
+	Value              = "value"
 )
 
 const (
@@ -76,6 +76,15 @@ const (
 	RcsOcmDeployerControllerManager = "rcs-ocm-deployer-controller-manager"
 )
 
+const (
+	SortByKey            = "sortBy"
+	OrderKey             = "order"
+	SearchKey            = "search"
+	FieldSelectorKey     = "fieldSelector"
+	InvalidFieldSelector = "metadata.name"
+	MetadataNameField    = "metadata.name"
+)
+
 const (
 	NameKey          = "name"
 	NamespaceKey     = "namespaces"
@@ -100,8 +109,16 @@ const (
 )
 
 const (
-	ContentType     = "Content-Type"
-	ApplicationJson = "application/json"
+	ContinueKey           = "continue"
+	HasMoreKey            = "hasMore"
+	RemainingItemCountKey = "remainingItemCount"
+)
+
+const (
+	ContentType               = "Content-Type"
+	ApplicationJson           = "application/json"
+	ApplicationMergePatchJson = "application/merge-patch+json"
+	ApplicationJsonPatchJson  = "application/json-patch+json"
 )
 
 const (
@@ -120,6 +137,8 @@ const (
 	Available           = "available"
 	Unknown             = "unknown"
 	UnAvailable         = "unavailable"
+	Site                = TestName + "-site"
+	SiteKey             = "site"
 )
 
 const (
@@ -136,12 +155,66 @@ const (
 	ContainerNameKey  = "containerName"
 	TestContainerName = "test-container"
 	Image             = "nginx"
+	InitKey           = "init"
+	ReadyKey          = "ready"
+	RestartCountKey   = "restartCount"
+)
+
+const (
+	PodsKey     = "pods"
+	PodNameKey  = "podName"
+	PodName     = TestName + "-pod"
+	PhaseKey    = "phase"
+	RestartsKey = "restarts"
+)
+
+const (
+	InitContainerName      = "test-init-container"
+	NodeName               = TestName + "-node"
+	HostIP                 = "192.168.0.1"
+	PodIP                  = "10.0.0.1"
+	RevisionName           = CappName + "-00001"
+	PodStartTime           = "2024-01-01T10:00:00Z"
+	ReasonCrashLoopBackOff = "CrashLoopBackOff"
+	ReasonError            = "Error"
+	ReasonCompleted        = "Completed"
+	ReasonNotReady         = "ContainersNotReady"
+)
+
+const (
+	EventsKey          = "events"
+	EventName          = TestName + "-event"
+	EventReasonBackOff = "BackOff"
+	EventReasonCreated = "Created"
+	EventTimestamp     = "2024-01-01T10:00:00Z"
+)
+
+const (
+	Username              = TestName + "-user"
+	DeployImage           = "nginx:1.27"
+	EnvKey                = "LOG_LEVEL"
+	EnvValue              = "debug"
+	ChangeCause           = "Release 1.27"
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+	DeployedByAnnotation  = "rcs.dana.io/deployed-by"
+	DeployedAtAnnotation  = "rcs.dana.io/deployed-at"
+	RevisionTimestamp     = "2024-01-01T10:00:00Z"
+)
+
+const (
+	TrafficTag              = "stable"
+	PreviousRevisionPercent = 10
+)
+
+const (
+	RolloutAnnotation     = "platform.dana.io/rollout"
+	RevisionFailedMessage = "Revision failed with message: container exited with code 1"
 )
 
 const (
-	PodsKey    = "pods"
-	PodNameKey = "podName"
-	PodName    = TestName + "-pod"
+	QueueProxyContainerName = "queue-proxy"
+	MetricsTimestamp        = "2024-01-01T10:00:00Z"
+	MetricsWindow           = 30 * time.Second
 )
 
 const (
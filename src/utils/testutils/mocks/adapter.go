@@ -7,6 +7,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
 )
 
 // CreateTestNamespace creates a test Namespace object.
@@ -37,6 +38,42 @@ func CreateTestCapp(dynClient runtimeClient.WithWatch, name, namespace, domain s
 	}
 }
 
+// CreateTestCappWithTraffic creates a test Capp object whose traffic is split between two revisions.
+func CreateTestCappWithTraffic(dynClient runtimeClient.WithWatch, name, namespace, domain, tag string) {
+	capp := PrepareCappWithTraffic(name, namespace, domain, tag)
+	err := dynClient.Create(context.TODO(), &capp)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// CreateTestCappWithServingRevision creates a test Capp object whose given revision receives all traffic.
+func CreateTestCappWithServingRevision(dynClient runtimeClient.WithWatch, name, namespace, domain, revisionName string) {
+	capp := PrepareCappWithServingRevision(name, namespace, domain, revisionName)
+	err := dynClient.Create(context.TODO(), &capp)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// CreateTestCappWithFailedRevision creates a test Capp object whose latest created revision failed to become ready.
+func CreateTestCappWithFailedRevision(dynClient runtimeClient.WithWatch, name, namespace, domain, servingRevisionName, failedRevisionName string) {
+	capp := PrepareCappWithFailedRevision(name, namespace, domain, servingRevisionName, failedRevisionName)
+	err := dynClient.Create(context.TODO(), &capp)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// CreateTestCappWithSite creates a test Capp object deployed on the given site.
+func CreateTestCappWithSite(dynClient runtimeClient.WithWatch, name, namespace, site, domain string, labels, annotations map[string]string) {
+	capp := PrepareCappWithSite(name, namespace, site, domain, labels, annotations)
+	err := dynClient.Create(context.TODO(), &capp)
+	if err != nil {
+		panic(err)
+	}
+}
+
 // CreateTestCappWithState creates a test Capp object with given state.
 func CreateTestCappWithState(dynClient runtimeClient.WithWatch, name, namespace, state string, labels, annotations map[string]string) {
 	cappRevision := PrepareCappWithKnativeObject(name, namespace, state, labels, annotations)
@@ -64,6 +101,15 @@ func CreateTestCappRevision(dynClient runtimeClient.WithWatch, name, namespace s
 	}
 }
 
+// CreateTestCappRevisionOfCapp creates a test CappRevision object of a Capp with the given number, image and annotations.
+func CreateTestCappRevisionOfCapp(dynClient runtimeClient.WithWatch, name, namespace, cappName, image string, revisionNumber int, annotations map[string]string) {
+	cappRevision := PrepareCappRevisionOfCapp(name, namespace, cappName, image, revisionNumber, annotations)
+	err := dynClient.Create(context.TODO(), &cappRevision)
+	if err != nil {
+		panic(err)
+	}
+}
+
 // CreateTestRoleBinding creates a test RoleBinding object.
 func CreateTestRoleBinding(fakeClient *fake.Clientset, name, namespace, role string) {
 	roleBinding := PrepareRoleBinding(name, namespace, role)
@@ -92,6 +138,33 @@ func CreateTestPod(fakeClient *fake.Clientset, namespace, name, cappName string,
 	}
 }
 
+// CreateTestPodWithStatus creates a test Pod object with an init container and container statuses.
+func CreateTestPodWithStatus(fakeClient *fake.Clientset, namespace, name, cappName string) {
+	pod := PreparePodWithStatus(namespace, name, cappName)
+	_, err := fakeClient.CoreV1().Pods(namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// CreateTestRevisionPod creates a test Pod object of a Knative revision whose container restarted the given number of times.
+func CreateTestRevisionPod(dynClient runtimeClient.WithWatch, namespace, name, revisionName string, restartCount int32) {
+	pod := PrepareRevisionPod(namespace, name, revisionName, restartCount)
+	err := dynClient.Create(context.TODO(), pod)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// CreateTestPodMetrics creates a test PodMetrics object.
+func CreateTestPodMetrics(dynClient runtimeClient.WithWatch, namespace, name, cappName, cpu, memory string) {
+	podMetrics := PreparePodMetrics(namespace, name, cappName, cpu, memory)
+	err := dynClient.Create(context.TODO(), &podMetrics)
+	if err != nil {
+		panic(err)
+	}
+}
+
 // CreateTestCNAMERecord creates a test CNAME record
 func CreateTestCNAMERecord(dynClient runtimeClient.WithWatch, name, cappName, cappNSName, hostname string, readyStatus, syncedStatus corev1.ConditionStatus) {
 	record := prepareCNAMERecord(name, cappName, cappNSName, hostname, readyStatus, syncedStatus)
@@ -137,3 +210,12 @@ func createTestSecret(fakeClient *fake.Clientset, secret corev1.Secret) {
 		panic(err)
 	}
 }
+
+// CreateTestEvent creates a test Event object about the given object, last observed at the given time.
+func CreateTestEvent(fakeClient *fake.Clientset, namespace, name, kind, objectName, eventType, reason string, lastTimestamp time.Time) {
+	event := PrepareEvent(namespace, name, kind, objectName, eventType, reason, lastTimestamp)
+	_, err := fakeClient.CoreV1().Events(namespace).Create(context.TODO(), event, metav1.CreateOptions{})
+	if err != nil {
+		panic(err)
+	}
+}
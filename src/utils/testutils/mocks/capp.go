@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"encoding/json"
 	"fmt"
 
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
@@ -9,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	knativeapis "knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
 	knativev1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
 )
@@ -32,6 +34,83 @@ func PrepareCapp(name, namespace, domain string, labels, annotations map[string]
 	}
 }
 
+// PrepareCappWithSite returns a mock Capp object deployed on the given site.
+func PrepareCappWithSite(name, namespace, site, domain string, labels, annotations map[string]string) cappv1alpha1.Capp {
+	capp := PrepareCapp(name, namespace, domain, labels, annotations)
+	capp.Status.ApplicationLinks.Site = site
+
+	return capp
+}
+
+// PrepareCappWithTraffic returns a mock Capp object whose traffic is split between the latest revision
+// and a previous revision tagged with the given tag.
+func PrepareCappWithTraffic(name, namespace, domain, tag string) cappv1alpha1.Capp {
+	latestRevision := true
+	previousPercent, latestPercent := int64(testutils.PreviousRevisionPercent), int64(100-testutils.PreviousRevisionPercent)
+
+	capp := PrepareCapp(name, namespace, domain, nil, nil)
+	capp.Status.KnativeObjectStatus.Traffic = []knativev1.TrafficTarget{
+		{
+			RevisionName:   name + "-00002",
+			LatestRevision: &latestRevision,
+			Percent:        &latestPercent,
+		},
+		{
+			Tag:          tag,
+			RevisionName: name + "-00001",
+			Percent:      &previousPercent,
+			URL:          knativeapis.HTTPS(fmt.Sprintf("%s-%s-%s.%s", tag, name, namespace, domain)),
+		},
+	}
+
+	return capp
+}
+
+// PrepareCappWithServingRevision returns a mock Capp object whose given revision is the latest one and receives all traffic.
+func PrepareCappWithServingRevision(name, namespace, domain, revisionName string) cappv1alpha1.Capp {
+	latestRevision := true
+	percent := int64(100)
+
+	capp := PrepareCapp(name, namespace, domain, nil, nil)
+	capp.Status.KnativeObjectStatus.LatestCreatedRevisionName = revisionName
+	capp.Status.KnativeObjectStatus.LatestReadyRevisionName = revisionName
+	capp.Status.KnativeObjectStatus.Traffic = []knativev1.TrafficTarget{
+		{
+			RevisionName:   revisionName,
+			LatestRevision: &latestRevision,
+			Percent:        &percent,
+		},
+	}
+
+	return capp
+}
+
+// PrepareCappWithFailedRevision returns a mock Capp object whose latest created revision failed to become ready,
+// while its given serving revision receives all traffic.
+func PrepareCappWithFailedRevision(name, namespace, domain, servingRevisionName, failedRevisionName string) cappv1alpha1.Capp {
+	capp := PrepareCappWithServingRevision(name, namespace, domain, servingRevisionName)
+	capp.Status.KnativeObjectStatus.LatestCreatedRevisionName = failedRevisionName
+	capp.Status.KnativeObjectStatus.Conditions = duckv1.Conditions{
+		{
+			Type:    knativev1.ServiceConditionConfigurationsReady,
+			Status:  corev1.ConditionFalse,
+			Message: testutils.RevisionFailedMessage,
+		},
+	}
+
+	return capp
+}
+
+// PrepareRolloutAnnotations returns the annotations of a Capp recording the given rollout.
+func PrepareRolloutAnnotations(rollout types.CappRollout) map[string]string {
+	rolloutJSON, err := json.Marshal(rollout)
+	if err != nil {
+		panic(err)
+	}
+
+	return map[string]string{testutils.RolloutAnnotation: string(rolloutJSON)}
+}
+
 // PrepareCappWithState returns a mock Capp object with given state.
 func PrepareCappWithState(name, namespace, state string, labels, annotations map[string]string) cappv1alpha1.Capp {
 	return cappv1alpha1.Capp{
@@ -213,11 +292,30 @@ func PrepareCappMetadata(name, namespace string) types.Metadata {
 	}
 }
 
+// PrepareCappMetadataWithResourceVersion returns a CappMetadata object with the given resourceVersion.
+func PrepareCappMetadataWithResourceVersion(name, namespace, resourceVersion string) types.Metadata {
+	return types.Metadata{
+		Name:            name,
+		Namespace:       namespace,
+		ResourceVersion: resourceVersion,
+	}
+}
+
 // PrepareCappSummary returns a CappSummary object.
 func PrepareCappSummary(name string, namespace string) types.CappSummary {
 	return types.CappSummary{
-		Name:   name,
-		Images: []string{testutils.CappImage},
-		URL:    fmt.Sprintf("https://%s-%s.%s", name, namespace, testutils.Domain),
+		Name:        name,
+		Images:      []string{testutils.CappImage},
+		URL:         fmt.Sprintf("https://%s-%s.%s", name, namespace, testutils.Domain),
+		State:       enabledKey,
+		ScaleMetric: concurrencyKey,
 	}
 }
+
+// PrepareCappSummaryWithSite returns a CappSummary object of a Capp deployed on the given site.
+func PrepareCappSummaryWithSite(name, namespace, site string) types.CappSummary {
+	summary := PrepareCappSummary(name, namespace)
+	summary.Site = site
+
+	return summary
+}
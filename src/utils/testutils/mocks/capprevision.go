@@ -3,7 +3,9 @@ package mocks
 import (
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
 	"github.com/dana-team/platform-backend/src/types"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
 )
 
 // PrepareCappRevision returns a mock CappRevision object.
@@ -22,6 +24,18 @@ func PrepareCappRevision(name, namespace string, labels, annotations map[string]
 	return cappRevision
 }
 
+// PrepareCappRevisionOfCapp returns a mock CappRevision object of a Capp with the given number, image and annotations.
+func PrepareCappRevisionOfCapp(name, namespace, cappName, image string, revisionNumber int, annotations map[string]string) cappv1alpha1.CappRevision {
+	createdAt, _ := time.Parse(time.RFC3339, testutils.RevisionTimestamp)
+
+	cappRevision := PrepareCappRevision(name, namespace, map[string]string{testutils.LabelCappName: cappName}, annotations)
+	cappRevision.CreationTimestamp = metav1.NewTime(createdAt)
+	cappRevision.Spec.RevisionNumber = revisionNumber
+	cappRevision.Spec.CappTemplate.Spec.ConfigurationSpec.Template.Spec.Containers[0].Image = image
+
+	return cappRevision
+}
+
 // PrepareCappRevisionSpec returns a mock CappRevision Spec object.
 func PrepareCappRevisionSpec(labels, annotations map[string]string) cappv1alpha1.CappRevisionSpec {
 	cappRevisionSpec := cappv1alpha1.CappRevisionSpec{
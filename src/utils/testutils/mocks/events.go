@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
+	"time"
+)
+
+// eventObjectAPIVersions maps the kinds of the objects events are about in tests to their API versions.
+var eventObjectAPIVersions = map[string]string{
+	"Capp":          cappv1alpha1.GroupVersion.String(),
+	"Service":       knativev1.SchemeGroupVersion.String(),
+	"Configuration": knativev1.SchemeGroupVersion.String(),
+	"Revision":      knativev1.SchemeGroupVersion.String(),
+	"Pod":           corev1.SchemeGroupVersion.String(),
+}
+
+// PrepareEvent returns a mock Event object about the given object, last observed at the given time.
+// Capps, pods and Knative objects are referenced with their API versions.
+func PrepareEvent(namespace, name, kind, objectName, eventType, reason string, lastTimestamp time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: eventObjectAPIVersions[kind],
+			Kind:       kind,
+			Name:       objectName,
+			Namespace:  namespace,
+		},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        reason,
+		Source:         corev1.EventSource{Component: "kubelet"},
+		Count:          1,
+		FirstTimestamp: metav1.NewTime(lastTimestamp),
+		LastTimestamp:  metav1.NewTime(lastTimestamp),
+	}
+}
@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"github.com/dana-team/platform-backend/src/utils"
+	"github.com/dana-team/platform-backend/src/utils/testutils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"time"
+)
+
+// PreparePodMetrics returns a mock PodMetrics object of a pod of the given Capp, whose container uses the given
+// amounts of CPU and memory next to a Knative queue-proxy container.
+func PreparePodMetrics(namespace, name, cappName, cpu, memory string) metricsv1beta1.PodMetrics {
+	timestamp, _ := time.Parse(time.RFC3339, testutils.MetricsTimestamp)
+	return metricsv1beta1.PodMetrics{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{utils.ParentCappLabel: cappName},
+		},
+		Timestamp: metav1.NewTime(timestamp),
+		Window:    metav1.Duration{Duration: testutils.MetricsWindow},
+		Containers: []metricsv1beta1.ContainerMetrics{
+			{
+				Name: testutils.ContainerName,
+				Usage: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+			},
+			{
+				Name: testutils.QueueProxyContainerName,
+				Usage: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1m"),
+					corev1.ResourceMemory: resource.MustParse("10Mi"),
+				},
+			},
+		},
+	}
+}
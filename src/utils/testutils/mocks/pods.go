@@ -3,7 +3,10 @@ package mocks
 import (
 	"github.com/dana-team/platform-backend/src/utils/testutils"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/serving/pkg/apis/serving"
+	"time"
 )
 
 // PreparePod simulates creating a pod and adding some log lines.
@@ -49,3 +52,82 @@ func PreparePod(namespace, podName, cappName string, isMultipleContainers bool)
 		},
 	}
 }
+
+// PreparePodWithStatus simulates a running pod of a Capp revision whose init container completed
+// and whose container is crash looping.
+func PreparePodWithStatus(namespace, podName, cappName string) *corev1.Pod {
+	pod := PreparePod(namespace, podName, cappName, false)
+	startTime, _ := time.Parse(time.RFC3339, testutils.PodStartTime)
+	timestamp := metav1.NewTime(startTime)
+
+	pod.Labels[serving.RevisionLabelKey] = testutils.RevisionName
+	pod.Spec.NodeName = testutils.NodeName
+	pod.Spec.InitContainers = []corev1.Container{
+		{
+			Name:  testutils.InitContainerName,
+			Image: testutils.Image,
+		},
+	}
+	pod.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+	}
+
+	pod.Status = corev1.PodStatus{
+		Phase:     corev1.PodRunning,
+		HostIP:    testutils.HostIP,
+		PodIPs:    []corev1.PodIP{{IP: testutils.PodIP}},
+		StartTime: &timestamp,
+		QOSClass:  corev1.PodQOSBurstable,
+		Conditions: []corev1.PodCondition{
+			{
+				Type:               corev1.PodReady,
+				Status:             corev1.ConditionFalse,
+				Reason:             testutils.ReasonNotReady,
+				LastTransitionTime: timestamp,
+			},
+		},
+		InitContainerStatuses: []corev1.ContainerStatus{
+			{
+				Name:  testutils.InitContainerName,
+				Ready: true,
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{Reason: testutils.ReasonCompleted, StartedAt: timestamp, FinishedAt: timestamp},
+				},
+			},
+		},
+		ContainerStatuses: []corev1.ContainerStatus{
+			{
+				Name:         pod.Spec.Containers[0].Name,
+				RestartCount: 3,
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{Reason: testutils.ReasonCrashLoopBackOff},
+				},
+				LastTerminationState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: testutils.ReasonError, StartedAt: timestamp, FinishedAt: timestamp},
+				},
+			},
+		},
+	}
+
+	return pod
+}
+
+// PrepareRevisionPod returns a mock pod of a Knative revision whose container restarted the given number of times.
+func PrepareRevisionPod(namespace, podName, revisionName string, restartCount int32) *corev1.Pod {
+	pod := PreparePod(namespace, podName, "", false)
+	pod.Labels[serving.RevisionLabelKey] = revisionName
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name:         pod.Spec.Containers[0].Name,
+			RestartCount: restartCount,
+		},
+	}
+
+	return pod
+}
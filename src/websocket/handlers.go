@@ -2,40 +2,268 @@ package websocket
 
 import (
 	"bufio"
-	"github.com/gin-gonic/gin"
+	"context"
+	"errors"
+	"github.com/dana-team/platform-backend/src/customerrors"
 	"github.com/gorilla/websocket"
 	"io"
+	"net/http"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
-// Stream reads lines from the provided stream, formats each line using the given formatFunc,
-// and sends them as WebSocket messages to the client connection until the context is done.
-func Stream(c *gin.Context, conn *websocket.Conn, stream io.ReadCloser, formatFunc func(string) string) {
+const (
+	defaultBufferSize = 1000
+	defaultBatchSize  = 100
+	defaultWriteWait  = 10 * time.Second
+	defaultPongWait   = 60 * time.Second
+
+	maxLineSize          = 1024 * 1024
+	maxCloseReasonLength = 123
+	linesSeparator       = "\n"
+)
+
+const (
+	CloseReasonStreamEnded     = "stream ended"
+	CloseReasonStreamCancelled = "stream cancelled"
+	errReadingStream           = "error reading stream: "
+)
+
+// ControlFrameFunc formats a message that is sent right before the close frame, telling the client why the stream is closed.
+type ControlFrameFunc func(code int, reason string) string
+
+// StreamOptions configure how lines are buffered, batched and kept alive while they are streamed to a WebSocket.
+type StreamOptions struct {
+	// BufferSize is the maximum number of lines read ahead of the client.
+	// Once the buffer is full, reading from the stream blocks until the client catches up.
+	BufferSize int
+	// BatchSize is the maximum number of lines sent in a single frame.
+	BatchSize int
+	// WriteWait is the time allowed to write a frame to the client.
+	WriteWait time.Duration
+	// PongWait is the time allowed to receive a pong from the client. Pings are sent every 9/10 of it.
+	PongWait time.Duration
+	// ControlFrame, if set, formats a message that is sent before the close frame once the stream is closed.
+	ControlFrame ControlFrameFunc
+}
+
+// withDefaults returns a copy of the options with every unset option replaced by its default value.
+func withDefaults(options *StreamOptions) StreamOptions {
+	result := StreamOptions{}
+	if options != nil {
+		result = *options
+	}
+
+	if result.BufferSize <= 0 {
+		result.BufferSize = defaultBufferSize
+	}
+
+	if result.BatchSize <= 0 {
+		result.BatchSize = defaultBatchSize
+	}
+
+	if result.WriteWait <= 0 {
+		result.WriteWait = defaultWriteWait
+	}
+
+	if result.PongWait <= 0 {
+		result.PongWait = defaultPongWait
+	}
+
+	return result
+}
+
+// Stream reads lines from the provided stream, formats each line using the given formatFunc, and sends them
+// to the client connection. Up to BufferSize lines are read ahead of the client, and the lines which are already
+// buffered are sent together in frames of up to BatchSize lines. The connection is closed with a close frame
+// once the stream ends, the stream fails, the context is done or the client goes away. If options is nil,
+// the default options are used.
+func Stream(ctx context.Context, conn *websocket.Conn, stream io.ReadCloser, formatFunc func(string) string, options *StreamOptions) {
 	defer stream.Close()
-	defer conn.Close()
 
-	reader := bufio.NewScanner(stream)
-	var line string
+	streamOptions := withDefaults(options)
+	lines := make(chan string, streamOptions.BufferSize)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var readErr error
+	go func() {
+		defer close(lines)
+
+		scanner := bufio.NewScanner(stream)
+		scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				return
+			case lines <- scanner.Text():
+			}
+		}
+
+		readErr = scanner.Err()
+	}()
+
+	streamLines(ctx, conn, lines, formatFunc, streamOptions, func() error { return readErr })
+}
+
+// StreamLines formats every line received from the channel using the given formatFunc and sends the lines to
+// the client connection, batching the lines which are already buffered in the channel. The connection is closed
+// with a close frame once the channel is closed, the context is done or the client goes away.
+// If options is nil, the default options are used.
+func StreamLines[T any](ctx context.Context, conn *websocket.Conn, lines <-chan T, formatFunc func(T) string, options *StreamOptions) {
+	streamLines(ctx, conn, lines, formatFunc, withDefaults(options), func() error { return nil })
+}
+
+// streamLines pumps the lines to the client while reading its control frames, and then closes the connection
+// with a reason matching the way the stream ended. streamErr is called once the channel is closed to
+// check whether the lines ended because of a failure.
+func streamLines[T any](ctx context.Context, conn *websocket.Conn, lines <-chan T, formatFunc func(T) string, options StreamOptions, streamErr func() error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go readControlFrames(conn, options.PongWait, cancel)
+
+	if err := pumpLines(ctx, conn, lines, formatFunc, options); err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			closeStream(conn, websocket.CloseGoingAway, CloseReasonStreamCancelled, options)
+		}
+		return
+	}
+
+	if err := streamErr(); err != nil {
+		closeStream(conn, websocket.CloseInternalServerErr, errReadingStream+err.Error(), options)
+		return
+	}
+
+	closeStream(conn, websocket.CloseNormalClosure, CloseReasonStreamEnded, options)
+}
+
+// closeStream sends the control frame formatted by the options, if any, followed by the close frame.
+func closeStream(conn *websocket.Conn, code int, reason string, options StreamOptions) {
+	if options.ControlFrame != nil {
+		_ = conn.SetWriteDeadline(time.Now().Add(options.WriteWait))
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(options.ControlFrame(code, reason)))
+	}
+
+	CloseWithReason(conn, code, reason)
+}
+
+// pumpLines writes the lines to the client until the channel is closed, in which case it returns nil.
+// Lines are batched as long as more lines are buffered, so bursts are sent in few frames while single lines
+// are sent right away. A ping is sent periodically to keep the connection alive and detect dead clients.
+func pumpLines[T any](ctx context.Context, conn *websocket.Conn, lines <-chan T, formatFunc func(T) string, options StreamOptions) error {
+	pingTicker := time.NewTicker(options.PongWait * 9 / 10)
+	defer pingTicker.Stop()
+
+	batch := make([]string, 0, options.BatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		message := strings.Join(batch, linesSeparator)
+		batch = batch[:0]
+
+		_ = conn.SetWriteDeadline(time.Now().Add(options.WriteWait))
+		return conn.WriteMessage(websocket.TextMessage, []byte(message))
+	}
+
 	for {
 		select {
-		case <-c.Done():
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-pingTicker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(options.WriteWait)); err != nil {
+				return err
+			}
+		case line, ok := <-lines:
+			if !ok {
+				return flush()
+			}
+
+			batch = append(batch, formatFunc(line))
+			if len(batch) < options.BatchSize && len(lines) > 0 {
+				continue
+			}
+
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readControlFrames reads from the client so that pongs and close frames are handled, and calls cancel once
+// the client closes the connection or stops answering pings. The client is not expected to send messages.
+func readControlFrames(conn *websocket.Conn, pongWait time.Duration, cancel context.CancelFunc) {
+	defer cancel()
+
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
 			return
-		default:
-			for reader.Scan() {
-				line = reader.Text()
-				message := formatFunc(line)
-				if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
-					SendErrorMessage(conn, "Error writing message to WebSocket")
+		}
+	}
+}
+
+// KeepAlive pings the client in the background until the context is done, and calls cancel if a ping can not be sent.
+// Every pong extends the read deadline of the connection, so the caller's reads fail once the client stops answering.
+// The caller must keep reading from the connection for pongs to be handled. If options is nil, the default waits are used.
+func KeepAlive(ctx context.Context, conn *websocket.Conn, cancel context.CancelFunc, options *StreamOptions) {
+	streamOptions := withDefaults(options)
+
+	_ = conn.SetReadDeadline(time.Now().Add(streamOptions.PongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(streamOptions.PongWait))
+	})
+
+	go func() {
+		ticker := time.NewTicker(streamOptions.PongWait * 9 / 10)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamOptions.WriteWait)); err != nil {
+					cancel()
 					return
 				}
-				time.Sleep(time.Second)
 			}
 		}
+	}()
+}
+
+// CloseWithReason sends a close frame with the given code and reason to the client.
+// The reason is truncated to the maximum length allowed in a close frame.
+func CloseWithReason(conn *websocket.Conn, code int, reason string) {
+	if len(reason) > maxCloseReasonLength {
+		reason = reason[:maxCloseReasonLength]
+		for !utf8.ValidString(reason) {
+			reason = reason[:len(reason)-1]
+		}
 	}
+
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(defaultWriteWait))
 }
 
-// SendErrorMessage sends an error message to the WebSocket client.
-func SendErrorMessage(conn *websocket.Conn, errorMsg string) {
-	message := "error: " + errorMsg
-	_ = conn.WriteMessage(websocket.TextMessage, []byte(message))
+// CloseWithError sends a close frame with the error as its reason to the client. Errors caused by the request,
+// such as validation errors, are sent with the policy violation code and any other error as an internal error.
+// If options is not nil and sets a ControlFrame, the formatted control frame is sent before the close frame.
+func CloseWithError(conn *websocket.Conn, err error, options *StreamOptions) {
+	code := websocket.CloseInternalServerErr
+
+	var errWithStatusCode customerrors.ErrorWithStatusCode
+	if errors.As(err, &errWithStatusCode) && errWithStatusCode.StatusCode() < http.StatusInternalServerError {
+		code = websocket.ClosePolicyViolation
+	}
+
+	closeStream(conn, code, err.Error(), withDefaults(options))
 }
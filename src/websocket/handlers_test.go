@@ -1,7 +1,11 @@
 package websocket
 
 import (
-	"github.com/gin-gonic/gin"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/customerrors"
+	"github.com/dana-team/platform-backend/src/types"
 	"github.com/gorilla/websocket"
 	"io"
 	"net/http"
@@ -11,95 +15,246 @@ import (
 	"time"
 )
 
-func Test_WebsocketHandlers(t *testing.T) {
-	type args struct {
-		c          *gin.Context
-		conn       *websocket.Conn
-		stream     io.ReadCloser
-		formatFunc func(string) string
+// dialStreamServer starts a WebSocket server running the given handler on every connection and returns a client connected to it.
+func dialStreamServer(t *testing.T, handler func(conn *websocket.Conn)) *websocket.Conn {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Failed to upgrade connection: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		handler(conn)
+	}))
+	t.Cleanup(server.Close)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Failed to dial WebSocket server: %v", err)
+	}
+	t.Cleanup(func() { ws.Close() })
+
+	return ws
+}
+
+// readUntilClose reads frames until the connection is closed and returns the received lines along with the number
+// of frames they were sent in and the close error.
+func readUntilClose(t *testing.T, ws *websocket.Conn) ([]string, int, *websocket.CloseError) {
+	var lines []string
+	frames := 0
+
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		_, msg, err := ws.ReadMessage()
+		if err != nil {
+			var closeErr *websocket.CloseError
+			if !errors.As(err, &closeErr) {
+				t.Fatalf("Expected a close frame, got %v", err)
+			}
+			return lines, frames, closeErr
+		}
+
+		frames++
+		lines = append(lines, strings.Split(string(msg), linesSeparator)...)
 	}
+}
+
+// errReader returns the given error once all of its content is read.
+type errReader struct {
+	io.Reader
+	err error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err == io.EOF {
+		return n, r.err
+	}
+	return n, err
+}
+
+func Test_Stream(t *testing.T) {
 	type want struct {
-		response []string
+		lines  []string
+		code   int
+		reason string
 	}
+
 	cases := map[string]struct {
-		args args
-		want want
+		stream io.Reader
+		want   want
 	}{
-		"ShouldSuccess": {
-			args: args{
-				formatFunc: func(s string) string {
-					return "formatted: " + s
-				},
+		"ShouldStreamLinesAndCloseWhenStreamEnds": {
+			stream: strings.NewReader("line1\nline2\nline3\n"),
+			want: want{
+				lines:  []string{"formatted: line1", "formatted: line2", "formatted: line3"},
+				code:   websocket.CloseNormalClosure,
+				reason: CloseReasonStreamEnded,
 			},
+		},
+		"ShouldCloseWithErrorWhenStreamFails": {
+			stream: &errReader{Reader: strings.NewReader("line1\n"), err: errors.New("connection reset")},
 			want: want{
-				response: []string{
-					"formatted: line1",
-					"formatted: line2",
-					"formatted: line3",
-				},
+				lines:  []string{"formatted: line1"},
+				code:   websocket.CloseInternalServerErr,
+				reason: errReadingStream + "connection reset",
 			},
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			w := httptest.NewRecorder()
-			c, _ := gin.CreateTestContext(w)
-
-			c.Request, _ = http.NewRequest(http.MethodGet, "/ws", nil)
-			tc.args.c = c
-
-			// Setup the WebSocket server
-			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				upgrader := websocket.Upgrader{}
-				conn, err := upgrader.Upgrade(w, r, nil)
-				if err != nil {
-					t.Fatalf("Failed to upgrade connection: %v", err)
-				}
-				tc.args.conn = conn
-
-				// Simulate the input stream
-				pr, pw := io.Pipe()
-				tc.args.stream = pr
-				go func() {
-					defer pw.Close()
-					for _, line := range []string{"line1", "line2", "line3"} {
-						_, err = pw.Write([]byte(line + "\n"))
-						time.Sleep(100 * time.Millisecond) // simulate delay between lines
-					}
-				}()
-
-				Stream(tc.args.c, tc.args.conn, tc.args.stream, tc.args.formatFunc)
-			}))
-			defer server.Close()
-
-			// Connect to the WebSocket server
-			url := "ws" + strings.TrimPrefix(server.URL, "http")
-			ws, _, err := websocket.DefaultDialer.Dial(url, nil)
-			if err != nil {
-				t.Fatalf("Failed to dial WebSocket server: %v", err)
-			}
-			defer ws.Close()
-
-			// Read messages from the WebSocket
-			var messages []string
-			for i := 0; i < len(tc.want.response); i++ {
-				_, msg, err := ws.ReadMessage()
-				if err != nil {
-					t.Fatalf("Failed to read message: %v", err)
-				}
-				messages = append(messages, string(msg))
+			ws := dialStreamServer(t, func(conn *websocket.Conn) {
+				Stream(context.Background(), conn, io.NopCloser(tc.stream), func(s string) string {
+					return "formatted: " + s
+				}, nil)
+			})
+
+			lines, _, closeErr := readUntilClose(t, ws)
+			if strings.Join(lines, ",") != strings.Join(tc.want.lines, ",") {
+				t.Errorf("Expected lines %v, got %v", tc.want.lines, lines)
 			}
 
-			// Verify the messages
-			if len(messages) != len(tc.want.response) {
-				t.Fatalf("Expected %d messages, got %d", len(tc.want.response), len(messages))
+			if closeErr.Code != tc.want.code || closeErr.Text != tc.want.reason {
+				t.Errorf("Expected close %d %q, got %d %q", tc.want.code, tc.want.reason, closeErr.Code, closeErr.Text)
 			}
+		})
+	}
+}
+
+func Test_StreamBatchesBufferedLines(t *testing.T) {
+	const linesCount = 250
+	const batchSize = 100
+
+	var expected []string
+	for i := 0; i < linesCount; i++ {
+		expected = append(expected, fmt.Sprintf("line%d", i))
+	}
+
+	ws := dialStreamServer(t, func(conn *websocket.Conn) {
+		lines := make(chan string, linesCount)
+		for _, line := range expected {
+			lines <- line
+		}
+		close(lines)
+
+		StreamLines(context.Background(), conn, lines, func(s string) string { return s }, &StreamOptions{BatchSize: batchSize})
+	})
+
+	lines, frames, closeErr := readUntilClose(t, ws)
+	if strings.Join(lines, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %d lines in order, got %d", linesCount, len(lines))
+	}
+
+	if frames != (linesCount+batchSize-1)/batchSize {
+		t.Errorf("Expected the lines to be sent in %d frames, got %d", (linesCount+batchSize-1)/batchSize, frames)
+	}
+
+	if closeErr.Code != websocket.CloseNormalClosure {
+		t.Errorf("Expected normal closure, got %d", closeErr.Code)
+	}
+}
+
+func Test_StreamLines(t *testing.T) {
+	lines := []types.LogLine{
+		{Pod: "pod-1", Container: "container-1", Line: "line1"},
+		{Pod: "pod-2", Container: "container-2", Line: "line2"},
+	}
+
+	ws := dialStreamServer(t, func(conn *websocket.Conn) {
+		linesChan := make(chan types.LogLine, len(lines))
+		for _, line := range lines {
+			linesChan <- line
+		}
+		close(linesChan)
+
+		StreamLines(context.Background(), conn, linesChan, func(line types.LogLine) string {
+			return line.Pod + "/" + line.Container + ": " + line.Line
+		}, nil)
+	})
+
+	received, _, closeErr := readUntilClose(t, ws)
+	expected := []string{"pod-1/container-1: line1", "pod-2/container-2: line2"}
+	if strings.Join(received, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected lines %v, got %v", expected, received)
+	}
+
+	if closeErr.Code != websocket.CloseNormalClosure || closeErr.Text != CloseReasonStreamEnded {
+		t.Errorf("Expected normal closure, got %d %q", closeErr.Code, closeErr.Text)
+	}
+}
+
+func Test_StreamLinesKeepsConnectionAliveUntilCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ws := dialStreamServer(t, func(conn *websocket.Conn) {
+		StreamLines(ctx, conn, make(chan string), func(s string) string { return s }, &StreamOptions{PongWait: 100 * time.Millisecond})
+	})
+
+	pings := make(chan struct{}, 1)
+	ws.SetPingHandler(func(appData string) error {
+		select {
+		case pings <- struct{}{}:
+		default:
+		}
+		return ws.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(time.Second))
+	})
+
+	go func() {
+		select {
+		case <-pings:
+		case <-time.After(5 * time.Second):
+			t.Errorf("Expected a ping to be sent")
+		}
+		// Waiting longer than the pong wait ensures that answered pings keep the connection open.
+		time.Sleep(200 * time.Millisecond)
+		cancel()
+	}()
+
+	_, _, closeErr := readUntilClose(t, ws)
+	if closeErr.Code != websocket.CloseGoingAway || closeErr.Text != CloseReasonStreamCancelled {
+		t.Errorf("Expected going away closure, got %d %q", closeErr.Code, closeErr.Text)
+	}
+}
+
+func Test_CloseWithError(t *testing.T) {
+	longMessage := strings.Repeat("é", maxCloseReasonLength)
+
+	cases := map[string]struct {
+		err        error
+		wantCode   int
+		wantReason string
+	}{
+		"ShouldCloseWithPolicyViolationOnValidationError": {
+			err:        customerrors.NewValidationError("invalid tailLines"),
+			wantCode:   websocket.ClosePolicyViolation,
+			wantReason: "invalid tailLines",
+		},
+		"ShouldCloseWithInternalErrorOnOtherErrors": {
+			err:        errors.New("error opening log stream"),
+			wantCode:   websocket.CloseInternalServerErr,
+			wantReason: "error opening log stream",
+		},
+		"ShouldTruncateLongReasons": {
+			err:        errors.New(longMessage),
+			wantCode:   websocket.CloseInternalServerErr,
+			wantReason: longMessage[:maxCloseReasonLength-1],
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ws := dialStreamServer(t, func(conn *websocket.Conn) {
+				CloseWithError(conn, tc.err, nil)
+			})
 
-			for i, msg := range messages {
-				if msg != tc.want.response[i] {
-					t.Errorf("Expected message %d to be %q, got %q", i, tc.want.response[i], msg)
-				}
+			_, _, closeErr := readUntilClose(t, ws)
+			if closeErr.Code != tc.wantCode || closeErr.Text != tc.wantReason {
+				t.Errorf("Expected close %d %q, got %d %q", tc.wantCode, tc.wantReason, closeErr.Code, closeErr.Text)
 			}
 		})
 	}
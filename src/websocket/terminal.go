@@ -0,0 +1,184 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/gorilla/websocket"
+	"io"
+	"k8s.io/client-go/tools/remotecommand"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	terminalSizeQueueLength = 10
+
+	errInvalidTerminalMessage = "invalid terminal message"
+)
+
+// TerminalSession bridges a WebSocket connection to the streams of an exec session. Client messages are parsed as
+// stdin and resize messages, and the output of the command is sent to the client as stdout and stderr messages.
+// It implements io.Reader for stdin and remotecommand.TerminalSizeQueue for TTY resizes.
+type TerminalSession struct {
+	conn    *websocket.Conn
+	cancel  context.CancelFunc
+	done    <-chan struct{}
+	sizes   chan remotecommand.TerminalSize
+	writeMu sync.Mutex
+	stdin   []byte
+	options StreamOptions
+}
+
+// NewTerminalSession creates a TerminalSession over the connection. The client is pinged until the context is done,
+// and cancel is called once the client closes the connection, stops answering pings or sends an invalid message.
+// If options is nil, the default write and pong waits are used.
+func NewTerminalSession(ctx context.Context, conn *websocket.Conn, cancel context.CancelFunc, options *StreamOptions) *TerminalSession {
+	session := &TerminalSession{
+		conn:    conn,
+		cancel:  cancel,
+		done:    ctx.Done(),
+		sizes:   make(chan remotecommand.TerminalSize, terminalSizeQueueLength),
+		options: withDefaults(options),
+	}
+
+	KeepAlive(ctx, conn, cancel, options)
+	return session
+}
+
+// Read reads the stdin sent by the client. Resize messages are queued for Next.
+// io.EOF is returned once the client goes away or sends an invalid message, which ends the session.
+func (t *TerminalSession) Read(p []byte) (int, error) {
+	for len(t.stdin) == 0 {
+		message, err := t.readMessage()
+		if err != nil {
+			t.cancel()
+			return 0, io.EOF
+		}
+
+		switch message.Type {
+		case types.TerminalMessageStdin:
+			t.stdin = []byte(message.Data)
+		case types.TerminalMessageResize:
+			t.queueResize(remotecommand.TerminalSize{Width: message.Cols, Height: message.Rows})
+		default:
+			t.cancel()
+			return 0, io.EOF
+		}
+	}
+
+	n := copy(p, t.stdin)
+	t.stdin = t.stdin[n:]
+	return n, nil
+}
+
+// readMessage reads the next message from the client and extends the read deadline.
+func (t *TerminalSession) readMessage() (types.TerminalMessage, error) {
+	message := types.TerminalMessage{}
+	_, data, err := t.conn.ReadMessage()
+	if err != nil {
+		return message, err
+	}
+	_ = t.conn.SetReadDeadline(time.Now().Add(t.options.PongWait))
+
+	if err := json.Unmarshal(data, &message); err != nil {
+		return message, fmt.Errorf("%s: %v", errInvalidTerminalMessage, err)
+	}
+
+	return message, nil
+}
+
+// queueResize queues the terminal size, dropping the oldest queued size if the queue is full
+// since only the latest size matters.
+func (t *TerminalSession) queueResize(size remotecommand.TerminalSize) {
+	for {
+		select {
+		case t.sizes <- size:
+			return
+		default:
+		}
+
+		select {
+		case <-t.sizes:
+		default:
+		}
+	}
+}
+
+// Next returns the next terminal size requested by the client, or nil once the session is done.
+func (t *TerminalSession) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-t.sizes:
+		return &size
+	case <-t.done:
+		return nil
+	}
+}
+
+// Stdout returns a writer sending its data to the client as stdout messages.
+func (t *TerminalSession) Stdout() io.Writer {
+	return &terminalWriter{session: t, messageType: types.TerminalMessageStdout}
+}
+
+// Stderr returns a writer sending its data to the client as stderr messages.
+func (t *TerminalSession) Stderr() io.Writer {
+	return &terminalWriter{session: t, messageType: types.TerminalMessageStderr}
+}
+
+// SendExit sends the exit code of the command to the client.
+func (t *TerminalSession) SendExit(code int) error {
+	return t.send(types.TerminalMessage{Type: types.TerminalMessageExit, Code: &code})
+}
+
+// send writes the message to the client. Writes of the stdout and stderr writers are serialized.
+func (t *TerminalSession) send(message types.TerminalMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+
+	_ = t.conn.SetWriteDeadline(time.Now().Add(t.options.WriteWait))
+	return t.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// terminalWriter sends everything written to it to the client as messages of a single type.
+// A multibyte character split between writes is held back until it is complete, so that it is not mangled.
+type terminalWriter struct {
+	session     *TerminalSession
+	messageType string
+	pending     []byte
+}
+
+func (w *terminalWriter) Write(p []byte) (int, error) {
+	data := append(w.pending, p...)
+	cut := incompleteRuneStart(data)
+	w.pending = append([]byte(nil), data[cut:]...)
+	if cut == 0 {
+		return len(p), nil
+	}
+
+	if err := w.session.send(types.TerminalMessage{Type: w.messageType, Data: string(data[:cut])}); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// incompleteRuneStart returns the index of the incomplete character at the end of data, or len(data) if there is none.
+func incompleteRuneStart(data []byte) int {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				return i
+			}
+			break
+		}
+	}
+
+	return len(data)
+}
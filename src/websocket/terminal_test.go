@@ -0,0 +1,105 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"github.com/dana-team/platform-backend/src/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"time"
+)
+
+// readTerminalMessages reads the given number of terminal messages from the connection.
+func readTerminalMessages(t *testing.T, ws *websocket.Conn, count int) []types.TerminalMessage {
+	var messages []types.TerminalMessage
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for len(messages) < count {
+		message := types.TerminalMessage{}
+		if err := ws.ReadJSON(&message); err != nil {
+			t.Fatalf("Error reading terminal message: %v", err)
+		}
+		messages = append(messages, message)
+	}
+
+	return messages
+}
+
+func Test_TerminalSession(t *testing.T) {
+	ws := dialStreamServer(t, func(conn *websocket.Conn) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		session := NewTerminalSession(ctx, conn, cancel, nil)
+		buf := make([]byte, 16)
+		n, err := session.Read(buf)
+		assert.NoError(t, err)
+		_, _ = session.Stdout().Write(buf[:n])
+
+		size := session.Next()
+		_, _ = fmt.Fprintf(session.Stderr(), "%dx%d", size.Width, size.Height)
+		_ = session.SendExit(3)
+	})
+
+	assert.NoError(t, ws.WriteJSON(types.TerminalMessage{Type: types.TerminalMessageResize, Cols: 80, Rows: 24}))
+	assert.NoError(t, ws.WriteJSON(types.TerminalMessage{Type: types.TerminalMessageStdin, Data: "ls\n"}))
+
+	messages := readTerminalMessages(t, ws, 3)
+	assert.Equal(t, types.TerminalMessage{Type: types.TerminalMessageStdout, Data: "ls\n"}, messages[0])
+	assert.Equal(t, types.TerminalMessage{Type: types.TerminalMessageStderr, Data: "80x24"}, messages[1])
+	assert.Equal(t, types.TerminalMessageExit, messages[2].Type)
+	assert.Equal(t, 3, *messages[2].Code)
+}
+
+func Test_TerminalSessionEndsOnInvalidMessage(t *testing.T) {
+	cases := map[string]struct {
+		message string
+	}{
+		"ShouldEndOnMalformedMessage": {
+			message: "not json",
+		},
+		"ShouldEndOnUnsupportedMessageType": {
+			message: `{"type":"stdout","data":"ls"}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			done := make(chan struct{})
+			ws := dialStreamServer(t, func(conn *websocket.Conn) {
+				defer close(done)
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				session := NewTerminalSession(ctx, conn, cancel, nil)
+				_, err := session.Read(make([]byte, 16))
+				assert.Equal(t, io.EOF, err)
+				assert.Error(t, ctx.Err())
+				assert.Nil(t, session.Next())
+			})
+
+			assert.NoError(t, ws.WriteMessage(websocket.TextMessage, []byte(tc.message)))
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Terminal session did not end")
+			}
+		})
+	}
+}
+
+func Test_TerminalWriterHoldsBackIncompleteCharacters(t *testing.T) {
+	ws := dialStreamServer(t, func(conn *websocket.Conn) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stdout := NewTerminalSession(ctx, conn, cancel, nil).Stdout()
+		_, _ = stdout.Write([]byte("caf\xc3"))
+		_, _ = stdout.Write([]byte("\xa9!"))
+	})
+
+	messages := readTerminalMessages(t, ws, 2)
+	assert.Equal(t, "caf", messages[0].Data)
+	assert.Equal(t, "é!", messages[1].Data)
+}
@@ -8,6 +8,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"net/http"
 	"net/url"
+	"time"
 
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
 	"github.com/dana-team/platform-backend/src/controllers"
@@ -15,16 +16,38 @@ import (
 	"github.com/dana-team/platform-backend/src/utils/testutils/mocks"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	knativeapis "knative.dev/pkg/apis"
 )
 
 func getCappClusterDomain(site string) string {
 	return fmt.Sprintf("apps.%s.os-pub.com", site)
 }
 
+// getCappSummary returns the expected summary of an existing Capp created by createTestCapp.
+func getCappSummary(name, namespace string) types.CappSummary {
+	capp := getCapp(k8sClient, name, namespace)
+	summary := types.CappSummary{
+		Name:                name,
+		Images:              []string{CappImageName},
+		URL:                 fmt.Sprintf("https://%s-%s.%s", name, namespace, getCappClusterDomain(capp.Status.ApplicationLinks.Site)),
+		State:               capp.Spec.State,
+		Site:                capp.Status.ApplicationLinks.Site,
+		LatestReadyRevision: capp.Status.KnativeObjectStatus.LatestReadyRevisionName,
+		CreationTimestamp:   capp.CreationTimestamp.UTC().Format(time.RFC3339),
+		ScaleMetric:         capp.Spec.ScaleMetric,
+	}
+
+	if readyCondition := capp.Status.KnativeObjectStatus.GetCondition(knativeapis.ConditionReady); readyCondition != nil {
+		summary.ReadyStatus = string(readyCondition.Status)
+		summary.ReadyReason = readyCondition.Reason
+	}
+
+	return summary
+}
+
 var _ = Describe("Validate Capp routes and functionality", func() {
 	var namespaceName, oneCappName, secondCappName string
 	var oneLabelKey, oneLabelValue, secondLabelKey, secondLabelValue string
-	var oneCappSite, secondCappSite string
 
 	BeforeEach(func() {
 		namespaceName = generateName(e2eNamespace)
@@ -33,14 +56,12 @@ var _ = Describe("Validate Capp routes and functionality", func() {
 		oneCappName = generateName("a-" + testCappName)
 		oneLabelKey = generateName(e2eLabelKey)
 		oneLabelValue = generateName(e2eLabelValue)
-		oneCapp := createTestCapp(k8sClient, oneCappName, namespaceName, map[string]string{oneLabelKey: oneLabelValue}, nil)
-		oneCappSite = oneCapp.Status.ApplicationLinks.Site
+		createTestCapp(k8sClient, oneCappName, namespaceName, map[string]string{oneLabelKey: oneLabelValue}, nil)
 
 		secondCappName = generateName("b-" + testCappName)
 		secondLabelKey = generateName(e2eLabelKey)
 		secondLabelValue = generateName(e2eLabelValue)
-		secondCapp := createTestCapp(k8sClient, secondCappName, namespaceName, map[string]string{secondLabelKey: secondLabelValue}, nil)
-		secondCappSite = secondCapp.Status.ApplicationLinks.Site
+		createTestCapp(k8sClient, secondCappName, namespaceName, map[string]string{secondLabelKey: secondLabelValue}, nil)
 	})
 
 	Context("Validate get Capps route", func() {
@@ -50,8 +71,8 @@ var _ = Describe("Validate Capp routes and functionality", func() {
 
 			expectedResponse := map[string]interface{}{
 				testutils.CappsKey: []types.CappSummary{
-					{Name: oneCappName, Images: []string{CappImageName}, URL: fmt.Sprintf("https://%s-%s.%s", oneCappName, namespaceName, getCappClusterDomain(oneCappSite))},
-					{Name: secondCappName, Images: []string{CappImageName}, URL: fmt.Sprintf("https://%s-%s.%s", secondCappName, namespaceName, getCappClusterDomain(secondCappSite))},
+					getCappSummary(oneCappName, namespaceName),
+					getCappSummary(secondCappName, namespaceName),
 				},
 				testutils.CountKey: 2,
 			}
@@ -69,8 +90,8 @@ var _ = Describe("Validate Capp routes and functionality", func() {
 
 			expectedResponse := map[string]interface{}{
 				testutils.CappsKey: []types.CappSummary{
-					{Name: oneCappName, Images: []string{CappImageName}, URL: fmt.Sprintf("https://%s-%s.%s", oneCappName, namespaceName, getCappClusterDomain(oneCappSite))},
-					{Name: secondCappName, Images: []string{CappImageName}, URL: fmt.Sprintf("https://%s-%s.%s", secondCappName, namespaceName, getCappClusterDomain(secondCappSite))},
+					getCappSummary(oneCappName, namespaceName),
+					getCappSummary(secondCappName, namespaceName),
 				},
 				testutils.CountKey: 2,
 			}
@@ -88,7 +109,30 @@ var _ = Describe("Validate Capp routes and functionality", func() {
 
 			expectedResponse := map[string]interface{}{
 				testutils.CappsKey: []types.CappSummary{
-					{Name: oneCappName, Images: []string{CappImageName}, URL: fmt.Sprintf("https://%s-%s.%s", oneCappName, namespaceName, getCappClusterDomain(oneCappSite))},
+					getCappSummary(oneCappName, namespaceName),
+				},
+				testutils.CountKey: 1,
+			}
+
+			Expect(status).Should(Equal(http.StatusOK))
+			expectMoreItems(response)
+			compareResponses(response, expectedResponse)
+		})
+
+		It("Should get the next Capp in a namespace using the continue token", func() {
+			limit := "1"
+
+			uri := fmt.Sprintf("%s/v1/namespaces/%s/%s?limit=%s", platformURL, namespaceName, testutils.CappsKey, limit)
+			status, response := performHTTPRequest(httpClient, nil, http.MethodGet, uri, "", "", userToken)
+			Expect(status).Should(Equal(http.StatusOK))
+			continueToken := expectMoreItems(response)
+
+			uri = fmt.Sprintf("%s/v1/namespaces/%s/%s?limit=%s&continue=%s", platformURL, namespaceName, testutils.CappsKey, limit, url.QueryEscape(continueToken))
+			status, response = performHTTPRequest(httpClient, nil, http.MethodGet, uri, "", "", userToken)
+
+			expectedResponse := map[string]interface{}{
+				testutils.CappsKey: []types.CappSummary{
+					getCappSummary(secondCappName, namespaceName),
 				},
 				testutils.CountKey: 1,
 			}
@@ -106,7 +150,7 @@ var _ = Describe("Validate Capp routes and functionality", func() {
 
 			expectedResponse := map[string]interface{}{
 				testutils.CappsKey: []types.CappSummary{
-					{Name: secondCappName, Images: []string{CappImageName}, URL: fmt.Sprintf("https://%s-%s.%s", secondCappName, namespaceName, getCappClusterDomain(secondCappSite))},
+					getCappSummary(secondCappName, namespaceName),
 				},
 				testutils.CountKey: 1,
 			}
@@ -140,7 +184,7 @@ var _ = Describe("Validate Capp routes and functionality", func() {
 
 			expectedResponse := map[string]interface{}{
 				testutils.CappsKey: []types.CappSummary{
-					{Name: secondCappName, Images: []string{CappImageName}, URL: fmt.Sprintf("https://%s-%s.%s", secondCappName, namespaceName, getCappClusterDomain(secondCappSite))},
+					getCappSummary(secondCappName, namespaceName),
 				},
 				testutils.CountKey: 1,
 			}
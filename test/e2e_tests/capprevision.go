@@ -242,6 +242,7 @@ var _ = Describe("Validate CappRevision routes and functionality", func() {
 			}
 
 			Expect(status).Should(Equal(http.StatusOK))
+			expectMoreItems(response)
 			compareResponses(response, expectedResponse)
 		})
 
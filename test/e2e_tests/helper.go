@@ -67,6 +67,21 @@ func compareResponses(response, expectedResponse map[string]interface{}) {
 	Expect(response).Should(BeComparableTo(expectedResponseNormalized))
 }
 
+// expectMoreItems asserts that the response of a paginated list has more items and returns its continue token.
+// The cursor fields are removed from the response so that the rest of it can be compared.
+func expectMoreItems(response map[string]interface{}) string {
+	Expect(response[testutils.HasMoreKey]).Should(BeTrue())
+	continueToken, ok := response[testutils.ContinueKey].(string)
+	Expect(ok).Should(BeTrue())
+	Expect(continueToken).ShouldNot(BeEmpty())
+
+	delete(response, testutils.HasMoreKey)
+	delete(response, testutils.ContinueKey)
+	delete(response, testutils.RemainingItemCountKey)
+
+	return continueToken
+}
+
 // compareError compares two errors and asserts that the response contains the expected response.
 func compareError(expectedResponse, response map[string]interface{}) {
 	expectedError, expectedResponseHasError := expectedResponse[testutils.ErrorKey]
@@ -101,6 +101,7 @@ var _ = Describe("Validate Secret routes and functionality", func() {
 			}
 
 			Expect(status).Should(Equal(http.StatusOK))
+			expectMoreItems(response)
 			compareResponses(response, expectedResponse)
 		})
 